@@ -13,28 +13,19 @@ import (
 )
 
 func ProtoLoadBalancerToLoadBalancer(dpdkLB *proto.GetLoadBalancerResponse, lbID string) (*LoadBalancer, error) {
-
-	var underlayRoute netip.Addr
-	if underlayRouteString := string(dpdkLB.GetUnderlayRoute()); underlayRouteString != "" {
-		var err error
-		underlayRoute, err = netip.ParseAddr(string(dpdkLB.GetUnderlayRoute()))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing underlay ip: %w", err)
-		}
+	underlayRoute, err := parseOptionalResponseAddr("underlay ip", dpdkLB.GetUnderlayRoute())
+	if err != nil {
+		return nil, err
 	}
-	var lbip netip.Addr
-	if lbipString := string(dpdkLB.GetLoadbalancedIp().GetAddress()); lbipString != "" {
-		var err error
-		lbip, err = netip.ParseAddr(string(dpdkLB.GetLoadbalancedIp().GetAddress()))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing lb ip: %w", err)
-		}
+	lbip, err := parseOptionalResponseAddr("lb ip", dpdkLB.GetLoadbalancedIp().GetAddress())
+	if err != nil {
+		return nil, err
 	}
-	var lbports = make([]LBPort, 0, len(dpdkLB.LoadbalancedPorts))
+	var lbports = make([]LBPort, 0, len(dpdkLB.GetLoadbalancedPorts()))
 	var p LBPort
-	for _, lbport := range dpdkLB.LoadbalancedPorts {
-		p.Protocol = uint32(lbport.Protocol)
-		p.Port = lbport.Port
+	for _, lbport := range dpdkLB.GetLoadbalancedPorts() {
+		p.Protocol = uint32(lbport.GetProtocol())
+		p.Port = lbport.GetPort()
 		lbports = append(lbports, p)
 	}
 
@@ -46,30 +37,21 @@ func ProtoLoadBalancerToLoadBalancer(dpdkLB *proto.GetLoadBalancerResponse, lbID
 			ID: lbID,
 		},
 		Spec: LoadBalancerSpec{
-			VNI:           dpdkLB.Vni,
+			VNI:           dpdkLB.GetVni(),
 			LbVipIP:       &lbip,
 			Lbports:       lbports,
 			UnderlayRoute: &underlayRoute,
 		},
-		Status: Status{
-			Code:    dpdkLB.Status.Code,
-			Message: dpdkLB.Status.Message,
-		},
+		Status: ProtoStatusToStatus(dpdkLB.GetStatus()),
 	}, nil
 }
 
 func StringLbportToLbport(lbport string) (LBPort, error) {
 	p := strings.Split(lbport, "/")
-	protocolName := strings.ToLower(p[0])
-	switch protocolName {
-	case "icmp", "tcp", "udp", "sctp":
-		protocolName = strings.ToUpper(protocolName)
-	case "icmpv6":
-		protocolName = "ICMPv6"
-	default:
-		return LBPort{}, fmt.Errorf("unsupported protocol")
+	protocol, err := ParseProtocol(p[0])
+	if err != nil {
+		return LBPort{}, err
 	}
-	protocol := proto.Protocol_value[protocolName]
 	port, err := strconv.Atoi(p[1])
 	if err != nil {
 		return LBPort{}, fmt.Errorf("error parsing port number: %w", err)
@@ -78,23 +60,22 @@ func StringLbportToLbport(lbport string) (LBPort, error) {
 }
 
 func ProtoInterfaceToInterface(dpdkIface *proto.Interface) (*Interface, error) {
-	var underlayRoute netip.Addr
-	if underlayRouteString := string(dpdkIface.GetUnderlayRoute()); underlayRouteString != "" {
-		var err error
-		underlayRoute, err = netip.ParseAddr(string(dpdkIface.GetUnderlayRoute()))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing underlay ip: %w", err)
-		}
+	if dpdkIface == nil {
+		return nil, MissingField("interface")
+	}
+	underlayRoute, err := parseOptionalResponseAddr("underlay ip", dpdkIface.GetUnderlayRoute())
+	if err != nil {
+		return nil, err
 	}
 
-	primaryIpv4, err := netip.ParseAddr(string(dpdkIface.GetPrimaryIpv4()))
+	primaryIpv4, err := ParseResponseAddr("primary ipv4", dpdkIface.GetPrimaryIpv4())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing primary ipv4: %w", err)
+		return nil, err
 	}
 
-	primaryIpv6, err := netip.ParseAddr(string(dpdkIface.GetPrimaryIpv6()))
+	primaryIpv6, err := ParseResponseAddr("primary ipv6", dpdkIface.GetPrimaryIpv6())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing primary ipv6: %w", err)
+		return nil, err
 	}
 
 	return &Interface{
@@ -102,7 +83,7 @@ func ProtoInterfaceToInterface(dpdkIface *proto.Interface) (*Interface, error) {
 			Kind: InterfaceKind,
 		},
 		InterfaceMeta: InterfaceMeta{
-			ID: string(dpdkIface.Id),
+			ID: string(dpdkIface.GetId()),
 		},
 		Spec: InterfaceSpec{
 			VNI:           dpdkIface.GetVni(),
@@ -134,9 +115,9 @@ func NetIPAddrToProtoIpAddress(addr *netip.Addr) *proto.IpAddress {
 }
 
 func ProtoIpAddressToNetIPAddr(protoIP *proto.IpAddress) (*netip.Addr, error) {
-	ip, err := netip.ParseAddr(string(protoIP.GetAddress()))
+	ip, err := ParseResponseAddr("IP address", protoIP.GetAddress())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing IP address: %w", err)
+		return nil, err
 	}
 	return &ip, nil
 }
@@ -163,14 +144,14 @@ func NetIPAddrToProtoIPConfig(addr *netip.Addr) *proto.IpConfig {
 }
 
 func ProtoVirtualIPToVirtualIP(interfaceID string, dpdkVIP *proto.GetVipResponse) (*VirtualIP, error) {
-	ip, err := netip.ParseAddr(string(dpdkVIP.GetVipIp().GetAddress()))
+	ip, err := ParseResponseAddr("virtual ip address", dpdkVIP.GetVipIp().GetAddress())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing virtual ip address: %w", err)
+		return nil, err
 	}
 
-	underlayRoute, err := netip.ParseAddr(string(dpdkVIP.GetUnderlayRoute()))
+	underlayRoute, err := ParseResponseAddr("underlay route", dpdkVIP.GetUnderlayRoute())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing underlay route: %w", err)
+		return nil, err
 	}
 
 	return &VirtualIP{
@@ -184,21 +165,21 @@ func ProtoVirtualIPToVirtualIP(interfaceID string, dpdkVIP *proto.GetVipResponse
 			IP:            &ip,
 			UnderlayRoute: &underlayRoute,
 		},
-		Status: ProtoStatusToStatus(dpdkVIP.Status),
+		Status: ProtoStatusToStatus(dpdkVIP.GetStatus()),
 	}, nil
 }
 
 func ProtoPrefixToPrefix(interfaceID string, dpdkPrefix *proto.Prefix) (*Prefix, error) {
-	addr, err := netip.ParseAddr(string(dpdkPrefix.GetIp().Address))
+	addr, err := ParseResponseAddr("dpdk prefix address", dpdkPrefix.GetIp().GetAddress())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing dpdk prefix address: %w", err)
+		return nil, err
 	}
 
 	prefix := netip.PrefixFrom(addr, int(dpdkPrefix.GetLength()))
 
-	underlayRoute, err := netip.ParseAddr(string(dpdkPrefix.UnderlayRoute))
+	underlayRoute, err := ParseResponseAddr("underlay route", dpdkPrefix.GetUnderlayRoute())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing underlay route: %w", err)
+		return nil, err
 	}
 
 	return &Prefix{
@@ -216,16 +197,16 @@ func ProtoPrefixToPrefix(interfaceID string, dpdkPrefix *proto.Prefix) (*Prefix,
 }
 
 func ProtoRouteToRoute(vni uint32, dpdkRoute *proto.Route) (*Route, error) {
-	prefixAddr, err := netip.ParseAddr(string(dpdkRoute.GetPrefix().Ip.GetAddress()))
+	prefixAddr, err := ParseResponseAddr("prefix address", dpdkRoute.GetPrefix().GetIp().GetAddress())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing prefix address: %w", err)
+		return nil, err
 	}
 
 	prefix := netip.PrefixFrom(prefixAddr, int(dpdkRoute.GetPrefix().GetLength()))
 
-	nextHopIP, err := netip.ParseAddr(string(dpdkRoute.NexthopAddress.GetAddress()))
+	nextHopIP, err := ParseResponseAddr("next hop address", dpdkRoute.GetNexthopAddress().GetAddress())
 	if err != nil {
-		return nil, fmt.Errorf("error parsing netxt hop address: %w", err)
+		return nil, err
 	}
 
 	return &Route{
@@ -252,21 +233,13 @@ func ProtoLBPrefixToProtoPrefix(lbprefix *proto.Prefix) *proto.Prefix {
 }
 
 func ProtoNatToNat(dpdkNat *proto.GetNatResponse, interfaceID string) (*Nat, error) {
-	var underlayRoute netip.Addr
-	if underlayRouteString := string(dpdkNat.GetUnderlayRoute()); underlayRouteString != "" {
-		var err error
-		underlayRoute, err = netip.ParseAddr(string(dpdkNat.GetUnderlayRoute()))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing underlay ip: %w", err)
-		}
+	underlayRoute, err := parseOptionalResponseAddr("underlay ip", dpdkNat.GetUnderlayRoute())
+	if err != nil {
+		return nil, err
 	}
-	var natip netip.Addr
-	if natvipipString := string(dpdkNat.GetNatIp().GetAddress()); natvipipString != "" {
-		var err error
-		natip, err = netip.ParseAddr(string(dpdkNat.GetNatIp().GetAddress()))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing nat ip: %w", err)
-		}
+	natip, err := parseOptionalResponseAddr("nat ip", dpdkNat.GetNatIp().GetAddress())
+	if err != nil {
+		return nil, err
 	}
 
 	return &Nat{
@@ -278,38 +251,27 @@ func ProtoNatToNat(dpdkNat *proto.GetNatResponse, interfaceID string) (*Nat, err
 		},
 		Spec: NatSpec{
 			NatIP:         &natip,
-			MinPort:       dpdkNat.MinPort,
-			MaxPort:       dpdkNat.MaxPort,
+			MinPort:       dpdkNat.GetMinPort(),
+			MaxPort:       dpdkNat.GetMaxPort(),
 			UnderlayRoute: &underlayRoute,
 		},
-		Status: Status{
-			Code:    dpdkNat.Status.Code,
-			Message: dpdkNat.Status.Message,
-		},
+		Status: ProtoStatusToStatus(dpdkNat.GetStatus()),
 	}, nil
 }
 
 func ProtoFwRuleToFwRule(dpdkFwRule *proto.FirewallRule, interfaceID string) (*FirewallRule, error) {
+	if dpdkFwRule == nil {
+		return nil, MissingField("firewall rule")
+	}
 
-	srcPrefix, err := netip.ParsePrefix(string(dpdkFwRule.GetSourcePrefix().GetIp().GetAddress()) + "/" + strconv.Itoa(int(dpdkFwRule.SourcePrefix.Length)))
+	srcPrefix, err := parseResponsePrefix("source prefix", dpdkFwRule.GetSourcePrefix())
 	if err != nil {
-		return nil, fmt.Errorf("error converting prefix: %w", err)
+		return nil, err
 	}
 
-	dstPrefix, err := netip.ParsePrefix(string(dpdkFwRule.GetDestinationPrefix().GetIp().GetAddress()) + "/" + strconv.Itoa(int(dpdkFwRule.DestinationPrefix.Length)))
+	dstPrefix, err := parseResponsePrefix("destination prefix", dpdkFwRule.GetDestinationPrefix())
 	if err != nil {
-		return nil, fmt.Errorf("error converting prefix: %w", err)
-	}
-	var direction, action string
-	if dpdkFwRule.Direction == 0 {
-		direction = "Ingress"
-	} else {
-		direction = "Egress"
-	}
-	if dpdkFwRule.Action == 0 {
-		action = "Drop"
-	} else {
-		action = "Accept"
+		return nil, err
 	}
 
 	return &FirewallRule{
@@ -318,13 +280,13 @@ func ProtoFwRuleToFwRule(dpdkFwRule *proto.FirewallRule, interfaceID string) (*F
 			InterfaceID: interfaceID,
 		},
 		Spec: FirewallRuleSpec{
-			RuleID:            string(dpdkFwRule.Id),
-			TrafficDirection:  direction,
-			FirewallAction:    action,
-			Priority:          dpdkFwRule.Priority,
+			RuleID:            string(dpdkFwRule.GetId()),
+			TrafficDirection:  TrafficDirection(dpdkFwRule.GetDirection()).String(),
+			FirewallAction:    FirewallAction(dpdkFwRule.GetAction()).String(),
+			Priority:          dpdkFwRule.GetPriority(),
 			SourcePrefix:      &srcPrefix,
 			DestinationPrefix: &dstPrefix,
-			ProtocolFilter:    dpdkFwRule.ProtocolFilter,
+			ProtocolFilter:    dpdkFwRule.GetProtocolFilter(),
 		},
 	}, nil
 }
@@ -411,3 +373,296 @@ func ProtoMeteringParamsToInterfaceMeteringParams(meteringParams *proto.Metering
 		PublicRate: meteringParams.PublicRate,
 	}
 }
+
+func NetIPPrefixToProtoPrefix(prefix netip.Prefix) *proto.Prefix {
+	addr := prefix.Addr()
+	return &proto.Prefix{
+		Ip:     NetIPAddrToProtoIpAddress(&addr),
+		Length: uint32(prefix.Bits()),
+	}
+}
+
+func LoadBalancerToProtoCreateRequest(lb *LoadBalancer) *proto.CreateLoadBalancerRequest {
+	var lbPorts = make([]*proto.LbPort, 0, len(lb.Spec.Lbports))
+	for _, p := range lb.Spec.Lbports {
+		lbPorts = append(lbPorts, &proto.LbPort{Port: p.Port, Protocol: Protocol(p.Protocol).Proto()})
+	}
+	return &proto.CreateLoadBalancerRequest{
+		LoadbalancerId:    []byte(lb.ID),
+		Vni:               lb.Spec.VNI,
+		LoadbalancedIp:    NetIPAddrToProtoIpAddress(lb.Spec.LbVipIP),
+		LoadbalancedPorts: lbPorts,
+	}
+}
+
+func LoadBalancerPrefixToProtoCreateRequest(lbprefix *LoadBalancerPrefix) *proto.CreateLoadBalancerPrefixRequest {
+	return &proto.CreateLoadBalancerPrefixRequest{
+		InterfaceId: []byte(lbprefix.InterfaceID),
+		Prefix:      NetIPPrefixToProtoPrefix(lbprefix.Spec.Prefix),
+	}
+}
+
+func LoadBalancerTargetToProtoCreateRequest(lbtarget *LoadBalancerTarget) *proto.CreateLoadBalancerTargetRequest {
+	return &proto.CreateLoadBalancerTargetRequest{
+		LoadbalancerId: []byte(lbtarget.LoadbalancerID),
+		TargetIp:       NetIPAddrToProtoIpAddress(lbtarget.Spec.TargetIP),
+	}
+}
+
+func InterfaceToProtoCreateRequest(iface *Interface) *proto.CreateInterfaceRequest {
+	req := &proto.CreateInterfaceRequest{
+		InterfaceType:      InterfaceTypeVirtual.Proto(),
+		InterfaceId:        []byte(iface.ID),
+		Vni:                iface.Spec.VNI,
+		Ipv4Config:         NetIPAddrToProtoIPConfig(iface.Spec.IPv4),
+		Ipv6Config:         NetIPAddrToProtoIPConfig(iface.Spec.IPv6),
+		DeviceName:         iface.Spec.Device,
+		MeteringParameters: InterfaceMeteringParamsToProtoMeteringParams(iface.Spec.Metering),
+	}
+	if iface.Spec.PXE != nil {
+		if iface.Spec.PXE.FileName != "" && iface.Spec.PXE.Server != "" {
+			req.PxeConfig = &proto.PxeConfig{NextServer: iface.Spec.PXE.Server, BootFilename: iface.Spec.PXE.FileName}
+		}
+	}
+	return req
+}
+
+func VirtualIPToProtoCreateRequest(virtualIP *VirtualIP) *proto.CreateVipRequest {
+	return &proto.CreateVipRequest{
+		InterfaceId: []byte(virtualIP.InterfaceID),
+		VipIp:       NetIPAddrToProtoIpAddress(virtualIP.Spec.IP),
+	}
+}
+
+func PrefixToProtoCreateRequest(prefix *Prefix) *proto.CreatePrefixRequest {
+	return &proto.CreatePrefixRequest{
+		InterfaceId: []byte(prefix.InterfaceID),
+		Prefix:      NetIPPrefixToProtoPrefix(prefix.Spec.Prefix),
+	}
+}
+
+func RouteToProtoRoute(route *Route) (*proto.Route, error) {
+	if route.Spec.Prefix == nil {
+		return nil, fmt.Errorf("prefix needs to be specified")
+	}
+	if route.Spec.NextHop == nil {
+		return nil, fmt.Errorf("nextHop needs to be specified")
+	}
+	return &proto.Route{
+		Weight:         100,
+		Prefix:         NetIPPrefixToProtoPrefix(*route.Spec.Prefix),
+		NexthopVni:     route.Spec.NextHop.VNI,
+		NexthopAddress: NetIPAddrToProtoIpAddress(route.Spec.NextHop.IP),
+	}, nil
+}
+
+func RouteToProtoCreateRequest(route *Route) (*proto.CreateRouteRequest, error) {
+	protoRoute, err := RouteToProtoRoute(route)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.CreateRouteRequest{
+		Vni:   route.VNI,
+		Route: protoRoute,
+	}, nil
+}
+
+func NatToProtoCreateRequest(nat *Nat) *proto.CreateNatRequest {
+	return &proto.CreateNatRequest{
+		InterfaceId: []byte(nat.InterfaceID),
+		NatIp:       NetIPAddrToProtoIpAddress(nat.Spec.NatIP),
+		MinPort:     nat.Spec.MinPort,
+		MaxPort:     nat.Spec.MaxPort,
+	}
+}
+
+func NeighborNatToProtoCreateRequest(nNat *NeighborNat) (*proto.CreateNeighborNatRequest, error) {
+	if nNat.Spec.UnderlayRoute == nil {
+		return nil, fmt.Errorf("underlayRoute needs to be specified")
+	}
+	return &proto.CreateNeighborNatRequest{
+		NatIp:         NetIPAddrToProtoIpAddress(nNat.NatIP),
+		Vni:           nNat.Spec.Vni,
+		MinPort:       nNat.Spec.MinPort,
+		MaxPort:       nNat.Spec.MaxPort,
+		UnderlayRoute: []byte(nNat.Spec.UnderlayRoute.String()),
+	}, nil
+}
+
+func StringToProtoFirewallAction(action string) (proto.FirewallAction, error) {
+	a, err := ParseFirewallAction(action)
+	return a.Proto(), err
+}
+
+func StringToProtoTrafficDirection(direction string) (proto.TrafficDirection, error) {
+	d, err := ParseTrafficDirection(direction)
+	return d.Proto(), err
+}
+
+// ICMP and ICMPv6 types for filters of ping rules.
+const (
+	ICMPEchoReply     = 0
+	ICMPEchoRequest   = 8
+	ICMPv6EchoRequest = 128
+	ICMPv6EchoReply   = 129
+)
+
+// ICMPFilter returns a protocol filter for ICMP. -1 matches all types or codes.
+func ICMPFilter(icmpType, icmpCode int32) *proto.ProtocolFilter {
+	return &proto.ProtocolFilter{Filter: &proto.ProtocolFilter_Icmp{Icmp: &proto.IcmpFilter{IcmpType: icmpType, IcmpCode: icmpCode}}}
+}
+
+// ICMPv6Filter returns a protocol filter for ICMPv6, e.g. ICMPv6Filter(ICMPv6EchoRequest, -1)
+// for ping6. dpservice has a single ICMP filter, which matches ICMPv6 in rules with IPv6
+// prefixes, so the filter equals the one of ICMPFilter.
+func ICMPv6Filter(icmpType, icmpCode int32) *proto.ProtocolFilter {
+	return ICMPFilter(icmpType, icmpCode)
+}
+
+// TCPFilter returns a protocol filter for TCP. A lower port of -1 matches all ports.
+func TCPFilter(srcPortLower, srcPortUpper, dstPortLower, dstPortUpper int32) *proto.ProtocolFilter {
+	return &proto.ProtocolFilter{Filter: &proto.ProtocolFilter_Tcp{Tcp: &proto.TcpFilter{
+		SrcPortLower: srcPortLower, SrcPortUpper: srcPortUpper, DstPortLower: dstPortLower, DstPortUpper: dstPortUpper,
+	}}}
+}
+
+// UDPFilter returns a protocol filter for UDP. A lower port of -1 matches all ports.
+func UDPFilter(srcPortLower, srcPortUpper, dstPortLower, dstPortUpper int32) *proto.ProtocolFilter {
+	return &proto.ProtocolFilter{Filter: &proto.ProtocolFilter_Udp{Udp: &proto.UdpFilter{
+		SrcPortLower: srcPortLower, SrcPortUpper: srcPortUpper, DstPortLower: dstPortLower, DstPortUpper: dstPortUpper,
+	}}}
+}
+
+// ProtocolNumberToProtoFilter returns the protocol filter matching all traffic of an IP
+// protocol number. Protocol 0 returns a nil filter, which matches all protocols. dpservice
+// only filters ICMP, ICMPv6, TCP and UDP, other protocols return an error.
+func ProtocolNumberToProtoFilter(protocol uint8) (*proto.ProtocolFilter, error) {
+	switch int32(protocol) {
+	case 0:
+		return nil, nil
+	case int32(proto.Protocol_ICMP), int32(proto.Protocol_ICMPV6):
+		return ICMPFilter(-1, -1), nil
+	case int32(proto.Protocol_TCP):
+		return TCPFilter(-1, -1, -1, -1), nil
+	case int32(proto.Protocol_UDP):
+		return UDPFilter(-1, -1, -1, -1), nil
+	default:
+		return nil, fmt.Errorf("protocol %d is not supported by firewall filters", protocol)
+	}
+}
+
+// ProtoFilterToProtocolNumber returns the IP protocol number filter matches, 0 for a nil
+// filter. ICMP filters of rules with IPv6 prefixes match ICMPv6.
+func ProtoFilterToProtocolNumber(filter *proto.ProtocolFilter, ipv6 bool) uint8 {
+	switch filter.GetFilter().(type) {
+	case *proto.ProtocolFilter_Icmp:
+		if ipv6 {
+			return uint8(proto.Protocol_ICMPV6)
+		}
+		return uint8(proto.Protocol_ICMP)
+	case *proto.ProtocolFilter_Tcp:
+		return uint8(proto.Protocol_TCP)
+	case *proto.ProtocolFilter_Udp:
+		return uint8(proto.Protocol_UDP)
+	default:
+		return 0
+	}
+}
+
+// ValidateProtocolFilter checks the ranges of the types, codes and ports of filter the way
+// dpservice does, so invalid rules can be rejected before they are sent.
+func ValidateProtocolFilter(filter *proto.ProtocolFilter) error {
+	switch f := filter.GetFilter().(type) {
+	case *proto.ProtocolFilter_Icmp:
+		if t := f.Icmp.GetIcmpType(); t < -1 || t > 255 {
+			return fmt.Errorf("invalid icmp type %d", t)
+		}
+		if c := f.Icmp.GetIcmpCode(); c < -1 || c > 255 {
+			return fmt.Errorf("invalid icmp code %d", c)
+		}
+	case *proto.ProtocolFilter_Tcp:
+		return validatePortFilter("tcp", f.Tcp.GetSrcPortLower(), f.Tcp.GetSrcPortUpper(), f.Tcp.GetDstPortLower(), f.Tcp.GetDstPortUpper())
+	case *proto.ProtocolFilter_Udp:
+		return validatePortFilter("udp", f.Udp.GetSrcPortLower(), f.Udp.GetSrcPortUpper(), f.Udp.GetDstPortLower(), f.Udp.GetDstPortUpper())
+	}
+	return nil
+}
+
+func validatePortFilter(protocol string, srcLower, srcUpper, dstLower, dstUpper int32) error {
+	for _, ports := range []struct {
+		name         string
+		lower, upper int32
+	}{
+		{"source", srcLower, srcUpper},
+		{"destination", dstLower, dstUpper},
+	} {
+		if ports.lower < -1 || ports.lower > 65535 || ports.upper < -1 || ports.upper > 65535 || ports.lower > ports.upper {
+			return fmt.Errorf("invalid %s %s port range %d-%d", protocol, ports.name, ports.lower, ports.upper)
+		}
+	}
+	return nil
+}
+
+// FirewallRuleTagSeparator separates the tags encoded in firewall rule IDs from the rule name.
+const FirewallRuleTagSeparator = "@"
+
+// FirewallRuleIDWithTags returns the rule ID of name tagged with tags, e.g. "allow-ssh@policy-a".
+// dpservice stores no metadata with firewall rules, so the tags are part of the ID and
+// survive restarts of the clients managing the rules. Neither name nor tags may contain
+// FirewallRuleTagSeparator.
+func FirewallRuleIDWithTags(name string, tags ...string) (string, error) {
+	if name == "" || strings.Contains(name, FirewallRuleTagSeparator) {
+		return "", fmt.Errorf("invalid firewall rule name %q", name)
+	}
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, FirewallRuleTagSeparator) {
+			return "", fmt.Errorf("invalid firewall rule tag %q", tag)
+		}
+	}
+	return strings.Join(append([]string{name}, tags...), FirewallRuleTagSeparator), nil
+}
+
+// FirewallRuleIDTags splits a rule ID created by FirewallRuleIDWithTags into the rule name
+// and its tags. IDs without tags return the ID as name.
+func FirewallRuleIDTags(ruleID string) (name string, tags []string) {
+	parts := strings.Split(ruleID, FirewallRuleTagSeparator)
+	return parts[0], parts[1:]
+}
+
+func FwRuleToProtoRule(fwRule *FirewallRule) (*proto.FirewallRule, error) {
+	action, err := ParseFirewallAction(fwRule.Spec.FirewallAction)
+	if err != nil {
+		return nil, err
+	}
+	direction, err := ParseTrafficDirection(fwRule.Spec.TrafficDirection)
+	if err != nil {
+		return nil, err
+	}
+	if fwRule.Spec.SourcePrefix == nil {
+		return nil, fmt.Errorf("source prefix needs to be specified")
+	}
+	if fwRule.Spec.DestinationPrefix == nil {
+		return nil, fmt.Errorf("destination prefix needs to be specified")
+	}
+
+	return &proto.FirewallRule{
+		Id:                []byte(fwRule.Spec.RuleID),
+		Direction:         direction.Proto(),
+		Action:            action.Proto(),
+		Priority:          fwRule.Spec.Priority,
+		SourcePrefix:      NetIPPrefixToProtoPrefix(*fwRule.Spec.SourcePrefix),
+		DestinationPrefix: NetIPPrefixToProtoPrefix(*fwRule.Spec.DestinationPrefix),
+		ProtocolFilter:    fwRule.Spec.ProtocolFilter,
+	}, nil
+}
+
+func FwRuleToProtoCreateRequest(fwRule *FirewallRule) (*proto.CreateFirewallRuleRequest, error) {
+	rule, err := FwRuleToProtoRule(fwRule)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.CreateFirewallRuleRequest{
+		InterfaceId: []byte(fwRule.InterfaceID),
+		Rule:        rule,
+	}, nil
+}
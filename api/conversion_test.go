@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("ToProto conversions", func() {
+	It("should round-trip firewall rules", func() {
+		src := netip.MustParsePrefix("10.0.0.0/8")
+		dst := netip.MustParsePrefix("192.168.1.0/24")
+		fwRule := &FirewallRule{
+			TypeMeta:         TypeMeta{Kind: FirewallRuleKind},
+			FirewallRuleMeta: FirewallRuleMeta{InterfaceID: "vm1"},
+			Spec: FirewallRuleSpec{
+				RuleID:            "rule1",
+				TrafficDirection:  "Egress",
+				FirewallAction:    "Accept",
+				Priority:          1000,
+				SourcePrefix:      &src,
+				DestinationPrefix: &dst,
+				ProtocolFilter: &proto.ProtocolFilter{Filter: &proto.ProtocolFilter_Tcp{Tcp: &proto.TcpFilter{
+					SrcPortLower: 1, SrcPortUpper: 1000, DstPortLower: 443, DstPortUpper: 443,
+				}}},
+			},
+		}
+
+		rule, err := FwRuleToProtoRule(fwRule)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule.Action).To(Equal(proto.FirewallAction_ACCEPT))
+		Expect(rule.Direction).To(Equal(proto.TrafficDirection_EGRESS))
+
+		Expect(ProtoFwRuleToFwRule(rule, "vm1")).To(Equal(fwRule))
+	})
+
+	It("should reject firewall rules with invalid actions", func() {
+		_, err := FwRuleToProtoRule(&FirewallRule{Spec: FirewallRuleSpec{FirewallAction: "maybe"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert protocol numbers to filters and back", func() {
+		for _, protocol := range []uint8{0, 1, 6, 17} {
+			filter, err := ProtocolNumberToProtoFilter(protocol)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ProtoFilterToProtocolNumber(filter, false)).To(Equal(protocol))
+		}
+
+		filter, err := ProtocolNumberToProtoFilter(58)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter.GetIcmp().GetIcmpType()).To(BeEquivalentTo(-1))
+		Expect(ProtoFilterToProtocolNumber(filter, true)).To(BeEquivalentTo(58))
+
+		_, err = ProtocolNumberToProtoFilter(132)
+		Expect(err).To(MatchError("protocol 132 is not supported by firewall filters"))
+	})
+
+	It("should validate protocol filters", func() {
+		Expect(ValidateProtocolFilter(nil)).To(Succeed())
+		Expect(ValidateProtocolFilter(ICMPv6Filter(ICMPv6EchoRequest, -1))).To(Succeed())
+		Expect(ValidateProtocolFilter(TCPFilter(-1, -1, 443, 443))).To(Succeed())
+		Expect(ValidateProtocolFilter(ICMPFilter(256, 0))).To(MatchError("invalid icmp type 256"))
+		Expect(ValidateProtocolFilter(ICMPFilter(0, -2))).To(MatchError("invalid icmp code -2"))
+		Expect(ValidateProtocolFilter(UDPFilter(-1, -1, 500, 400))).To(MatchError("invalid udp destination port range 500-400"))
+		Expect(ValidateProtocolFilter(TCPFilter(0, 70000, -1, -1))).To(MatchError("invalid tcp source port range 0-70000"))
+	})
+
+	It("should encode tags in firewall rule IDs", func() {
+		id, err := FirewallRuleIDWithTags("allow-ssh", "policy-a", "team-b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("allow-ssh@policy-a@team-b"))
+
+		name, tags := FirewallRuleIDTags(id)
+		Expect(name).To(Equal("allow-ssh"))
+		Expect(tags).To(Equal([]string{"policy-a", "team-b"}))
+
+		name, tags = FirewallRuleIDTags("rule1")
+		Expect(name).To(Equal("rule1"))
+		Expect(tags).To(BeEmpty())
+
+		_, err = FirewallRuleIDWithTags("allow-ssh", "a@b")
+		Expect(err).To(MatchError(`invalid firewall rule tag "a@b"`))
+		_, err = FirewallRuleIDWithTags("")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should round-trip routes", func() {
+		prefix := netip.MustParsePrefix("10.0.1.0/24")
+		nextHop := netip.MustParseAddr("fc00::1")
+		route := &Route{
+			TypeMeta:  TypeMeta{Kind: RouteKind},
+			RouteMeta: RouteMeta{VNI: 100},
+			Spec: RouteSpec{
+				Prefix:  &prefix,
+				NextHop: &RouteNextHop{VNI: 200, IP: &nextHop},
+			},
+		}
+
+		req, err := RouteToProtoCreateRequest(route)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Vni).To(Equal(uint32(100)))
+
+		Expect(ProtoRouteToRoute(req.Vni, req.Route)).To(Equal(route))
+	})
+
+	It("should require the prefix of routes", func() {
+		_, err := RouteToProtoRoute(&Route{})
+		Expect(err).To(MatchError("prefix needs to be specified"))
+	})
+
+	It("should round-trip prefixes", func() {
+		underlayRoute := netip.MustParseAddr("fc00::2")
+		prefix := &Prefix{
+			TypeMeta:   TypeMeta{Kind: PrefixKind},
+			PrefixMeta: PrefixMeta{InterfaceID: "vm1"},
+			Spec: PrefixSpec{
+				Prefix:        netip.MustParsePrefix("10.0.2.0/24"),
+				UnderlayRoute: &underlayRoute,
+			},
+		}
+
+		req := PrefixToProtoCreateRequest(prefix)
+		Expect(string(req.InterfaceId)).To(Equal("vm1"))
+		req.Prefix.UnderlayRoute = []byte(underlayRoute.String())
+
+		Expect(ProtoPrefixToPrefix("vm1", req.Prefix)).To(Equal(prefix))
+	})
+
+	It("should convert interfaces to create requests", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		req := InterfaceToProtoCreateRequest(&Interface{
+			InterfaceMeta: InterfaceMeta{ID: "vm1"},
+			Spec: InterfaceSpec{
+				VNI:  100,
+				IPv4: &ipv4,
+				PXE:  &PXE{Server: "10.0.0.2", FileName: "boot.ipxe"},
+			},
+		})
+		Expect(string(req.InterfaceId)).To(Equal("vm1"))
+		Expect(req.Vni).To(Equal(uint32(100)))
+		Expect(string(req.Ipv4Config.PrimaryAddress)).To(Equal("10.0.0.1"))
+		Expect(req.Ipv6Config).To(BeNil())
+		Expect(req.PxeConfig.BootFilename).To(Equal("boot.ipxe"))
+	})
+
+	It("should convert load balancers to create requests", func() {
+		ip := netip.MustParseAddr("10.0.0.10")
+		req := LoadBalancerToProtoCreateRequest(&LoadBalancer{
+			LoadBalancerMeta: LoadBalancerMeta{ID: "lb1"},
+			Spec: LoadBalancerSpec{
+				VNI:     100,
+				LbVipIP: &ip,
+				Lbports: []LBPort{{Protocol: 6, Port: 443}},
+			},
+		})
+		Expect(string(req.LoadbalancerId)).To(Equal("lb1"))
+		Expect(req.LoadbalancedPorts).To(HaveLen(1))
+		Expect(req.LoadbalancedPorts[0].Protocol).To(Equal(proto.Protocol_TCP))
+	})
+})
+
+var _ = Describe("FromProto conversions of truncated responses", func() {
+	It("should not panic on empty responses", func() {
+		lb, err := ProtoLoadBalancerToLoadBalancer(&proto.GetLoadBalancerResponse{}, "lb1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(lb.Status).To(Equal(Status{}))
+
+		nat, err := ProtoNatToNat(&proto.GetNatResponse{}, "vm1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nat.Status).To(Equal(Status{}))
+	})
+
+	It("should report missing required fields", func() {
+		_, err := ProtoInterfaceToInterface(nil)
+		Expect(errors.Is(err, ErrMalformedResponse)).To(BeTrue())
+
+		_, err = ProtoInterfaceToInterface(&proto.Interface{PrimaryIpv4: []byte("10.0.0.1")})
+		Expect(err).To(MatchError("malformed response: error parsing primary ipv6: missing"))
+
+		_, err = ProtoRouteToRoute(100, &proto.Route{})
+		Expect(errors.Is(err, ErrMalformedResponse)).To(BeTrue())
+
+		_, err = ProtoPrefixToPrefix("vm1", &proto.Prefix{Ip: &proto.IpAddress{Address: []byte("10.0.0.0")}, Length: 24})
+		Expect(err).To(MatchError("malformed response: error parsing underlay route: missing"))
+
+		_, err = ProtoFwRuleToFwRule(&proto.FirewallRule{Id: []byte("fw1")}, "vm1")
+		Expect(err).To(MatchError("malformed response: error parsing source prefix: missing"))
+
+		_, err = ProtoFwRuleToFwRule(nil, "vm1")
+		Expect(errors.Is(err, ErrMalformedResponse)).To(BeTrue())
+	})
+
+	It("should report unparsable fields", func() {
+		_, err := ProtoVirtualIPToVirtualIP("vm1", &proto.GetVipResponse{
+			VipIp:         &proto.IpAddress{Address: []byte("10.0.0.1")},
+			UnderlayRoute: []byte("not-an-ip"),
+		})
+		Expect(errors.Is(err, ErrMalformedResponse)).To(BeTrue())
+		var malformed *MalformedResponseError
+		Expect(errors.As(err, &malformed)).To(BeTrue())
+		Expect(malformed.Field).To(Equal("underlay route"))
+	})
+})
@@ -0,0 +1,369 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conversiontest provides canonical dpservice proto and api object pairs for every
+// kind, so alternative conversions, e.g. of forks or the simulator, can be verified against
+// the reference conversions of the api package.
+package conversiontest
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fixture is a canonical conversion of a single object.
+type Fixture struct {
+	// Name identifies the fixture in verification errors.
+	Name string
+	// Proto is the message returned by dpservice that converts to Object, or nil if
+	// dpservice does not return objects of the kind.
+	Proto proto.Message
+	// Object is the api object.
+	Object api.Object
+	// Request is the create request Object converts to.
+	Request proto.Message
+}
+
+// FromProtoFunc converts the Proto of a fixture to an api object. The IDs not contained
+// in the proto, e.g. the interface ID of a prefix, are taken from the Object of the fixture.
+type FromProtoFunc func(f Fixture) (api.Object, error)
+
+// ToProtoFunc converts an api object to its create request.
+type ToProtoFunc func(obj api.Object) (proto.Message, error)
+
+func addr(s string) *netip.Addr {
+	a := netip.MustParseAddr(s)
+	return &a
+}
+
+func prefix(s string) *netip.Prefix {
+	p := netip.MustParsePrefix(s)
+	return &p
+}
+
+func ipv4(s string) *dpdkproto.IpAddress {
+	return &dpdkproto.IpAddress{Ipver: dpdkproto.IpVersion_IPV4, Address: []byte(s)}
+}
+
+func ipv6(s string) *dpdkproto.IpAddress {
+	return &dpdkproto.IpAddress{Ipver: dpdkproto.IpVersion_IPV6, Address: []byte(s)}
+}
+
+// Fixtures returns fresh copies of the canonical fixtures of all kinds.
+func Fixtures() []Fixture {
+	tcpFilter := &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{
+		SrcPortLower: -1, SrcPortUpper: -1, DstPortLower: 443, DstPortUpper: 443,
+	}}}
+
+	return []Fixture{
+		{
+			Name: "Interface",
+			Proto: &dpdkproto.Interface{
+				Id:             []byte("vm1"),
+				Vni:            100,
+				PrimaryIpv4:    []byte("10.0.0.1"),
+				PrimaryIpv6:    []byte("2001:db8::1"),
+				UnderlayRoute:  []byte("fc00:1::1"),
+				PciName:        "net_tap2",
+				MeteringParams: &dpdkproto.MeteringParams{TotalRate: 1000, PublicRate: 100},
+			},
+			Object: &api.Interface{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+				Spec: api.InterfaceSpec{
+					VNI:           100,
+					Device:        "net_tap2",
+					IPv4:          addr("10.0.0.1"),
+					IPv6:          addr("2001:db8::1"),
+					UnderlayRoute: addr("fc00:1::1"),
+					Metering:      &api.MeteringParams{TotalRate: 1000, PublicRate: 100},
+				},
+			},
+			Request: &dpdkproto.CreateInterfaceRequest{
+				InterfaceType:      dpdkproto.InterfaceType_VIRTUAL,
+				InterfaceId:        []byte("vm1"),
+				Vni:                100,
+				Ipv4Config:         &dpdkproto.IpConfig{PrimaryAddress: []byte("10.0.0.1")},
+				Ipv6Config:         &dpdkproto.IpConfig{PrimaryAddress: []byte("2001:db8::1")},
+				DeviceName:         "net_tap2",
+				MeteringParameters: &dpdkproto.MeteringParams{TotalRate: 1000, PublicRate: 100},
+			},
+		},
+		{
+			Name: "VirtualIP",
+			Proto: &dpdkproto.GetVipResponse{
+				Status:        &dpdkproto.Status{},
+				VipIp:         ipv4("20.0.0.1"),
+				UnderlayRoute: []byte("fc00:1::2"),
+			},
+			Object: &api.VirtualIP{
+				TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+				VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm1"},
+				Spec:          api.VirtualIPSpec{IP: addr("20.0.0.1"), UnderlayRoute: addr("fc00:1::2")},
+			},
+			Request: &dpdkproto.CreateVipRequest{
+				InterfaceId: []byte("vm1"),
+				VipIp:       ipv4("20.0.0.1"),
+			},
+		},
+		{
+			Name: "Prefix",
+			Proto: &dpdkproto.Prefix{
+				Ip:            ipv4("10.0.1.0"),
+				Length:        24,
+				UnderlayRoute: []byte("fc00:1::3"),
+			},
+			Object: &api.Prefix{
+				TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+				PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+				Spec:       api.PrefixSpec{Prefix: *prefix("10.0.1.0/24"), UnderlayRoute: addr("fc00:1::3")},
+			},
+			Request: &dpdkproto.CreatePrefixRequest{
+				InterfaceId: []byte("vm1"),
+				Prefix:      &dpdkproto.Prefix{Ip: ipv4("10.0.1.0"), Length: 24},
+			},
+		},
+		{
+			Name: "LoadBalancerPrefix",
+			Object: &api.LoadBalancerPrefix{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+				LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: "vm1"},
+				Spec:                   api.LoadBalancerPrefixSpec{Prefix: *prefix("10.0.2.0/24")},
+			},
+			Request: &dpdkproto.CreateLoadBalancerPrefixRequest{
+				InterfaceId: []byte("vm1"),
+				Prefix:      &dpdkproto.Prefix{Ip: ipv4("10.0.2.0"), Length: 24},
+			},
+		},
+		{
+			Name: "Route",
+			Proto: &dpdkproto.Route{
+				Prefix:         &dpdkproto.Prefix{Ip: ipv4("10.0.3.0"), Length: 24},
+				NexthopVni:     200,
+				NexthopAddress: ipv6("fc00:2::1"),
+				Weight:         100,
+			},
+			Object: &api.Route{
+				TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+				RouteMeta: api.RouteMeta{VNI: 100},
+				Spec: api.RouteSpec{
+					Prefix:  prefix("10.0.3.0/24"),
+					NextHop: &api.RouteNextHop{VNI: 200, IP: addr("fc00:2::1")},
+				},
+			},
+			Request: &dpdkproto.CreateRouteRequest{
+				Vni: 100,
+				Route: &dpdkproto.Route{
+					Prefix:         &dpdkproto.Prefix{Ip: ipv4("10.0.3.0"), Length: 24},
+					NexthopVni:     200,
+					NexthopAddress: ipv6("fc00:2::1"),
+					Weight:         100,
+				},
+			},
+		},
+		{
+			Name: "LoadBalancer",
+			Proto: &dpdkproto.GetLoadBalancerResponse{
+				Status:            &dpdkproto.Status{},
+				LoadbalancedIp:    ipv4("30.0.0.1"),
+				Vni:               100,
+				LoadbalancedPorts: []*dpdkproto.LbPort{{Port: 443, Protocol: dpdkproto.Protocol_TCP}},
+				UnderlayRoute:     []byte("fc00:1::4"),
+			},
+			Object: &api.LoadBalancer{
+				TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+				LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"},
+				Spec: api.LoadBalancerSpec{
+					VNI:           100,
+					LbVipIP:       addr("30.0.0.1"),
+					Lbports:       []api.LBPort{{Protocol: 6, Port: 443}},
+					UnderlayRoute: addr("fc00:1::4"),
+				},
+			},
+			Request: &dpdkproto.CreateLoadBalancerRequest{
+				LoadbalancerId:    []byte("lb1"),
+				LoadbalancedIp:    ipv4("30.0.0.1"),
+				Vni:               100,
+				LoadbalancedPorts: []*dpdkproto.LbPort{{Port: 443, Protocol: dpdkproto.Protocol_TCP}},
+			},
+		},
+		{
+			Name: "LoadBalancerTarget",
+			Object: &api.LoadBalancerTarget{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+				LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb1"},
+				Spec:                   api.LoadBalancerTargetSpec{TargetIP: addr("fc00:1::1")},
+			},
+			Request: &dpdkproto.CreateLoadBalancerTargetRequest{
+				LoadbalancerId: []byte("lb1"),
+				TargetIp:       ipv6("fc00:1::1"),
+			},
+		},
+		{
+			Name: "Nat",
+			Proto: &dpdkproto.GetNatResponse{
+				Status:        &dpdkproto.Status{},
+				NatIp:         ipv4("40.0.0.1"),
+				MinPort:       1024,
+				MaxPort:       2048,
+				UnderlayRoute: []byte("fc00:1::5"),
+			},
+			Object: &api.Nat{
+				TypeMeta: api.TypeMeta{Kind: api.NatKind},
+				NatMeta:  api.NatMeta{InterfaceID: "vm1"},
+				Spec: api.NatSpec{
+					NatIP:         addr("40.0.0.1"),
+					MinPort:       1024,
+					MaxPort:       2048,
+					UnderlayRoute: addr("fc00:1::5"),
+				},
+			},
+			Request: &dpdkproto.CreateNatRequest{
+				InterfaceId: []byte("vm1"),
+				NatIp:       ipv4("40.0.0.1"),
+				MinPort:     1024,
+				MaxPort:     2048,
+			},
+		},
+		{
+			Name: "NeighborNat",
+			Object: &api.NeighborNat{
+				TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+				NeighborNatMeta: api.NeighborNatMeta{NatIP: addr("40.0.0.1")},
+				Spec: api.NeighborNatSpec{
+					Vni:           100,
+					MinPort:       2048,
+					MaxPort:       4096,
+					UnderlayRoute: addr("fc00:2::5"),
+				},
+			},
+			Request: &dpdkproto.CreateNeighborNatRequest{
+				NatIp:         ipv4("40.0.0.1"),
+				Vni:           100,
+				MinPort:       2048,
+				MaxPort:       4096,
+				UnderlayRoute: []byte("fc00:2::5"),
+			},
+		},
+		{
+			Name: "FirewallRule",
+			Proto: &dpdkproto.FirewallRule{
+				Id:                []byte("rule1"),
+				Direction:         dpdkproto.TrafficDirection_INGRESS,
+				Action:            dpdkproto.FirewallAction_ACCEPT,
+				Priority:          1000,
+				SourcePrefix:      &dpdkproto.Prefix{Ip: ipv4("0.0.0.0"), Length: 0},
+				DestinationPrefix: &dpdkproto.Prefix{Ip: ipv4("10.0.0.1"), Length: 32},
+				ProtocolFilter:    tcpFilter,
+			},
+			Object: &api.FirewallRule{
+				TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+				FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"},
+				Spec: api.FirewallRuleSpec{
+					RuleID:            "rule1",
+					TrafficDirection:  "Ingress",
+					FirewallAction:    "Accept",
+					Priority:          1000,
+					SourcePrefix:      prefix("0.0.0.0/0"),
+					DestinationPrefix: prefix("10.0.0.1/32"),
+					ProtocolFilter:    tcpFilter,
+				},
+			},
+			Request: &dpdkproto.CreateFirewallRuleRequest{
+				InterfaceId: []byte("vm1"),
+				Rule: &dpdkproto.FirewallRule{
+					Id:                []byte("rule1"),
+					Direction:         dpdkproto.TrafficDirection_INGRESS,
+					Action:            dpdkproto.FirewallAction_ACCEPT,
+					Priority:          1000,
+					SourcePrefix:      &dpdkproto.Prefix{Ip: ipv4("0.0.0.0"), Length: 0},
+					DestinationPrefix: &dpdkproto.Prefix{Ip: ipv4("10.0.0.1"), Length: 32},
+					ProtocolFilter:    tcpFilter,
+				},
+			},
+		},
+	}
+}
+
+// FromProto is the reference conversion of the api package.
+func FromProto(f Fixture) (api.Object, error) {
+	switch msg := f.Proto.(type) {
+	case *dpdkproto.Interface:
+		return api.ProtoInterfaceToInterface(msg)
+	case *dpdkproto.GetVipResponse:
+		return api.ProtoVirtualIPToVirtualIP(f.Object.(*api.VirtualIP).InterfaceID, msg)
+	case *dpdkproto.Prefix:
+		return api.ProtoPrefixToPrefix(f.Object.(*api.Prefix).InterfaceID, msg)
+	case *dpdkproto.Route:
+		return api.ProtoRouteToRoute(f.Object.(*api.Route).VNI, msg)
+	case *dpdkproto.GetLoadBalancerResponse:
+		return api.ProtoLoadBalancerToLoadBalancer(msg, f.Object.(*api.LoadBalancer).ID)
+	case *dpdkproto.GetNatResponse:
+		return api.ProtoNatToNat(msg, f.Object.(*api.Nat).InterfaceID)
+	case *dpdkproto.FirewallRule:
+		return api.ProtoFwRuleToFwRule(msg, f.Object.(*api.FirewallRule).InterfaceID)
+	default:
+		return nil, fmt.Errorf("unsupported proto %T", f.Proto)
+	}
+}
+
+// ToProto is the reference conversion of the api package.
+func ToProto(obj api.Object) (proto.Message, error) {
+	switch obj := obj.(type) {
+	case *api.Interface:
+		return api.InterfaceToProtoCreateRequest(obj), nil
+	case *api.VirtualIP:
+		return api.VirtualIPToProtoCreateRequest(obj), nil
+	case *api.Prefix:
+		return api.PrefixToProtoCreateRequest(obj), nil
+	case *api.LoadBalancerPrefix:
+		return api.LoadBalancerPrefixToProtoCreateRequest(obj), nil
+	case *api.Route:
+		return api.RouteToProtoCreateRequest(obj)
+	case *api.LoadBalancer:
+		return api.LoadBalancerToProtoCreateRequest(obj), nil
+	case *api.LoadBalancerTarget:
+		return api.LoadBalancerTargetToProtoCreateRequest(obj), nil
+	case *api.Nat:
+		return api.NatToProtoCreateRequest(obj), nil
+	case *api.NeighborNat:
+		return api.NeighborNatToProtoCreateRequest(obj)
+	case *api.FirewallRule:
+		return api.FwRuleToProtoCreateRequest(obj)
+	default:
+		return nil, fmt.Errorf("unsupported object %T", obj)
+	}
+}
+
+// Verify checks the given conversions against all fixtures and returns an errors.Aggregate
+// keyed by fixture name for every mismatch. A nil function skips its direction.
+func Verify(fromProto FromProtoFunc, toProto ToProtoFunc) error {
+	agg := &errors.Aggregate{}
+	for _, f := range Fixtures() {
+		if fromProto != nil && f.Proto != nil {
+			obj, err := fromProto(f)
+			switch {
+			case err != nil:
+				agg.Add(f.Name, fmt.Errorf("error converting from proto: %w", err))
+			case !reflect.DeepEqual(obj, f.Object):
+				agg.Add(f.Name, fmt.Errorf("converted from proto to %v, expected %v", obj, f.Object))
+			}
+		}
+		if toProto != nil {
+			req, err := toProto(f.Object)
+			switch {
+			case err != nil:
+				agg.Add(f.Name, fmt.Errorf("error converting to proto: %w", err))
+			case !proto.Equal(req, f.Request):
+				agg.Add(f.Name, fmt.Errorf("converted to proto %v, expected %v", req, f.Request))
+			}
+		}
+	}
+	return agg.ErrorOrNil()
+}
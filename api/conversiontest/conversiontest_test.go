@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversiontest
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("Verify", func() {
+	It("should accept the reference conversions", func() {
+		Expect(Verify(FromProto, ToProto)).To(Succeed())
+	})
+
+	It("should cover every creatable kind", func() {
+		kinds := map[string]bool{}
+		for _, f := range Fixtures() {
+			kinds[f.Object.GetKind()] = true
+		}
+		Expect(kinds).To(HaveLen(10))
+	})
+
+	It("should report mismatching conversions by fixture", func() {
+		toProto := func(obj api.Object) (proto.Message, error) {
+			req, err := ToProto(obj)
+			if r, ok := req.(*dpdkproto.CreateRouteRequest); ok {
+				r.Route.Weight = 0
+			}
+			return req, err
+		}
+
+		err := Verify(nil, toProto)
+		agg, ok := err.(*errors.Aggregate)
+		Expect(ok).To(BeTrue())
+		Expect(agg.IDs()).To(Equal([]string{"Route"}))
+	})
+})
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// FirewallAction is the action of a firewall rule, FirewallRuleSpec.FirewallAction holds its String.
+type FirewallAction int32
+
+const (
+	FirewallActionDrop   = FirewallAction(proto.FirewallAction_DROP)
+	FirewallActionAccept = FirewallAction(proto.FirewallAction_ACCEPT)
+)
+
+// ParseFirewallAction parses drop/deny/0 and accept/allow/1, ignoring the case.
+func ParseFirewallAction(action string) (FirewallAction, error) {
+	switch strings.ToLower(action) {
+	case "accept", "allow", "1":
+		return FirewallActionAccept, nil
+	case "drop", "deny", "0":
+		return FirewallActionDrop, nil
+	default:
+		return 0, fmt.Errorf("firewall action can be only: drop/deny/0|accept/allow/1")
+	}
+}
+
+// String returns the name dpservice reports the action with, e.g. "Accept".
+func (a FirewallAction) String() string {
+	switch a {
+	case FirewallActionDrop:
+		return "Drop"
+	case FirewallActionAccept:
+		return "Accept"
+	default:
+		return fmt.Sprintf("FirewallAction(%d)", int32(a))
+	}
+}
+
+func (a FirewallAction) Proto() proto.FirewallAction {
+	return proto.FirewallAction(a)
+}
+
+// TrafficDirection is the direction of a firewall rule, FirewallRuleSpec.TrafficDirection holds its String.
+type TrafficDirection int32
+
+const (
+	TrafficDirectionIngress = TrafficDirection(proto.TrafficDirection_INGRESS)
+	TrafficDirectionEgress  = TrafficDirection(proto.TrafficDirection_EGRESS)
+)
+
+// ParseTrafficDirection parses ingress/0 and egress/1, ignoring the case.
+func ParseTrafficDirection(direction string) (TrafficDirection, error) {
+	switch strings.ToLower(direction) {
+	case "ingress", "0":
+		return TrafficDirectionIngress, nil
+	case "egress", "1":
+		return TrafficDirectionEgress, nil
+	default:
+		return 0, fmt.Errorf("traffic direction can be only: Ingress = 0/Egress = 1")
+	}
+}
+
+// String returns the name dpservice reports the direction with, e.g. "Ingress".
+func (d TrafficDirection) String() string {
+	switch d {
+	case TrafficDirectionIngress:
+		return "Ingress"
+	case TrafficDirectionEgress:
+		return "Egress"
+	default:
+		return fmt.Sprintf("TrafficDirection(%d)", int32(d))
+	}
+}
+
+func (d TrafficDirection) Proto() proto.TrafficDirection {
+	return proto.TrafficDirection(d)
+}
+
+// Protocol is an IP protocol number as used by LBPort.Protocol.
+type Protocol uint32
+
+const (
+	ProtocolICMP   = Protocol(proto.Protocol_ICMP)
+	ProtocolTCP    = Protocol(proto.Protocol_TCP)
+	ProtocolUDP    = Protocol(proto.Protocol_UDP)
+	ProtocolICMPv6 = Protocol(proto.Protocol_ICMPV6)
+	ProtocolSCTP   = Protocol(proto.Protocol_SCTP)
+)
+
+var protocolNames = map[Protocol]string{
+	ProtocolICMP:   "icmp",
+	ProtocolTCP:    "tcp",
+	ProtocolUDP:    "udp",
+	ProtocolICMPv6: "icmpv6",
+	ProtocolSCTP:   "sctp",
+}
+
+// ParseProtocol parses the protocol names returned by String, ignoring the case.
+func ParseProtocol(protocol string) (Protocol, error) {
+	for p, name := range protocolNames {
+		if strings.EqualFold(protocol, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported protocol")
+}
+
+// String returns the lower case protocol name, e.g. "tcp".
+func (p Protocol) String() string {
+	if name, ok := protocolNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Protocol(%d)", uint32(p))
+}
+
+func (p Protocol) Proto() proto.Protocol {
+	return proto.Protocol(p)
+}
+
+// InterfaceType is the type of an interface.
+type InterfaceType int32
+
+const (
+	InterfaceTypeVirtual   = InterfaceType(proto.InterfaceType_VIRTUAL)
+	InterfaceTypeBaremetal = InterfaceType(proto.InterfaceType_BAREMETAL)
+)
+
+// ParseInterfaceType parses virtual/0 and baremetal/1, ignoring the case.
+func ParseInterfaceType(interfaceType string) (InterfaceType, error) {
+	switch strings.ToLower(interfaceType) {
+	case "virtual", "0":
+		return InterfaceTypeVirtual, nil
+	case "baremetal", "1":
+		return InterfaceTypeBaremetal, nil
+	default:
+		return 0, fmt.Errorf("interface type can be only: Virtual = 0/Baremetal = 1")
+	}
+}
+
+func (t InterfaceType) String() string {
+	switch t {
+	case InterfaceTypeVirtual:
+		return "Virtual"
+	case InterfaceTypeBaremetal:
+		return "Baremetal"
+	default:
+		return fmt.Sprintf("InterfaceType(%d)", int32(t))
+	}
+}
+
+func (t InterfaceType) Proto() proto.InterfaceType {
+	return proto.InterfaceType(t)
+}
+
+// VniType selects the address families of a VNI, VniMeta.VniType holds its value.
+type VniType uint8
+
+const (
+	VniTypeIPv4 = VniType(proto.VniType_VNI_IPV4)
+	VniTypeIPv6 = VniType(proto.VniType_VNI_IPV6)
+	VniTypeBoth = VniType(proto.VniType_VNI_BOTH)
+)
+
+// ParseVniType parses ipv4/0, ipv6/1 and both/2, ignoring the case.
+func ParseVniType(vniType string) (VniType, error) {
+	switch strings.ToLower(vniType) {
+	case "ipv4", "0":
+		return VniTypeIPv4, nil
+	case "ipv6", "1":
+		return VniTypeIPv6, nil
+	case "both", "2":
+		return VniTypeBoth, nil
+	default:
+		return 0, fmt.Errorf("vni type can be only: IPv4 = 0/IPv6 = 1/Both = 2")
+	}
+}
+
+func (t VniType) String() string {
+	switch t {
+	case VniTypeIPv4:
+		return "IPv4"
+	case VniTypeIPv6:
+		return "IPv6"
+	case VniTypeBoth:
+		return "Both"
+	default:
+		return fmt.Sprintf("VniType(%d)", uint8(t))
+	}
+}
+
+func (t VniType) Proto() proto.VniType {
+	return proto.VniType(t)
+}
+
+// NatType selects the NATs listed by ListNats.
+type NatType int32
+
+const (
+	NatTypeAny      NatType = 0
+	NatTypeLocal    NatType = 1
+	NatTypeNeighbor NatType = 2
+)
+
+// ParseNatType parses any/0, local/1 and neigh/neighbor/2, ignoring the case. The empty
+// string is NatTypeAny.
+func ParseNatType(natType string) (NatType, error) {
+	switch strings.ToLower(natType) {
+	case "local", "1":
+		return NatTypeLocal, nil
+	case "neigh", "2", "neighbor":
+		return NatTypeNeighbor, nil
+	case "any", "0", "":
+		return NatTypeAny, nil
+	default:
+		return 0, fmt.Errorf("nat type can be only: Any = 0/Local = 1/Neigh(bor) = 2")
+	}
+}
+
+// String returns the lower case name accepted by ListNats, e.g. "local".
+func (t NatType) String() string {
+	switch t {
+	case NatTypeAny:
+		return "any"
+	case NatTypeLocal:
+		return "local"
+	case NatTypeNeighbor:
+		return "neighbor"
+	default:
+		return fmt.Sprintf("NatType(%d)", int32(t))
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("enums", func() {
+	It("should parse firewall actions and directions", func() {
+		for _, s := range []string{"accept", "Allow", "1"} {
+			Expect(ParseFirewallAction(s)).To(Equal(FirewallActionAccept))
+		}
+		Expect(ParseFirewallAction("deny")).To(Equal(FirewallActionDrop))
+		_, err := ParseFirewallAction("reject")
+		Expect(err).To(MatchError("firewall action can be only: drop/deny/0|accept/allow/1"))
+
+		Expect(ParseTrafficDirection("EGRESS")).To(Equal(TrafficDirectionEgress))
+		_, err = ParseTrafficDirection("both")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should format the names dpservice reports", func() {
+		Expect(FirewallActionAccept.String()).To(Equal("Accept"))
+		Expect(TrafficDirectionIngress.String()).To(Equal("Ingress"))
+		Expect(FirewallAction(7).String()).To(Equal("FirewallAction(7)"))
+		Expect(TrafficDirectionEgress.Proto()).To(Equal(proto.TrafficDirection_EGRESS))
+	})
+
+	It("should round-trip protocols, interface, VNI and NAT types", func() {
+		for _, p := range []Protocol{ProtocolICMP, ProtocolTCP, ProtocolUDP, ProtocolICMPv6, ProtocolSCTP} {
+			Expect(ParseProtocol(p.String())).To(Equal(p))
+		}
+		Expect(ParseProtocol("TCP")).To(Equal(ProtocolTCP))
+		Expect(ProtocolICMPv6.Proto()).To(Equal(proto.Protocol_ICMPV6))
+
+		Expect(ParseInterfaceType("baremetal")).To(Equal(InterfaceTypeBaremetal))
+		Expect(InterfaceTypeVirtual.Proto()).To(Equal(proto.InterfaceType_VIRTUAL))
+
+		for _, t := range []VniType{VniTypeIPv4, VniTypeIPv6, VniTypeBoth} {
+			Expect(ParseVniType(t.String())).To(Equal(t))
+		}
+		Expect(VniTypeBoth.Proto()).To(Equal(proto.VniType_VNI_BOTH))
+
+		for _, t := range []NatType{NatTypeAny, NatTypeLocal, NatTypeNeighbor} {
+			Expect(ParseNatType(t.String())).To(Equal(t))
+		}
+		Expect(ParseNatType("neigh")).To(Equal(NatTypeNeighbor))
+		Expect(ParseNatType("")).To(Equal(NatTypeAny))
+	})
+
+	It("should parse load balancer ports with ICMPv6", func() {
+		Expect(StringLbportToLbport("icmpv6/0")).To(Equal(LBPort{Protocol: uint32(ProtocolICMPv6)}))
+	})
+})
@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strings"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// SpecEqual reports whether a and b are of the same kind and have semantically equal specs,
+// e.g. whether the live state of an object matches the desired one. Fields populated by
+// dpservice, i.e. underlay routes and virtual functions, and the status are ignored, as are
+// fields dpservice never returns: the NAT, virtual IP and PXE config of interfaces, the VNI of
+// NATs and the expiry of firewall rules. Unset and invalid addresses are equal, as are unset and
+// empty load balancer ports, which are compared regardless of their order. Specs of other
+// kinds are compared with reflect.DeepEqual.
+func SpecEqual(a, b Object) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	aSpec, aOK := normalizedSpec(a)
+	bSpec, bOK := normalizedSpec(b)
+	return aOK && bOK && reflect.DeepEqual(aSpec, bSpec)
+}
+
+// SpecDiff returns the spec fields set in desired whose value differs in live, formatted as
+// "spec.<field>: <live> -> <desired>". The specs are normalized like by SpecEqual, so fields
+// SpecEqual ignores never differ. Fields not set in desired are not compared either, as
+// dpservice may fill them in, e.g. the device of an interface.
+func SpecDiff(desired, live Object) ([]string, error) {
+	if reflect.TypeOf(desired) != reflect.TypeOf(live) {
+		return nil, fmt.Errorf("cannot compare %T with %T", desired, live)
+	}
+	desiredSpec, err := normalizedSpecMap(desired)
+	if err != nil {
+		return nil, err
+	}
+	liveSpec, err := normalizedSpecMap(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredRaw, err := rawSpecMap(desired)
+	if err != nil {
+		return nil, err
+	}
+	liveRaw, err := rawSpecMap(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	collectDiffs(nil, desiredSpec, liveSpec, func(path []string) {
+		diffs = append(diffs, fmt.Sprintf("spec.%s: %s -> %s", strings.Join(path, "."),
+			formatSpecValue(lookup(liveRaw, path)), formatSpecValue(lookup(desiredRaw, path))))
+	})
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+func collectDiffs(path []string, desired, live map[string]interface{}, report func(path []string)) {
+	for key, desiredValue := range desired {
+		if isZeroSpecValue(desiredValue) {
+			continue
+		}
+		fieldPath := append(append([]string(nil), path...), key)
+		liveValue := live[key]
+
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		liveMap, liveIsMap := liveValue.(map[string]interface{})
+		if desiredIsMap && liveIsMap {
+			collectDiffs(fieldPath, desiredMap, liveMap, report)
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue, liveValue) {
+			report(fieldPath)
+		}
+	}
+}
+
+// normalizedSpecMap returns the normalized spec of obj decoded into a map keyed by field name.
+func normalizedSpecMap(obj Object) (map[string]interface{}, error) {
+	spec, ok := normalizedSpec(obj)
+	if !ok {
+		return nil, fmt.Errorf("%T has no spec", obj)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// rawSpecMap returns the spec of obj as encoded to JSON, used to format differing values.
+func rawSpecMap(obj Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var m struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m.Spec, nil
+}
+
+func lookup(m map[string]interface{}, path []string) interface{} {
+	var v interface{} = m
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func isZeroSpecValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func formatSpecValue(v interface{}) string {
+	if isZeroSpecValue(v) {
+		return "<unset>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// normalizedSpec returns the fields of the spec of obj compared by SpecEqual and SpecDiff, with
+// unset and empty values normalized and named like in the spec. It reports false if obj has no spec.
+func normalizedSpec(obj Object) (interface{}, bool) {
+	switch obj := obj.(type) {
+	case *Interface:
+		return struct {
+			VNI      uint32         `json:"vni"`
+			Device   string         `json:"device"`
+			IPv4     netip.Addr     `json:"primary_ipv4"`
+			IPv6     netip.Addr     `json:"primary_ipv6"`
+			Metering MeteringParams `json:"metering"`
+		}{obj.Spec.VNI, obj.Spec.Device, addrValue(obj.Spec.IPv4), addrValue(obj.Spec.IPv6), meteringValue(obj.Spec.Metering)}, true
+	case *VirtualIP:
+		return struct {
+			IP netip.Addr `json:"vip_ip"`
+		}{addrValue(obj.Spec.IP)}, true
+	case *Nat:
+		return struct {
+			NatIP   netip.Addr `json:"nat_ip"`
+			MinPort uint32     `json:"min_port"`
+			MaxPort uint32     `json:"max_port"`
+		}{addrValue(obj.Spec.NatIP), obj.Spec.MinPort, obj.Spec.MaxPort}, true
+	case *NeighborNat:
+		return struct {
+			Vni     uint32 `json:"vni"`
+			MinPort uint32 `json:"min_port"`
+			MaxPort uint32 `json:"max_port"`
+		}{obj.Spec.Vni, obj.Spec.MinPort, obj.Spec.MaxPort}, true
+	case *Prefix:
+		return struct {
+			Prefix netip.Prefix `json:"prefix"`
+		}{obj.Spec.Prefix}, true
+	case *LoadBalancerPrefix:
+		return struct {
+			Prefix netip.Prefix `json:"prefix"`
+		}{obj.Spec.Prefix}, true
+	case *LoadBalancer:
+		return struct {
+			VNI     uint32     `json:"vni"`
+			LbVipIP netip.Addr `json:"loadbalanced_ip"`
+			Lbports []LBPort   `json:"loadbalanced_ports"`
+		}{obj.Spec.VNI, addrValue(obj.Spec.LbVipIP), sortedLBPorts(obj.Spec.Lbports)}, true
+	case *LoadBalancerTarget:
+		return struct {
+			TargetIP netip.Addr `json:"target_ip"`
+		}{addrValue(obj.Spec.TargetIP)}, true
+	case *FirewallRule:
+		filter, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(obj.Spec.ProtocolFilter)
+		if err != nil {
+			return nil, false
+		}
+		if len(filter) == 0 {
+			filter = nil
+		}
+		return struct {
+			RuleID            string       `json:"id"`
+			TrafficDirection  string       `json:"direction"`
+			FirewallAction    string       `json:"action"`
+			Priority          uint32       `json:"priority"`
+			SourcePrefix      netip.Prefix `json:"source_prefix"`
+			DestinationPrefix netip.Prefix `json:"destination_prefix"`
+			ProtocolFilter    []byte       `json:"protocol_filter"`
+		}{obj.Spec.RuleID, strings.ToLower(obj.Spec.TrafficDirection), strings.ToLower(obj.Spec.FirewallAction), obj.Spec.Priority,
+			prefixValue(obj.Spec.SourcePrefix), prefixValue(obj.Spec.DestinationPrefix), filter}, true
+	case *Route:
+		type nextHop struct {
+			VNI uint32     `json:"vni"`
+			IP  netip.Addr `json:"address"`
+		}
+		var hop nextHop
+		if obj.Spec.NextHop != nil {
+			hop = nextHop{obj.Spec.NextHop.VNI, addrValue(obj.Spec.NextHop.IP)}
+		}
+		return struct {
+			Prefix  netip.Prefix `json:"prefix"`
+			NextHop nextHop      `json:"next_hop"`
+		}{prefixValue(obj.Spec.Prefix), hop}, true
+	default:
+		spec := specField(obj)
+		if !spec.IsValid() {
+			return nil, false
+		}
+		return spec.Interface(), true
+	}
+}
+
+// specField returns the Spec field of the struct obj points to, the zero value if it has none.
+func specField(obj Object) reflect.Value {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v.Elem().FieldByName("Spec")
+}
+
+func addrValue(addr *netip.Addr) netip.Addr {
+	if addr == nil {
+		return netip.Addr{}
+	}
+	return *addr
+}
+
+func prefixValue(prefix *netip.Prefix) netip.Prefix {
+	if prefix == nil {
+		return netip.Prefix{}
+	}
+	return *prefix
+}
+
+func meteringValue(params *MeteringParams) MeteringParams {
+	if params == nil {
+		return MeteringParams{}
+	}
+	return *params
+}
+
+// sortedLBPorts returns a sorted copy of ports, nil if there are none.
+func sortedLBPorts(ports []LBPort) []LBPort {
+	if len(ports) == 0 {
+		return nil
+	}
+	ports = append([]LBPort(nil), ports...)
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+		return ports[i].Port < ports[j].Port
+	})
+	return ports
+}
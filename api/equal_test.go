@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecEqual", func() {
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+	underlayRoute := netip.MustParseAddr("fc00::1")
+
+	It("should ignore fields populated by dpservice", func() {
+		desired := &Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+			PXE: &PXE{Server: "10.0.0.2"}, VIP: &VirtualIP{}}}
+		live := &Interface{
+			Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+				UnderlayRoute: &underlayRoute, VirtualFunction: &VirtualFunction{Name: "net_tap0"}, Metering: &MeteringParams{}},
+			Status: Status{Code: 0, Message: "ok"},
+		}
+		Expect(SpecEqual(desired, live)).To(BeTrue())
+
+		live.Spec.VNI = 200
+		Expect(SpecEqual(desired, live)).To(BeFalse())
+	})
+
+	It("should treat unset and empty values as equal", func() {
+		var zero netip.Addr
+		Expect(SpecEqual(&Nat{Spec: NatSpec{MinPort: 1, MaxPort: 2}}, &Nat{Spec: NatSpec{NatIP: &zero, MinPort: 1, MaxPort: 2, Vni: 100}})).To(BeTrue())
+		Expect(SpecEqual(
+			&LoadBalancer{Spec: LoadBalancerSpec{VNI: 100, Lbports: []LBPort{{Protocol: 6, Port: 443}, {Protocol: 6, Port: 80}}}},
+			&LoadBalancer{Spec: LoadBalancerSpec{VNI: 100, Lbports: []LBPort{{Protocol: 6, Port: 80}, {Protocol: 6, Port: 443}}}},
+		)).To(BeTrue())
+		Expect(SpecEqual(&LoadBalancer{}, &LoadBalancer{Spec: LoadBalancerSpec{Lbports: []LBPort{}}})).To(BeTrue())
+	})
+
+	It("should compare firewall rules", func() {
+		rule, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		other, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(SpecEqual(rule, other)).To(BeTrue())
+
+		other, err = ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/80 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(SpecEqual(rule, other)).To(BeFalse())
+	})
+
+	It("should not match objects of different kinds", func() {
+		prefix := netip.MustParsePrefix("10.0.0.0/24")
+		Expect(SpecEqual(&Prefix{Spec: PrefixSpec{Prefix: prefix}}, &LoadBalancerPrefix{Spec: LoadBalancerPrefixSpec{Prefix: prefix}})).To(BeFalse())
+		Expect(SpecEqual(&Vni{Spec: VniSpec{InUse: true}}, &Vni{Spec: VniSpec{InUse: true}})).To(BeTrue())
+	})
+})
+
+var _ = Describe("SpecDiff", func() {
+	ipv4 := netip.MustParseAddr("10.200.1.4")
+	underlayRoute := netip.MustParseAddr("fc00::4")
+
+	It("should only report fields set in the desired object", func() {
+		desired := &Interface{Spec: InterfaceSpec{VNI: 100, IPv4: &ipv4}}
+		live := &Interface{Spec: InterfaceSpec{VNI: 100, IPv4: &ipv4, Device: "0000:01:00.0", UnderlayRoute: &underlayRoute}}
+
+		diffs, err := SpecDiff(desired, live)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+
+		desired.Spec.VNI = 200
+		diffs, err = SpecDiff(desired, live)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(ConsistOf("spec.vni: 100 -> 200"))
+	})
+
+	It("should ignore fields dpservice never returns", func() {
+		diffs, err := SpecDiff(
+			&Interface{Spec: InterfaceSpec{VNI: 100, IPv4: &ipv4, PXE: &PXE{Server: "10.0.0.2", FileName: "boot.ipxe"}}},
+			&Interface{Spec: InterfaceSpec{VNI: 100, IPv4: &ipv4}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+
+		diffs, err = SpecDiff(&Nat{Spec: NatSpec{MinPort: 1, MaxPort: 2, Vni: 100}}, &Nat{Spec: NatSpec{MinPort: 1, MaxPort: 2}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+
+		rule, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		expiring, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		expiresAt := time.Now().Add(time.Hour)
+		expiring.Spec.ExpiresAt = &expiresAt
+		diffs, err = SpecDiff(expiring, rule)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+	})
+
+	It("should report nested fields", func() {
+		nextHop := netip.MustParseAddr("fc00::1")
+		prefix := netip.MustParsePrefix("10.0.0.0/24")
+		diffs, err := SpecDiff(
+			&Route{Spec: RouteSpec{Prefix: &prefix, NextHop: &RouteNextHop{VNI: 200, IP: &nextHop}}},
+			&Route{Spec: RouteSpec{Prefix: &prefix, NextHop: &RouteNextHop{VNI: 100, IP: &nextHop}}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diffs).To(ConsistOf("spec.next_hop.vni: 100 -> 200"))
+	})
+
+	It("should not compare objects of different kinds", func() {
+		_, err := SpecDiff(&Prefix{}, &LoadBalancerPrefix{})
+		Expect(err).To(HaveOccurred())
+	})
+})
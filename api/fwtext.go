@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// ParseFirewallRule parses a firewall rule in the compact text form used by CLI tools and
+// config files:
+//
+//	<direction> <action> <protocol> <source> -> <destination> [prio <priority>] [id <rule id>]
+//
+// e.g. "ingress allow tcp 10.0.0.0/24 -> any/443 prio 100". The protocol is any, tcp, udp,
+// icmp or icmpv6, ICMP protocols optionally followed by /<type>[/<code>]. Source and
+// destination are any or a prefix, a bare address being a single host prefix, optionally
+// followed by /<port> or /<lower>-<upper> for tcp and udp. any matches the address family of
+// the other endpoint. The interface ID of the returned rule is empty.
+func ParseFirewallRule(text string) (*FirewallRule, error) {
+	rule, err := parseFirewallRule(strings.Fields(text))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing firewall rule %q: %w", text, err)
+	}
+	return rule, nil
+}
+
+func parseFirewallRule(fields []string) (*FirewallRule, error) {
+	if len(fields) < 6 || fields[4] != "->" {
+		return nil, fmt.Errorf("expected <direction> <action> <protocol> <source> -> <destination>")
+	}
+	direction, err := ParseTrafficDirection(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	action, err := ParseFirewallAction(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	protocol, icmp, err := parseFirewallProtocol(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	src, err := parseFirewallEndpoint(fields[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %w", err)
+	}
+	dst, err := parseFirewallEndpoint(fields[5])
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %w", err)
+	}
+	srcPrefix, dstPrefix, err := firewallEndpointPrefixes(src, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &FirewallRule{
+		TypeMeta: TypeMeta{Kind: FirewallRuleKind},
+		Spec: FirewallRuleSpec{
+			TrafficDirection:  direction.String(),
+			FirewallAction:    action.String(),
+			SourcePrefix:      &srcPrefix,
+			DestinationPrefix: &dstPrefix,
+		},
+	}
+	for options := fields[6:]; len(options) > 0; options = options[2:] {
+		if len(options) < 2 {
+			return nil, fmt.Errorf("missing value of %s", options[0])
+		}
+		switch options[0] {
+		case "prio":
+			priority, err := strconv.ParseUint(options[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority: %w", err)
+			}
+			rule.Spec.Priority = uint32(priority)
+		case "id":
+			rule.Spec.RuleID = options[1]
+		default:
+			return nil, fmt.Errorf("unknown option %s", options[0])
+		}
+	}
+
+	switch protocol {
+	case 0:
+		if src.hasPorts || dst.hasPorts {
+			return nil, fmt.Errorf("ports require tcp or udp")
+		}
+	case ProtocolICMP, ProtocolICMPv6:
+		if src.hasPorts || dst.hasPorts {
+			return nil, fmt.Errorf("ports require tcp or udp")
+		}
+		rule.Spec.ProtocolFilter = ICMPFilter(icmp[0], icmp[1])
+	case ProtocolTCP:
+		rule.Spec.ProtocolFilter = TCPFilter(src.ports[0], src.ports[1], dst.ports[0], dst.ports[1])
+	case ProtocolUDP:
+		rule.Spec.ProtocolFilter = UDPFilter(src.ports[0], src.ports[1], dst.ports[0], dst.ports[1])
+	}
+	if err := ValidateProtocolFilter(rule.Spec.ProtocolFilter); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseFirewallProtocol parses the protocol field, returning the ICMP type and code, -1 if
+// omitted. Protocol 0 is any.
+func parseFirewallProtocol(field string) (Protocol, [2]int32, error) {
+	icmp := [2]int32{-1, -1}
+	name, rest, _ := strings.Cut(field, "/")
+	if strings.EqualFold(name, "any") && rest == "" {
+		return 0, icmp, nil
+	}
+	protocol, err := ParseProtocol(name)
+	if err != nil {
+		return 0, icmp, err
+	}
+	switch protocol {
+	case ProtocolICMP, ProtocolICMPv6:
+		if rest == "" {
+			break
+		}
+		parts := strings.Split(rest, "/")
+		if len(parts) > 2 {
+			return 0, icmp, fmt.Errorf("invalid %s type and code %s", protocol, rest)
+		}
+		for i, part := range parts {
+			v, err := strconv.ParseInt(part, 10, 32)
+			if err != nil {
+				return 0, icmp, fmt.Errorf("invalid %s type and code %s", protocol, rest)
+			}
+			icmp[i] = int32(v)
+		}
+	case ProtocolTCP, ProtocolUDP:
+		if rest != "" {
+			return 0, icmp, fmt.Errorf("invalid protocol %s", field)
+		}
+	default:
+		return 0, icmp, fmt.Errorf("protocol %s is not supported by firewall filters", protocol)
+	}
+	return protocol, icmp, nil
+}
+
+type firewallEndpoint struct {
+	// prefix is invalid for any.
+	prefix   netip.Prefix
+	ports    [2]int32
+	hasPorts bool
+}
+
+func parseFirewallEndpoint(field string) (firewallEndpoint, error) {
+	endpoint := firewallEndpoint{ports: [2]int32{-1, -1}}
+	parts := strings.Split(field, "/")
+	var ports string
+	switch {
+	case strings.EqualFold(parts[0], "any"):
+		if len(parts) > 2 {
+			return endpoint, fmt.Errorf("invalid endpoint %s", field)
+		}
+		if len(parts) == 2 {
+			ports = parts[1]
+		}
+	case len(parts) == 1:
+		addr, err := netip.ParseAddr(parts[0])
+		if err != nil {
+			return endpoint, err
+		}
+		endpoint.prefix = netip.PrefixFrom(addr, addr.BitLen())
+	case len(parts) <= 3:
+		prefix, err := netip.ParsePrefix(parts[0] + "/" + parts[1])
+		if err != nil {
+			return endpoint, err
+		}
+		endpoint.prefix = prefix.Masked()
+		if len(parts) == 3 {
+			ports = parts[2]
+		}
+	default:
+		return endpoint, fmt.Errorf("invalid endpoint %s", field)
+	}
+
+	if ports == "" {
+		return endpoint, nil
+	}
+	lower, upper, isRange := strings.Cut(ports, "-")
+	if !isRange {
+		upper = lower
+	}
+	for i, port := range []string{lower, upper} {
+		v, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return endpoint, fmt.Errorf("invalid port range %s", ports)
+		}
+		endpoint.ports[i] = int32(v)
+	}
+	endpoint.hasPorts = true
+	return endpoint, nil
+}
+
+// firewallEndpointPrefixes returns the prefixes of src and dst, any matching the address
+// family of the other endpoint, IPv4 if both are any.
+func firewallEndpointPrefixes(src, dst firewallEndpoint) (netip.Prefix, netip.Prefix, error) {
+	anyPrefix := netip.PrefixFrom(netip.IPv4Unspecified(), 0)
+	switch {
+	case src.prefix.IsValid() && dst.prefix.IsValid():
+		if src.prefix.Addr().Is4() != dst.prefix.Addr().Is4() {
+			return netip.Prefix{}, netip.Prefix{}, fmt.Errorf("source and destination differ in address family")
+		}
+		return src.prefix, dst.prefix, nil
+	case src.prefix.IsValid():
+		if src.prefix.Addr().Is6() {
+			anyPrefix = netip.PrefixFrom(netip.IPv6Unspecified(), 0)
+		}
+		return src.prefix, anyPrefix, nil
+	case dst.prefix.IsValid():
+		if dst.prefix.Addr().Is6() {
+			anyPrefix = netip.PrefixFrom(netip.IPv6Unspecified(), 0)
+		}
+		return anyPrefix, dst.prefix, nil
+	default:
+		return anyPrefix, anyPrefix, nil
+	}
+}
+
+// FormatFirewallRule returns the text form of rule parsed by ParseFirewallRule. The
+// priority is always included, the rule ID if set. Rules that have no text form, e.g.
+// without prefixes, return an error.
+func FormatFirewallRule(rule *FirewallRule) (string, error) {
+	direction, err := ParseTrafficDirection(rule.Spec.TrafficDirection)
+	if err != nil {
+		return "", err
+	}
+	action, err := ParseFirewallAction(rule.Spec.FirewallAction)
+	if err != nil {
+		return "", err
+	}
+	if rule.Spec.SourcePrefix == nil || rule.Spec.DestinationPrefix == nil {
+		return "", fmt.Errorf("firewall rule %s has no source or destination prefix", rule.Spec.RuleID)
+	}
+
+	protocol := "any"
+	srcPorts, dstPorts := "", ""
+	switch f := rule.Spec.ProtocolFilter.GetFilter().(type) {
+	case *proto.ProtocolFilter_Icmp:
+		protocol = Protocol(ProtoFilterToProtocolNumber(rule.Spec.ProtocolFilter, rule.Spec.SourcePrefix.Addr().Is6())).String()
+		if icmpType := f.Icmp.GetIcmpType(); icmpType != -1 {
+			protocol += "/" + strconv.Itoa(int(icmpType))
+			if icmpCode := f.Icmp.GetIcmpCode(); icmpCode != -1 {
+				protocol += "/" + strconv.Itoa(int(icmpCode))
+			}
+		}
+	case *proto.ProtocolFilter_Tcp:
+		protocol = ProtocolTCP.String()
+		srcPorts = formatFirewallPorts(f.Tcp.GetSrcPortLower(), f.Tcp.GetSrcPortUpper())
+		dstPorts = formatFirewallPorts(f.Tcp.GetDstPortLower(), f.Tcp.GetDstPortUpper())
+	case *proto.ProtocolFilter_Udp:
+		protocol = ProtocolUDP.String()
+		srcPorts = formatFirewallPorts(f.Udp.GetSrcPortLower(), f.Udp.GetSrcPortUpper())
+		dstPorts = formatFirewallPorts(f.Udp.GetDstPortLower(), f.Udp.GetDstPortUpper())
+	}
+
+	text := fmt.Sprintf("%s %s %s %s -> %s prio %d",
+		strings.ToLower(direction.String()), strings.ToLower(action.String()), protocol,
+		formatFirewallEndpoint(*rule.Spec.SourcePrefix, srcPorts),
+		formatFirewallEndpoint(*rule.Spec.DestinationPrefix, dstPorts),
+		rule.Spec.Priority)
+	if rule.Spec.RuleID != "" {
+		text += " id " + rule.Spec.RuleID
+	}
+	return text, nil
+}
+
+func formatFirewallPorts(lower, upper int32) string {
+	switch {
+	case lower == -1:
+		return ""
+	case lower == upper:
+		return strconv.Itoa(int(lower))
+	default:
+		return fmt.Sprintf("%d-%d", lower, upper)
+	}
+}
+
+func formatFirewallEndpoint(prefix netip.Prefix, ports string) string {
+	endpoint := prefix.String()
+	if prefix.Bits() == 0 && prefix.Addr().IsUnspecified() {
+		endpoint = "any"
+	}
+	if ports != "" {
+		endpoint += "/" + ports
+	}
+	return endpoint
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("firewall rule text", func() {
+	It("should parse rules", func() {
+		rule, err := ParseFirewallRule("ingress allow tcp 10.0.0.0/24 -> any/443 prio 100")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rule.Spec.TrafficDirection).To(Equal("Ingress"))
+		Expect(rule.Spec.FirewallAction).To(Equal("Accept"))
+		Expect(rule.Spec.Priority).To(Equal(uint32(100)))
+		Expect(*rule.Spec.SourcePrefix).To(Equal(netip.MustParsePrefix("10.0.0.0/24")))
+		Expect(*rule.Spec.DestinationPrefix).To(Equal(netip.MustParsePrefix("0.0.0.0/0")))
+		Expect(rule.Spec.ProtocolFilter).To(Equal(TCPFilter(-1, -1, 443, 443)))
+	})
+
+	It("should match the address family of the other endpoint with any", func() {
+		rule, err := ParseFirewallRule("egress deny icmpv6/128 any -> 2001:db8::1 id ping6")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rule.Spec.SourcePrefix).To(Equal(netip.MustParsePrefix("::/0")))
+		Expect(*rule.Spec.DestinationPrefix).To(Equal(netip.MustParsePrefix("2001:db8::1/128")))
+		Expect(rule.Spec.ProtocolFilter).To(Equal(ICMPFilter(ICMPv6EchoRequest, -1)))
+		Expect(rule.Spec.RuleID).To(Equal("ping6"))
+	})
+
+	It("should round-trip rules", func() {
+		for _, text := range []string{
+			"ingress accept tcp 10.0.0.0/24 -> any/443 prio 100",
+			"ingress accept udp 10.0.0.0/8/1000-2000 -> 10.1.0.0/16/53 prio 0 id dns",
+			"egress drop any any -> any prio 1000",
+			"ingress accept icmp/8/0 192.168.0.1/32 -> any prio 5",
+			"egress accept icmpv6 2001:db8::/64 -> any prio 5",
+		} {
+			rule, err := ParseFirewallRule(text)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(FormatFirewallRule(rule)).To(Equal(text))
+		}
+	})
+
+	It("should reject invalid rules", func() {
+		for _, text := range []string{
+			"ingress allow tcp 10.0.0.0/24 any/443",
+			"inbound allow tcp any -> any",
+			"ingress allow sctp any -> any",
+			"ingress allow icmp any/80 -> any",
+			"ingress allow tcp any -> any/70000",
+			"ingress allow tcp 10.0.0.0/24 -> 2001:db8::/64",
+			"ingress allow tcp any -> any prio",
+			"ingress allow tcp any -> any ttl 10",
+		} {
+			_, err := ParseFirewallRule(text)
+			Expect(err).To(HaveOccurred(), text)
+		}
+	})
+})
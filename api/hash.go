@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// HashSpec returns a stable hash of the desired spec of obj, e.g. to be stored as an annotation or
+// label by orchestrators to skip reconciling objects that did not change. The spec is normalized
+// like by SpecEqual, but fields dpservice never returns, like the virtual IP, NAT and PXE config
+// of an interface, the VNI of a NAT or the expiry of a firewall rule, are part of the hash. Fields
+// populated by dpservice, like underlay routes, are not. The hash is 32 hex characters long, short
+// enough for a Kubernetes label value.
+func HashSpec(obj Object) (string, error) {
+	kind, err := DefaultScheme.KindOf(obj)
+	if err != nil {
+		return "", err
+	}
+	spec, ok := normalizedSpec(obj)
+	if !ok {
+		return "", fmt.Errorf("%s has no spec", kind)
+	}
+	data, err := json.Marshal(struct {
+		Kind       string      `json:"kind"`
+		Spec       interface{} `json:"spec"`
+		Unreturned interface{} `json:"unreturned,omitempty"`
+	}{kind, spec, unreturnedSpec(obj)})
+	if err != nil {
+		return "", fmt.Errorf("error encoding spec of %s: %w", kind, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// unreturnedSpec returns the set spec fields of obj that dpservice never returns and
+// SpecEqual therefore ignores, nil if there are none.
+func unreturnedSpec(obj Object) interface{} {
+	switch obj := obj.(type) {
+	case *Interface:
+		type unreturned struct {
+			PXE *PXE        `json:"pxe,omitempty"`
+			Nat interface{} `json:"nat,omitempty"`
+			VIP interface{} `json:"vip,omitempty"`
+		}
+		var u unreturned
+		if obj.Spec.PXE != nil && *obj.Spec.PXE != (PXE{}) {
+			u.PXE = obj.Spec.PXE
+		}
+		if obj.Spec.Nat != nil {
+			nat, _ := normalizedSpec(obj.Spec.Nat)
+			u.Nat = struct {
+				Spec       interface{} `json:"spec"`
+				Unreturned interface{} `json:"unreturned,omitempty"`
+			}{nat, unreturnedSpec(obj.Spec.Nat)}
+		}
+		if obj.Spec.VIP != nil {
+			u.VIP, _ = normalizedSpec(obj.Spec.VIP)
+		}
+		if u.PXE == nil && u.Nat == nil && u.VIP == nil {
+			return nil
+		}
+		return u
+	case *Nat:
+		if obj.Spec.Vni != 0 {
+			return struct {
+				Vni uint32 `json:"vni"`
+			}{obj.Spec.Vni}
+		}
+	case *FirewallRule:
+		if obj.Spec.ExpiresAt != nil {
+			return struct {
+				ExpiresAt time.Time `json:"expires_at"`
+			}{obj.Spec.ExpiresAt.UTC()}
+		}
+	}
+	return nil
+}
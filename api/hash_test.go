@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HashSpec", func() {
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+	underlayRoute := netip.MustParseAddr("fc00::1")
+
+	It("should be stable", func() {
+		hash, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).To(Equal("193faf892e25b9db0843444be024f0cc"))
+	})
+
+	It("should hash equal specs equally", func() {
+		desired, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6}})
+		Expect(err).NotTo(HaveOccurred())
+		live, err := HashSpec(&Interface{
+			InterfaceMeta: InterfaceMeta{ID: "vm1"},
+			Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+				UnderlayRoute: &underlayRoute, Metering: &MeteringParams{}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(live).To(Equal(desired))
+
+		changed, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 200, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).NotTo(Equal(desired))
+	})
+
+	It("should hash fields dpservice does not return", func() {
+		vip := netip.MustParseAddr("20.0.0.1")
+		base, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6}})
+		Expect(err).NotTo(HaveOccurred())
+		withVIP, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+			VIP: &VirtualIP{Spec: VirtualIPSpec{IP: &vip}}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withVIP).NotTo(Equal(base))
+
+		otherVIP := netip.MustParseAddr("20.0.0.2")
+		changedVIP, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+			VIP: &VirtualIP{Spec: VirtualIPSpec{IP: &otherVIP}}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changedVIP).NotTo(Equal(withVIP))
+
+		withPXE, err := HashSpec(&Interface{Spec: InterfaceSpec{VNI: 100, Device: "net_tap0", IPv4: &ipv4, IPv6: &ipv6,
+			PXE: &PXE{Server: "10.0.0.254", FileName: "boot.ipxe"}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(withPXE).NotTo(Equal(base))
+
+		nat, err := HashSpec(&Nat{Spec: NatSpec{NatIP: &vip, MinPort: 100, MaxPort: 200}})
+		Expect(err).NotTo(HaveOccurred())
+		natVni, err := HashSpec(&Nat{Spec: NatSpec{NatIP: &vip, MinPort: 100, MaxPort: 200, Vni: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(natVni).NotTo(Equal(nat))
+
+		rule, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		ruleHash, err := HashSpec(rule)
+		Expect(err).NotTo(HaveOccurred())
+		expiresAt := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+		rule.Spec.ExpiresAt = &expiresAt
+		Expect(HashSpec(rule)).NotTo(Equal(ruleHash))
+	})
+
+	It("should tell kinds apart", func() {
+		prefix := netip.MustParsePrefix("10.0.0.0/24")
+		a, err := HashSpec(&Prefix{Spec: PrefixSpec{Prefix: prefix}})
+		Expect(err).NotTo(HaveOccurred())
+		b, err := HashSpec(&LoadBalancerPrefix{Spec: LoadBalancerPrefixSpec{Prefix: prefix}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).NotTo(Equal(b))
+		Expect(a).To(HaveLen(32))
+	})
+
+	It("should hash firewall rules", func() {
+		rule, err := ParseFirewallRule("ingress accept tcp 10.0.0.0/24 -> any/443 prio 100 id r1")
+		Expect(err).NotTo(HaveOccurred())
+		a, err := HashSpec(rule)
+		Expect(err).NotTo(HaveOccurred())
+		rule.Spec.FirewallAction = "ACCEPT"
+		Expect(HashSpec(rule)).To(Equal(a))
+	})
+})
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// ErrMalformedResponse matches all MalformedResponseErrors with errors.Is.
+var ErrMalformedResponse = errors.New("malformed response")
+
+var errMissing = errors.New("missing")
+
+// MalformedResponseError is returned when a response of dpservice lacks a required field or
+// contains an unparsable value, instead of panicking on it.
+type MalformedResponseError struct {
+	// Field names the offending field, e.g. "underlay route".
+	Field string
+	Err   error
+}
+
+// MalformedResponse returns a MalformedResponseError for field.
+func MalformedResponse(field string, err error) error {
+	return &MalformedResponseError{Field: field, Err: err}
+}
+
+// MissingField returns a MalformedResponseError for the missing field.
+func MissingField(field string) error {
+	return MalformedResponse(field, errMissing)
+}
+
+func (e *MalformedResponseError) Error() string {
+	return fmt.Sprintf("malformed response: error parsing %s: %v", e.Field, e.Err)
+}
+
+func (e *MalformedResponseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *MalformedResponseError) Is(target error) bool {
+	return target == ErrMalformedResponse
+}
+
+// ParseResponseAddr parses the address of field, a required field of a dpservice response.
+func ParseResponseAddr(field string, addr []byte) (netip.Addr, error) {
+	if len(addr) == 0 {
+		return netip.Addr{}, MissingField(field)
+	}
+	ip, err := netip.ParseAddr(string(addr))
+	if err != nil {
+		return netip.Addr{}, MalformedResponse(field, err)
+	}
+	return ip, nil
+}
+
+// parseOptionalResponseAddr is ParseResponseAddr for optional fields, returning the zero
+// address if the field is empty.
+func parseOptionalResponseAddr(field string, addr []byte) (netip.Addr, error) {
+	if len(addr) == 0 {
+		return netip.Addr{}, nil
+	}
+	return ParseResponseAddr(field, addr)
+}
+
+// parseResponsePrefix parses prefix, a required field of a dpservice response.
+func parseResponsePrefix(field string, prefix *proto.Prefix) (netip.Prefix, error) {
+	if prefix == nil {
+		return netip.Prefix{}, MissingField(field)
+	}
+	addr, err := ParseResponseAddr(field, prefix.GetIp().GetAddress())
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	parsed, err := addr.Prefix(int(prefix.GetLength()))
+	if err != nil {
+		return netip.Prefix{}, MalformedResponse(field, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:generate go run ../hack/openapi-gen -o zz_generated.openapi.go
+
+// OpenAPISchemaNames returns the names of the types OpenAPISchema knows in sorted order: all
+// registered kinds, their lists, Machine and the types they reference.
+func OpenAPISchemaNames() []string {
+	names := make([]string, 0, len(openAPISchemas))
+	for name := range openAPISchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenAPISchema returns the OpenAPI v3 schema of the JSON encoding of the type name, e.g.
+// "Interface". Referenced types are referred to as "#/components/schemas/<name>". It reports
+// false if the type is unknown.
+func OpenAPISchema(name string) (json.RawMessage, bool) {
+	s, ok := openAPISchemas[name]
+	if !ok {
+		return nil, false
+	}
+	return json.RawMessage(s), true
+}
+
+// OpenAPIComponents returns the components object of an OpenAPI v3 document containing the
+// schemas of all types, for REST gateways and validation tooling to embed in their documents.
+func OpenAPIComponents() json.RawMessage {
+	schemas := make(map[string]json.RawMessage, len(openAPISchemas))
+	for name, s := range openAPISchemas {
+		schemas[name] = json.RawMessage(s)
+	}
+	data, err := json.Marshal(map[string]interface{}{"schemas": schemas})
+	if err != nil {
+		// the schemas are generated valid JSON
+		panic(err)
+	}
+	return data
+}
+
+// JSONSchema returns a self-contained JSON Schema (draft 2020-12) document validating
+// manifests of the type name, e.g. for editors and CI checks of manifest repositories. The
+// schemas of all types are included as "$defs".
+func JSONSchema(name string) (json.RawMessage, error) {
+	if _, ok := openAPISchemas[name]; !ok {
+		return nil, fmt.Errorf("no schema for %q", name)
+	}
+	defs := make(map[string]json.RawMessage, len(openAPISchemas))
+	for n, s := range openAPISchemas {
+		defs[n] = json.RawMessage(strings.ReplaceAll(s, "#/components/schemas/", "#/$defs/"))
+	}
+	return json.Marshal(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref":    "#/$defs/" + name,
+		"$defs":   defs,
+	})
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// schemaNode is the part of a schema needed to walk it.
+type schemaNode struct {
+	Ref        string                 `json:"$ref"`
+	Items      *schemaNode            `json:"items"`
+	Properties map[string]*schemaNode `json:"properties"`
+}
+
+// expectCovered fails if value has fields missing in the schema s, resolving references.
+func expectCovered(path string, value interface{}, s *schemaNode) {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		raw, ok := OpenAPISchema(name)
+		ExpectWithOffset(1, ok).To(BeTrue(), "missing schema %s referenced by %s", name, path)
+		s = &schemaNode{}
+		ExpectWithOffset(1, json.Unmarshal(raw, s)).To(Succeed())
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			property, ok := s.Properties[key]
+			ExpectWithOffset(1, ok).To(BeTrue(), "missing property %s.%s", path, key)
+			expectCovered(path+"."+key, field, property)
+		}
+	case []interface{}:
+		for _, item := range v {
+			ExpectWithOffset(1, s.Items).NotTo(BeNil(), "missing items of %s", path)
+			expectCovered(path+"[]", item, s.Items)
+		}
+	}
+}
+
+var _ = Describe("OpenAPI schemas", func() {
+	It("should exist for all kinds", func() {
+		for _, kind := range DefaultScheme.Kinds() {
+			raw, ok := OpenAPISchema(kind)
+			Expect(ok).To(BeTrue(), kind)
+			Expect(json.Valid(raw)).To(BeTrue(), kind)
+		}
+		Expect(OpenAPISchemaNames()).To(ContainElements("InterfaceList", "Machine", "ProtocolFilter"))
+		_, ok := OpenAPISchema("Unknown")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should cover the JSON encoding of objects", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		prefix := netip.MustParsePrefix("10.0.1.0/24")
+		expiresAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		machine := &Machine{
+			Interface: Interface{
+				TypeMeta:      TypeMeta{Kind: InterfaceKind},
+				InterfaceMeta: InterfaceMeta{ID: "vm1"},
+				Spec: InterfaceSpec{VNI: 100, Device: "net_tap2", IPv4: &ipv4, IPv6: &ipv6, UnderlayRoute: &ipv6,
+					VirtualFunction: &VirtualFunction{Name: "vf"}, PXE: &PXE{Server: "s", FileName: "f"},
+					Metering: &MeteringParams{TotalRate: 1, PublicRate: 1}},
+				Status: Status{Code: 1, Message: "m"},
+			},
+			VirtualIP: &VirtualIP{Spec: VirtualIPSpec{IP: &ipv4, UnderlayRoute: &ipv6}},
+			Nat:       &Nat{Spec: NatSpec{NatIP: &ipv4, MinPort: 1, MaxPort: 2, UnderlayRoute: &ipv6, Vni: 1}},
+			Prefixes:  []Prefix{{Spec: PrefixSpec{Prefix: prefix, UnderlayRoute: &ipv6}}},
+			LoadBalancerTargets: []LoadBalancerTarget{{
+				LoadBalancerTargetMeta: LoadBalancerTargetMeta{LoadbalancerID: "lb1"},
+				Spec:                   LoadBalancerTargetSpec{TargetIP: &ipv6},
+			}},
+			FirewallRules: []FirewallRule{{Spec: FirewallRuleSpec{RuleID: "r", TrafficDirection: "Ingress", FirewallAction: "Accept",
+				SourcePrefix: &prefix, DestinationPrefix: &prefix, ProtocolFilter: TCPFilter(1, 2, 3, 4), ExpiresAt: &expiresAt}}},
+		}
+		data, err := json.Marshal(machine)
+		Expect(err).NotTo(HaveOccurred())
+		var value interface{}
+		Expect(json.Unmarshal(data, &value)).To(Succeed())
+		expectCovered("Machine", value, &schemaNode{Ref: "#/components/schemas/Machine"})
+	})
+
+	It("should return the components of all schemas", func() {
+		var components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		}
+		Expect(json.Unmarshal(OpenAPIComponents(), &components)).To(Succeed())
+		Expect(components.Schemas).To(HaveLen(len(OpenAPISchemaNames())))
+	})
+})
+
+var _ = Describe("JSONSchema", func() {
+	It("should return a self-contained document", func() {
+		raw, err := JSONSchema(InterfaceKind)
+		Expect(err).NotTo(HaveOccurred())
+		var doc struct {
+			Ref  string                     `json:"$ref"`
+			Defs map[string]json.RawMessage `json:"$defs"`
+		}
+		Expect(json.Unmarshal(raw, &doc)).To(Succeed())
+		Expect(doc.Ref).To(Equal("#/$defs/Interface"))
+		Expect(string(doc.Defs[InterfaceKind])).To(ContainSubstring(`"$ref":"#/$defs/InterfaceSpec"`))
+		Expect(string(raw)).NotTo(ContainSubstring("#/components/schemas/"))
+
+		_, err = JSONSchema("Unknown")
+		Expect(err).To(MatchError(`no schema for "Unknown"`))
+	})
+})
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Scheme maps kinds to the api types implementing them and back.
+type Scheme struct {
+	newFuncs map[string]func() Object
+	kinds    map[reflect.Type]string
+}
+
+// NewScheme creates an empty scheme.
+func NewScheme() *Scheme {
+	return &Scheme{
+		newFuncs: make(map[string]func() Object),
+		kinds:    make(map[reflect.Type]string),
+	}
+}
+
+// Register adds kind, whose objects are created by newFunc, to the scheme.
+// It panics if the kind or its type is already registered.
+func (s *Scheme) Register(kind string, newFunc func() Object) {
+	if _, ok := s.newFuncs[kind]; ok {
+		panic(fmt.Sprintf("api: kind %q is already registered", kind))
+	}
+	typ := reflect.TypeOf(newFunc())
+	if registered, ok := s.kinds[typ]; ok {
+		panic(fmt.Sprintf("api: type %s is already registered as kind %q", typ, registered))
+	}
+	s.newFuncs[kind] = newFunc
+	s.kinds[typ] = kind
+}
+
+// New creates an empty object of the given kind with its kind set.
+func (s *Scheme) New(kind string) (Object, error) {
+	newFunc, ok := s.newFuncs[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported kind %q", kind)
+	}
+	return newFunc(), nil
+}
+
+// KindOf returns the kind registered for the type of obj, regardless of its kind field.
+func (s *Scheme) KindOf(obj Object) (string, error) {
+	kind, ok := s.kinds[reflect.TypeOf(obj)]
+	if !ok {
+		return "", fmt.Errorf("unregistered type %T", obj)
+	}
+	return kind, nil
+}
+
+// Recognizes reports whether kind is registered.
+func (s *Scheme) Recognizes(kind string) bool {
+	_, ok := s.newFuncs[kind]
+	return ok
+}
+
+// Kinds returns all registered kinds in sorted order.
+func (s *Scheme) Kinds() []string {
+	kinds := make([]string, 0, len(s.newFuncs))
+	for kind := range s.newFuncs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// DefaultScheme contains all object kinds of this package.
+var DefaultScheme = NewScheme()
+
+func init() {
+	DefaultScheme.Register(InterfaceKind, func() Object { return &Interface{TypeMeta: TypeMeta{Kind: InterfaceKind}} })
+	DefaultScheme.Register(VirtualIPKind, func() Object { return &VirtualIP{TypeMeta: TypeMeta{Kind: VirtualIPKind}} })
+	DefaultScheme.Register(NatKind, func() Object { return &Nat{TypeMeta: TypeMeta{Kind: NatKind}} })
+	DefaultScheme.Register(NeighborNatKind, func() Object { return &NeighborNat{TypeMeta: TypeMeta{Kind: NeighborNatKind}} })
+	DefaultScheme.Register(PrefixKind, func() Object { return &Prefix{TypeMeta: TypeMeta{Kind: PrefixKind}} })
+	DefaultScheme.Register(LoadBalancerKind, func() Object { return &LoadBalancer{TypeMeta: TypeMeta{Kind: LoadBalancerKind}} })
+	DefaultScheme.Register(LoadBalancerTargetKind, func() Object {
+		return &LoadBalancerTarget{TypeMeta: TypeMeta{Kind: LoadBalancerTargetKind}}
+	})
+	DefaultScheme.Register(LoadBalancerPrefixKind, func() Object {
+		return &LoadBalancerPrefix{TypeMeta: TypeMeta{Kind: LoadBalancerPrefixKind}}
+	})
+	DefaultScheme.Register(FirewallRuleKind, func() Object { return &FirewallRule{TypeMeta: TypeMeta{Kind: FirewallRuleKind}} })
+	DefaultScheme.Register(RouteKind, func() Object { return &Route{TypeMeta: TypeMeta{Kind: RouteKind}} })
+	DefaultScheme.Register(InitializedKind, func() Object { return &Initialized{TypeMeta: TypeMeta{Kind: InitializedKind}} })
+	DefaultScheme.Register(VniKind, func() Object { return &Vni{TypeMeta: TypeMeta{Kind: VniKind}} })
+	DefaultScheme.Register(VersionKind, func() Object { return &Version{TypeMeta: TypeMeta{Kind: VersionKind}} })
+	DefaultScheme.Register(CaptureStartKind, func() Object { return &CaptureStart{TypeMeta: TypeMeta{Kind: CaptureStartKind}} })
+	DefaultScheme.Register(CaptureStopKind, func() Object { return &CaptureStop{TypeMeta: TypeMeta{Kind: CaptureStopKind}} })
+	DefaultScheme.Register(CaptureStatusKind, func() Object { return &CaptureStatus{TypeMeta: TypeMeta{Kind: CaptureStatusKind}} })
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheme", func() {
+	It("should map every registered kind to its type and back", func() {
+		for _, kind := range DefaultScheme.Kinds() {
+			obj, err := DefaultScheme.New(kind)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GetKind()).To(Equal(kind))
+
+			Expect(DefaultScheme.KindOf(obj)).To(Equal(kind))
+		}
+	})
+
+	It("should reject unknown kinds", func() {
+		_, err := DefaultScheme.New("Unknown")
+		Expect(err).To(HaveOccurred())
+		Expect(DefaultScheme.Recognizes("Unknown")).To(BeFalse())
+	})
+
+	It("should panic on duplicate registrations", func() {
+		s := NewScheme()
+		s.Register(RouteKind, func() Object { return &Route{} })
+		Expect(func() { s.Register(RouteKind, func() Object { return &Route{} }) }).To(Panic())
+		Expect(func() { s.Register("OtherRoute", func() Object { return &Route{} }) }).To(Panic())
+	})
+})
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// summary formats a one-line summary of an object: its kind and name, the given
+// key=value fields with empty values left out, and the status code if it is not 0.
+func summary(kind, name string, status Status, fields ...string) string {
+	var sb strings.Builder
+	sb.WriteString(kind)
+	if name != "" {
+		sb.WriteString(" ")
+		sb.WriteString(name)
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, " %s=%s", fields[i], fields[i+1])
+	}
+	if status.Code != 0 {
+		fmt.Fprintf(&sb, " status=%d", status.Code)
+	}
+	return sb.String()
+}
+
+func addrString(addr *netip.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.String()
+}
+
+func prefixString(prefix *netip.Prefix) string {
+	if prefix == nil {
+		return ""
+	}
+	return prefix.String()
+}
+
+func portRange(min, max uint32) string {
+	if min == 0 && max == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", min, max)
+}
+
+func listSummary(kind string, status Status, count int, fields ...string) string {
+	return summary(kind, "", status, append(fields, "items", fmt.Sprint(count))...)
+}
+
+func (m *Route) String() string {
+	var nextHopVNI, nextHopIP string
+	if m.Spec.NextHop != nil {
+		nextHopVNI = fmt.Sprint(m.Spec.NextHop.VNI)
+		nextHopIP = addrString(m.Spec.NextHop.IP)
+	}
+	return summary(RouteKind, prefixString(m.Spec.Prefix), m.Status,
+		"vni", fmt.Sprint(m.VNI), "next_hop_vni", nextHopVNI, "next_hop_ip", nextHopIP)
+}
+
+func (l *RouteList) String() string {
+	return listSummary(RouteListKind, l.Status, len(l.Items), "vni", fmt.Sprint(l.VNI))
+}
+
+func (m *Prefix) String() string {
+	return summary(PrefixKind, m.Spec.Prefix.String(), m.Status,
+		"interface", m.InterfaceID, "underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (l *PrefixList) String() string {
+	return listSummary(PrefixListKind, l.Status, len(l.Items), "interface", l.InterfaceID)
+}
+
+func (m *VirtualIP) String() string {
+	return summary(VirtualIPKind, addrString(m.Spec.IP), m.Status,
+		"interface", m.InterfaceID, "underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (m *LoadBalancer) String() string {
+	ports := make([]string, len(m.Spec.Lbports))
+	for i, port := range m.Spec.Lbports {
+		ports[i] = fmt.Sprintf("%d/%d", port.Port, port.Protocol)
+	}
+	return summary(LoadBalancerKind, m.ID, m.Status,
+		"vni", fmt.Sprint(m.Spec.VNI), "ip", addrString(m.Spec.LbVipIP), "ports", strings.Join(ports, ","),
+		"underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (m *LoadBalancerTarget) String() string {
+	return summary(LoadBalancerTargetKind, addrString(m.Spec.TargetIP), m.Status, "loadbalancer", m.LoadbalancerID)
+}
+
+func (l *LoadBalancerTargetList) String() string {
+	return listSummary(LoadBalancerTargetListKind, l.Status, len(l.Items), "loadbalancer", l.LoadBalancerID)
+}
+
+func (m *LoadBalancerPrefix) String() string {
+	return summary(LoadBalancerPrefixKind, m.Spec.Prefix.String(), m.Status,
+		"interface", m.InterfaceID, "underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (m *Interface) String() string {
+	var vf string
+	if m.Spec.VirtualFunction != nil {
+		vf = m.Spec.VirtualFunction.Name
+	}
+	return summary(InterfaceKind, m.ID, m.Status,
+		"vni", fmt.Sprint(m.Spec.VNI), "ipv4", addrString(m.Spec.IPv4), "ipv6", addrString(m.Spec.IPv6),
+		"device", m.Spec.Device, "vf", vf, "underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (l *InterfaceList) String() string {
+	return listSummary(InterfaceListKind, l.Status, len(l.Items))
+}
+
+func (l *NatList) String() string {
+	return listSummary(NatListKind, l.Status, len(l.Items), "nat_ip", addrString(l.NatIP), "type", l.NatType)
+}
+
+func (m *NeighborNat) String() string {
+	return summary(NeighborNatKind, addrString(m.NatIP), m.Status,
+		"vni", fmt.Sprint(m.Spec.Vni), "ports", portRange(m.Spec.MinPort, m.Spec.MaxPort),
+		"underlay_route", addrString(m.Spec.UnderlayRoute))
+}
+
+func (m *FirewallRule) String() string {
+	var protocol string
+	if m.Spec.ProtocolFilter != nil {
+		switch {
+		case m.Spec.ProtocolFilter.GetTcp() != nil:
+			protocol = "tcp"
+		case m.Spec.ProtocolFilter.GetUdp() != nil:
+			protocol = "udp"
+		case m.Spec.ProtocolFilter.GetIcmp() != nil:
+			protocol = "icmp"
+		}
+	}
+	return summary(FirewallRuleKind, m.GetName(), m.Status,
+		"direction", m.Spec.TrafficDirection, "action", m.Spec.FirewallAction, "priority", fmt.Sprint(m.Spec.Priority),
+		"src", prefixString(m.Spec.SourcePrefix), "dst", prefixString(m.Spec.DestinationPrefix), "protocol", protocol)
+}
+
+func (l *FirewallRuleList) String() string {
+	return listSummary(FirewallRuleListKind, l.Status, len(l.Items), "interface", l.InterfaceID)
+}
+
+func (m *Initialized) String() string {
+	return summary(InitializedKind, "", m.Status, "uuid", m.Spec.UUID)
+}
+
+func (m *Vni) String() string {
+	return summary(VniKind, fmt.Sprint(m.VNI), m.Status, "type", fmt.Sprint(m.VniType), "in_use", fmt.Sprint(m.Spec.InUse))
+}
+
+func (m *Version) String() string {
+	return summary(VersionKind, "", m.Status,
+		"client", m.ClientName, "client_version", m.ClientVersion, "client_protocol", m.ClientProtocol,
+		"service_version", m.Spec.ServiceVersion, "service_protocol", m.Spec.ServiceProtocol)
+}
+
+func (m *CaptureStart) String() string {
+	var sink string
+	if m.Config != nil {
+		sink = addrString(m.Config.SinkNodeIP)
+	}
+	return summary(CaptureStartKind, "", m.Status, "sink", sink, "interfaces", fmt.Sprint(len(m.Spec.Interfaces)))
+}
+
+func (m *CaptureStop) String() string {
+	return summary(CaptureStopKind, "", m.Status, "interfaces", fmt.Sprint(m.Spec.InterfaceCount))
+}
+
+func (m *CaptureStatus) String() string {
+	return summary(CaptureStatusKind, "", m.Status,
+		"active", fmt.Sprint(m.Spec.OperationStatus), "sink", addrString(m.Spec.Config.SinkNodeIP),
+		"interfaces", fmt.Sprint(len(m.Spec.Interfaces)))
+}
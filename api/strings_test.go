@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("String", func() {
+	addr := func(s string) *netip.Addr {
+		a := netip.MustParseAddr(s)
+		return &a
+	}
+	prefix := func(s string) *netip.Prefix {
+		p := netip.MustParsePrefix(s)
+		return &p
+	}
+
+	DescribeTable("should summarize objects",
+		func(obj fmt.Stringer, expected string) {
+			Expect(obj.String()).To(Equal(expected))
+		},
+		Entry("route", &Route{
+			RouteMeta: RouteMeta{VNI: 100},
+			Spec:      RouteSpec{Prefix: prefix("10.0.0.0/24"), NextHop: &RouteNextHop{VNI: 200, IP: addr("fc00::1")}},
+		}, "Route 10.0.0.0/24 vni=100 next_hop_vni=200 next_hop_ip=fc00::1"),
+		Entry("route list", &RouteList{RouteListMeta: RouteListMeta{VNI: 100}, Items: make([]Route, 2)},
+			"RouteList vni=100 items=2"),
+		Entry("prefix", &Prefix{
+			PrefixMeta: PrefixMeta{InterfaceID: "vm1"},
+			Spec:       PrefixSpec{Prefix: netip.MustParsePrefix("10.1.0.0/24"), UnderlayRoute: addr("fc00::2")},
+		}, "Prefix 10.1.0.0/24 interface=vm1 underlay_route=fc00::2"),
+		Entry("prefix list", &PrefixList{PrefixListMeta: PrefixListMeta{InterfaceID: "vm1"}},
+			"PrefixList interface=vm1 items=0"),
+		Entry("virtual IP", &VirtualIP{
+			VirtualIPMeta: VirtualIPMeta{InterfaceID: "vm1"},
+			Spec:          VirtualIPSpec{IP: addr("20.0.0.1")},
+		}, "VirtualIP 20.0.0.1 interface=vm1"),
+		Entry("load balancer", &LoadBalancer{
+			LoadBalancerMeta: LoadBalancerMeta{ID: "lb1"},
+			Spec:             LoadBalancerSpec{VNI: 100, LbVipIP: addr("20.0.0.2"), Lbports: []LBPort{{Protocol: 6, Port: 443}, {Protocol: 17, Port: 53}}},
+		}, "LoadBalancer lb1 vni=100 ip=20.0.0.2 ports=443/6,53/17"),
+		Entry("load balancer target", &LoadBalancerTarget{
+			LoadBalancerTargetMeta: LoadBalancerTargetMeta{LoadbalancerID: "lb1"},
+			Spec:                   LoadBalancerTargetSpec{TargetIP: addr("fc00::3")},
+		}, "LoadBalancerTarget fc00::3 loadbalancer=lb1"),
+		Entry("load balancer target list", &LoadBalancerTargetList{
+			LoadBalancerTargetListMeta: LoadBalancerTargetListMeta{LoadBalancerID: "lb1"},
+			Items:                      make([]LoadBalancerTarget, 1),
+		}, "LoadBalancerTargetList loadbalancer=lb1 items=1"),
+		Entry("load balancer prefix", &LoadBalancerPrefix{
+			LoadBalancerPrefixMeta: LoadBalancerPrefixMeta{InterfaceID: "vm1"},
+			Spec:                   LoadBalancerPrefixSpec{Prefix: netip.MustParsePrefix("10.2.0.0/24")},
+		}, "LoadBalancerPrefix 10.2.0.0/24 interface=vm1"),
+		Entry("interface", &Interface{
+			InterfaceMeta: InterfaceMeta{ID: "vm1"},
+			Spec: InterfaceSpec{VNI: 100, IPv4: addr("10.0.0.1"), IPv6: addr("2001:db8::1"), Device: "net_tap0",
+				VirtualFunction: &VirtualFunction{Name: "vf0"}},
+			Status: Status{Code: 201},
+		}, "Interface vm1 vni=100 ipv4=10.0.0.1 ipv6=2001:db8::1 device=net_tap0 vf=vf0 status=201"),
+		Entry("interface list", &InterfaceList{Items: make([]Interface, 3)}, "InterfaceList items=3"),
+		Entry("NAT", &Nat{
+			NatMeta: NatMeta{InterfaceID: "vm1"},
+			Spec:    NatSpec{NatIP: addr("20.0.0.3"), MinPort: 1000, MaxPort: 2000},
+		}, "20.0.0.3 <1000, 2000>"),
+		Entry("NAT list", &NatList{NatListMeta: NatListMeta{NatIP: addr("20.0.0.3"), NatType: "local"}},
+			"NatList nat_ip=20.0.0.3 type=local items=0"),
+		Entry("neighbor NAT", &NeighborNat{
+			NeighborNatMeta: NeighborNatMeta{NatIP: addr("20.0.0.3")},
+			Spec:            NeighborNatSpec{Vni: 100, MinPort: 2000, MaxPort: 3000, UnderlayRoute: addr("fc00::4")},
+		}, "NeighborNat 20.0.0.3 vni=100 ports=2000-3000 underlay_route=fc00::4"),
+		Entry("firewall rule", &FirewallRule{
+			FirewallRuleMeta: FirewallRuleMeta{InterfaceID: "vm1"},
+			Spec: FirewallRuleSpec{RuleID: "r1", TrafficDirection: "Ingress", FirewallAction: "Accept", Priority: 100,
+				SourcePrefix: prefix("10.0.0.0/8"), ProtocolFilter: &proto.ProtocolFilter{Filter: &proto.ProtocolFilter_Tcp{Tcp: &proto.TcpFilter{}}}},
+		}, "FirewallRule vm1/r1 direction=Ingress action=Accept priority=100 src=10.0.0.0/8 protocol=tcp"),
+		Entry("firewall rule list", &FirewallRuleList{FirewallRuleListMeta: FirewallRuleListMeta{InterfaceID: "vm1"}},
+			"FirewallRuleList interface=vm1 items=0"),
+		Entry("initialized", &Initialized{Spec: InitializedSpec{UUID: "uuid"}}, "Initialized uuid=uuid"),
+		Entry("VNI", &Vni{VniMeta: VniMeta{VNI: 100}, Spec: VniSpec{InUse: true}}, "Vni 100 type=0 in_use=true"),
+		Entry("version", &Version{
+			VersionMeta: VersionMeta{ClientName: "test", ClientProtocol: "v1"},
+			Spec:        VersionSpec{ServiceVersion: "v2", ServiceProtocol: "v1"},
+		}, "Version client=test client_protocol=v1 service_version=v2 service_protocol=v1"),
+		Entry("capture start", &CaptureStart{
+			CaptureStartMeta: CaptureStartMeta{Config: &CaptureConfig{SinkNodeIP: addr("fc00::5")}},
+			Spec:             CaptureStartSpec{Interfaces: make([]CaptureInterface, 2)},
+		}, "CaptureStart sink=fc00::5 interfaces=2"),
+		Entry("capture stop", &CaptureStop{Spec: CaptureStopSpec{InterfaceCount: 2}}, "CaptureStop interfaces=2"),
+		Entry("capture status", &CaptureStatus{
+			Spec: CaptureGetStatusSpec{OperationStatus: true, Config: CaptureConfig{SinkNodeIP: addr("fc00::5")}},
+		}, "CaptureStatus active=true sink=fc00::5 interfaces=0"),
+	)
+})
@@ -4,24 +4,63 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/netip"
 	"reflect"
+	"time"
 
 	proto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 type Object interface {
 	GetKind() string
+	// GetName returns a human readable name of the object.
 	GetName() string
+	// GetID returns the key identifying the object among all objects of its kind.
+	GetID() string
 	GetStatus() Status
 }
 
-type List interface {
+// ObjectList is implemented by all lists of objects.
+type ObjectList interface {
+	GetKind() string
 	GetItems() []Object
 	GetStatus() Status
 }
 
+// List is the former name of ObjectList.
+//
+// Deprecated: use ObjectList.
+type List = ObjectList
+
+var (
+	_ Object = (*Route)(nil)
+	_ Object = (*Prefix)(nil)
+	_ Object = (*VirtualIP)(nil)
+	_ Object = (*LoadBalancer)(nil)
+	_ Object = (*LoadBalancerTarget)(nil)
+	_ Object = (*LoadBalancerPrefix)(nil)
+	_ Object = (*Interface)(nil)
+	_ Object = (*Nat)(nil)
+	_ Object = (*NeighborNat)(nil)
+	_ Object = (*FirewallRule)(nil)
+	_ Object = (*Initialized)(nil)
+	_ Object = (*Vni)(nil)
+	_ Object = (*Version)(nil)
+	_ Object = (*CaptureStart)(nil)
+	_ Object = (*CaptureStop)(nil)
+	_ Object = (*CaptureStatus)(nil)
+
+	_ ObjectList = (*RouteList)(nil)
+	_ ObjectList = (*PrefixList)(nil)
+	_ ObjectList = (*LoadBalancerTargetList)(nil)
+	_ ObjectList = (*InterfaceList)(nil)
+	_ ObjectList = (*NatList)(nil)
+	_ ObjectList = (*FirewallRuleList)(nil)
+)
+
 type TypeMeta struct {
 	Kind string `json:"kind"`
 }
@@ -81,6 +120,10 @@ func (m *Route) GetName() string {
 	return fmt.Sprintf("%s-%d", m.Spec.Prefix, m.Spec.NextHop.VNI)
 }
 
+func (m *Route) GetID() string {
+	return fmt.Sprintf("%d/%s", m.VNI, m.Spec.Prefix)
+}
+
 func (m *Route) GetStatus() Status {
 	return m.Status
 }
@@ -134,6 +177,10 @@ func (m *Prefix) GetName() string {
 	return m.Spec.Prefix.String()
 }
 
+func (m *Prefix) GetID() string {
+	return fmt.Sprintf("%s/%s", m.InterfaceID, m.Spec.Prefix)
+}
+
 func (m *Prefix) GetStatus() Status {
 	return m.Status
 }
@@ -159,6 +206,10 @@ func (m *VirtualIP) GetName() string {
 	return "on interface: " + m.VirtualIPMeta.InterfaceID
 }
 
+func (m *VirtualIP) GetID() string {
+	return m.InterfaceID
+}
+
 func (m *VirtualIP) GetStatus() Status {
 	return m.Status
 }
@@ -184,6 +235,10 @@ func (m *LoadBalancerMeta) GetName() string {
 	return m.ID
 }
 
+func (m *LoadBalancerMeta) GetID() string {
+	return m.ID
+}
+
 func (m *LoadBalancer) GetStatus() Status {
 	return m.Status
 }
@@ -215,6 +270,10 @@ func (m *LoadBalancerTarget) GetName() string {
 	return "on loadbalancer: " + m.LoadBalancerTargetMeta.LoadbalancerID
 }
 
+func (m *LoadBalancerTarget) GetID() string {
+	return fmt.Sprintf("%s/%s", m.LoadbalancerID, m.Spec.TargetIP)
+}
+
 func (m *LoadBalancerTarget) GetStatus() Status {
 	return m.Status
 }
@@ -261,6 +320,10 @@ func (m *LoadBalancerPrefix) GetName() string {
 	return m.Spec.Prefix.String()
 }
 
+func (m *LoadBalancerPrefix) GetID() string {
+	return fmt.Sprintf("%s/%s", m.InterfaceID, m.Spec.Prefix)
+}
+
 func (m *LoadBalancerPrefix) GetStatus() Status {
 	return m.Status
 }
@@ -296,6 +359,10 @@ func (m *InterfaceMeta) GetName() string {
 	return m.ID
 }
 
+func (m *InterfaceMeta) GetID() string {
+	return m.ID
+}
+
 func (m *Interface) GetStatus() Status {
 	return m.Status
 }
@@ -355,6 +422,10 @@ func (m *NatMeta) GetName() string {
 	return m.InterfaceID
 }
 
+func (m *NatMeta) GetID() string {
+	return m.InterfaceID
+}
+
 func (m *Nat) GetStatus() Status {
 	return m.Status
 }
@@ -410,6 +481,10 @@ func (m *NeighborNatMeta) GetName() string {
 	return m.NatIP.String()
 }
 
+func (m *NeighborNat) GetID() string {
+	return fmt.Sprintf("%s/%d/%d-%d", m.NatIP, m.Spec.Vni, m.Spec.MinPort, m.Spec.MaxPort)
+}
+
 func (m *NeighborNat) GetStatus() Status {
 	return m.Status
 }
@@ -437,6 +512,10 @@ func (m *FirewallRule) GetName() string {
 	return m.FirewallRuleMeta.InterfaceID + "/" + m.Spec.RuleID
 }
 
+func (m *FirewallRule) GetID() string {
+	return m.InterfaceID + "/" + m.Spec.RuleID
+}
+
 func (m *FirewallRule) GetStatus() Status {
 	return m.Status
 }
@@ -449,6 +528,53 @@ type FirewallRuleSpec struct {
 	SourcePrefix      *netip.Prefix         `json:"source_prefix,omitempty"`
 	DestinationPrefix *netip.Prefix         `json:"destination_prefix,omitempty"`
 	ProtocolFilter    *proto.ProtocolFilter `json:"protocol_filter,omitempty"`
+	// ExpiresAt is when the rule is deleted by a fwjanitor.Janitor. dpservice has no rule
+	// expiry, the field is not sent to and never returned by dpservice.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// firewallRuleSpec has the same fields as FirewallRuleSpec but no JSON methods.
+type firewallRuleSpec FirewallRuleSpec
+
+// MarshalJSON encodes the protocol filter with protojson, as its oneof cannot be
+// decoded by encoding/json.
+func (s FirewallRuleSpec) MarshalJSON() ([]byte, error) {
+	var filter json.RawMessage
+	if s.ProtocolFilter != nil {
+		var err error
+		filter, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(s.ProtocolFilter)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling protocol filter: %w", err)
+		}
+	}
+	return json.Marshal(&struct {
+		firewallRuleSpec
+		ProtocolFilter json.RawMessage `json:"protocol_filter,omitempty"`
+	}{
+		firewallRuleSpec: firewallRuleSpec(s),
+		ProtocolFilter:   filter,
+	})
+}
+
+func (s *FirewallRuleSpec) UnmarshalJSON(data []byte) error {
+	aux := &struct {
+		*firewallRuleSpec
+		ProtocolFilter json.RawMessage `json:"protocol_filter,omitempty"`
+	}{
+		firewallRuleSpec: (*firewallRuleSpec)(s),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	s.ProtocolFilter = nil
+	if len(aux.ProtocolFilter) > 0 && string(aux.ProtocolFilter) != "null" {
+		filter := &proto.ProtocolFilter{}
+		if err := protojson.Unmarshal(aux.ProtocolFilter, filter); err != nil {
+			return fmt.Errorf("error unmarshaling protocol filter: %w", err)
+		}
+		s.ProtocolFilter = filter
+	}
+	return nil
 }
 
 type FirewallRuleList struct {
@@ -493,6 +619,10 @@ func (m *InitializedMeta) GetName() string {
 	return "initialized"
 }
 
+func (m *InitializedMeta) GetID() string {
+	return "initialized"
+}
+
 func (m *Initialized) GetStatus() Status {
 	return m.Status
 }
@@ -518,6 +648,10 @@ func (m *VniMeta) GetName() string {
 	return fmt.Sprintf("%d", m.VNI)
 }
 
+func (m *VniMeta) GetID() string {
+	return fmt.Sprintf("%d/%d", m.VNI, m.VniType)
+}
+
 func (m *Vni) GetStatus() Status {
 	return m.Status
 }
@@ -545,6 +679,10 @@ func (m *VersionMeta) GetName() string {
 	return fmt.Sprintf("%s-%s", m.ClientName, m.ClientProtocol)
 }
 
+func (m *VersionMeta) GetID() string {
+	return m.GetName()
+}
+
 func (m *Version) GetStatus() Status {
 	return m.Status
 }
@@ -574,6 +712,10 @@ func (m *CaptureStartMeta) GetName() string {
 	return m.Config.SinkNodeIP.String()
 }
 
+func (m *CaptureStartMeta) GetID() string {
+	return "capture"
+}
+
 func (m *CaptureStart) GetStatus() Status {
 	return m.Status
 }
@@ -601,6 +743,10 @@ func (m *CaptureStopMeta) GetName() string {
 	return "capture stopped"
 }
 
+func (m *CaptureStopMeta) GetID() string {
+	return "capture"
+}
+
 func (m *CaptureStop) GetStatus() Status {
 	return m.Status
 }
@@ -619,6 +765,10 @@ func (m *CaptureStatusMeta) GetName() string {
 	return "get capture status"
 }
 
+func (m *CaptureStatusMeta) GetID() string {
+	return "capture"
+}
+
 func (m *CaptureStatus) GetStatus() Status {
 	return m.Status
 }
@@ -629,6 +779,64 @@ type CaptureGetStatusSpec struct {
 	Interfaces      []CaptureInterface `json:"interfaces,omitempty"`
 }
 
+// Machine bundles the dataplane configuration of a single VM: its interface and the objects
+// configured on it, plus the load balancer targets it serves. Machines are no dpservice
+// objects, they are created and deleted with client.ApplyMachine and client.DeleteMachine.
+type Machine struct {
+	Interface            Interface            `json:"interface"`
+	VirtualIP            *VirtualIP           `json:"virtual_ip,omitempty"`
+	Nat                  *Nat                 `json:"nat,omitempty"`
+	Prefixes             []Prefix             `json:"prefixes,omitempty"`
+	LoadBalancerPrefixes []LoadBalancerPrefix `json:"loadbalancer_prefixes,omitempty"`
+	LoadBalancerTargets  []LoadBalancerTarget `json:"loadbalancer_targets,omitempty"`
+	FirewallRules        []FirewallRule       `json:"firewall_rules,omitempty"`
+}
+
+// Objects returns the objects of the machine in creation order, the interface first. The
+// kinds are set on all objects, and the interface ID on all objects configured on the interface.
+func (m *Machine) Objects() []Object {
+	id := m.Interface.ID
+	iface := m.Interface
+	iface.Kind = InterfaceKind
+	objs := []Object{&iface}
+	if m.VirtualIP != nil {
+		vip := *m.VirtualIP
+		vip.Kind = VirtualIPKind
+		vip.InterfaceID = id
+		objs = append(objs, &vip)
+	}
+	if m.Nat != nil {
+		nat := *m.Nat
+		nat.Kind = NatKind
+		nat.InterfaceID = id
+		objs = append(objs, &nat)
+	}
+	for _, prefix := range m.Prefixes {
+		prefix := prefix
+		prefix.Kind = PrefixKind
+		prefix.InterfaceID = id
+		objs = append(objs, &prefix)
+	}
+	for _, prefix := range m.LoadBalancerPrefixes {
+		prefix := prefix
+		prefix.Kind = LoadBalancerPrefixKind
+		prefix.InterfaceID = id
+		objs = append(objs, &prefix)
+	}
+	for _, rule := range m.FirewallRules {
+		rule := rule
+		rule.Kind = FirewallRuleKind
+		rule.InterfaceID = id
+		objs = append(objs, &rule)
+	}
+	for _, target := range m.LoadBalancerTargets {
+		target := target
+		target.Kind = LoadBalancerTargetKind
+		objs = append(objs, &target)
+	}
+	return objs
+}
+
 var (
 	InterfaceKind              = reflect.TypeOf(Interface{}).Name()
 	InterfaceListKind          = reflect.TypeOf(InterfaceList{}).Name()
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by openapi-gen. DO NOT EDIT.
+
+package api
+
+var openAPISchemas = map[string]string{
+	"CaptureConfig":              `{"type":"object","properties":{"sink_node_ipv6":{"type":"string","format":"ip"},"udp_dst_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"udp_src_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"CaptureGetStatusSpec":       `{"type":"object","properties":{"capture_config":{"$ref":"#/components/schemas/CaptureConfig"},"interfaces":{"type":"array","items":{"$ref":"#/components/schemas/CaptureInterface"}},"operation_status":{"type":"boolean"}}}`,
+	"CaptureInterface":           `{"type":"object","properties":{"interface_info":{"type":"string"},"interface_type":{"type":"string"}}}`,
+	"CaptureStart":               `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/CaptureStartMeta"},"spec":{"$ref":"#/components/schemas/CaptureStartSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"CaptureStartMeta":           `{"type":"object","properties":{"capture_config":{"$ref":"#/components/schemas/CaptureConfig"}}}`,
+	"CaptureStartSpec":           `{"type":"object","properties":{"interfaces":{"type":"array","items":{"$ref":"#/components/schemas/CaptureInterface"}}}}`,
+	"CaptureStatus":              `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/CaptureStatusMeta"},"spec":{"$ref":"#/components/schemas/CaptureGetStatusSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"CaptureStatusMeta":          `{"type":"object"}`,
+	"CaptureStop":                `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/CaptureStopMeta"},"spec":{"$ref":"#/components/schemas/CaptureStopSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"CaptureStopMeta":            `{"type":"object"}`,
+	"CaptureStopSpec":            `{"type":"object","properties":{"iface_cnt":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"FirewallRule":               `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/FirewallRuleMeta"},"spec":{"$ref":"#/components/schemas/FirewallRuleSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"FirewallRuleList":           `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/FirewallRule"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/FirewallRuleListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"FirewallRuleListMeta":       `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"FirewallRuleMeta":           `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"FirewallRuleSpec":           `{"type":"object","properties":{"action":{"type":"string"},"destination_prefix":{"type":"string","format":"cidr"},"direction":{"type":"string"},"expires_at":{"type":"string","format":"date-time"},"id":{"type":"string"},"priority":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"protocol_filter":{"$ref":"#/components/schemas/ProtocolFilter"},"source_prefix":{"type":"string","format":"cidr"}}}`,
+	"IcmpFilter":                 `{"type":"object","properties":{"icmp_code":{"type":"integer","format":"int32"},"icmp_type":{"type":"integer","format":"int32"}},"additionalProperties":false}`,
+	"Initialized":                `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/InitializedMeta"},"spec":{"$ref":"#/components/schemas/InitializedSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"InitializedMeta":            `{"type":"object"}`,
+	"InitializedSpec":            `{"type":"object","properties":{"uuid":{"type":"string"}}}`,
+	"Interface":                  `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/InterfaceMeta"},"spec":{"$ref":"#/components/schemas/InterfaceSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"InterfaceList":              `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/Interface"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/InterfaceListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"InterfaceListMeta":          `{"type":"object"}`,
+	"InterfaceMeta":              `{"type":"object","properties":{"id":{"type":"string"}}}`,
+	"InterfaceSpec":              `{"type":"object","properties":{"device":{"type":"string"},"metering":{"$ref":"#/components/schemas/MeteringParams"},"primary_ipv4":{"type":"string","format":"ip"},"primary_ipv6":{"type":"string","format":"ip"},"pxe":{"$ref":"#/components/schemas/PXE"},"underlay_route":{"type":"string","format":"ip"},"virtual_function":{"$ref":"#/components/schemas/VirtualFunction"},"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"LBPort":                     `{"type":"object","properties":{"port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"protocol":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"LoadBalancer":               `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/LoadBalancerMeta"},"spec":{"$ref":"#/components/schemas/LoadBalancerSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"LoadBalancerMeta":           `{"type":"object","properties":{"id":{"type":"string"}}}`,
+	"LoadBalancerPrefix":         `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/LoadBalancerPrefixMeta"},"spec":{"$ref":"#/components/schemas/LoadBalancerPrefixSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"LoadBalancerPrefixMeta":     `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"LoadBalancerPrefixSpec":     `{"type":"object","properties":{"prefix":{"type":"string","format":"cidr"},"underlay_route":{"type":"string","format":"ip"}}}`,
+	"LoadBalancerSpec":           `{"type":"object","properties":{"loadbalanced_ip":{"type":"string","format":"ip"},"loadbalanced_ports":{"type":"array","items":{"$ref":"#/components/schemas/LBPort"}},"underlay_route":{"type":"string","format":"ip"},"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"LoadBalancerTarget":         `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/LoadBalancerTargetMeta"},"spec":{"$ref":"#/components/schemas/LoadBalancerTargetSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"LoadBalancerTargetList":     `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/LoadBalancerTarget"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/LoadBalancerTargetListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"LoadBalancerTargetListMeta": `{"type":"object","properties":{"loadbalancer_id":{"type":"string"}}}`,
+	"LoadBalancerTargetMeta":     `{"type":"object","properties":{"loadbalancer_id":{"type":"string"}}}`,
+	"LoadBalancerTargetSpec":     `{"type":"object","properties":{"target_ip":{"type":"string","format":"ip"}}}`,
+	"Machine":                    `{"type":"object","properties":{"firewall_rules":{"type":"array","items":{"$ref":"#/components/schemas/FirewallRule"}},"interface":{"$ref":"#/components/schemas/Interface"},"loadbalancer_prefixes":{"type":"array","items":{"$ref":"#/components/schemas/LoadBalancerPrefix"}},"loadbalancer_targets":{"type":"array","items":{"$ref":"#/components/schemas/LoadBalancerTarget"}},"nat":{"$ref":"#/components/schemas/Nat"},"prefixes":{"type":"array","items":{"$ref":"#/components/schemas/Prefix"}},"virtual_ip":{"$ref":"#/components/schemas/VirtualIP"}}}`,
+	"MeteringParams":             `{"type":"object","properties":{"public_rate":{"type":"integer","format":"int64","minimum":0},"total_rate":{"type":"integer","format":"int64","minimum":0}}}`,
+	"Nat":                        `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/NatMeta"},"spec":{"$ref":"#/components/schemas/NatSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"NatList":                    `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/Nat"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/NatListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"NatListMeta":                `{"type":"object","properties":{"nat_ip":{"type":"string","format":"ip"},"nat_type":{"type":"string"}}}`,
+	"NatMeta":                    `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"NatSpec":                    `{"type":"object","properties":{"max_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"min_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"nat_ip":{"type":"string","format":"ip"},"underlay_route":{"type":"string","format":"ip"},"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"NeighborNat":                `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/NeighborNatMeta"},"spec":{"$ref":"#/components/schemas/NeighborNatSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"NeighborNatMeta":            `{"type":"object","properties":{"nat_ip":{"type":"string","format":"ip"}}}`,
+	"NeighborNatSpec":            `{"type":"object","properties":{"max_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"min_port":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"underlay_route":{"type":"string","format":"ip"},"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"PXE":                        `{"type":"object","properties":{"boot_filename":{"type":"string"},"next_server":{"type":"string"}}}`,
+	"Prefix":                     `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/PrefixMeta"},"spec":{"$ref":"#/components/schemas/PrefixSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"PrefixList":                 `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/Prefix"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/PrefixListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"PrefixListMeta":             `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"PrefixMeta":                 `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"PrefixSpec":                 `{"type":"object","properties":{"prefix":{"type":"string","format":"cidr"},"underlay_route":{"type":"string","format":"ip"}}}`,
+	"ProtocolFilter":             `{"type":"object","properties":{"icmp":{"$ref":"#/components/schemas/IcmpFilter"},"tcp":{"$ref":"#/components/schemas/TcpFilter"},"udp":{"$ref":"#/components/schemas/UdpFilter"}},"additionalProperties":false}`,
+	"Route":                      `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/RouteMeta"},"spec":{"$ref":"#/components/schemas/RouteSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"RouteList":                  `{"type":"object","properties":{"items":{"type":"array","items":{"$ref":"#/components/schemas/Route"}},"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/RouteListMeta"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"RouteListMeta":              `{"type":"object","properties":{"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"RouteMeta":                  `{"type":"object","properties":{"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"RouteNextHop":               `{"type":"object","properties":{"address":{"type":"string","format":"ip"},"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295}}}`,
+	"RouteSpec":                  `{"type":"object","properties":{"next_hop":{"$ref":"#/components/schemas/RouteNextHop"},"prefix":{"type":"string","format":"cidr"}}}`,
+	"Status":                     `{"type":"object","properties":{"code":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"message":{"type":"string"}}}`,
+	"TcpFilter":                  `{"type":"object","properties":{"dst_port_lower":{"type":"integer","format":"int32"},"dst_port_upper":{"type":"integer","format":"int32"},"src_port_lower":{"type":"integer","format":"int32"},"src_port_upper":{"type":"integer","format":"int32"}},"additionalProperties":false}`,
+	"UdpFilter":                  `{"type":"object","properties":{"dst_port_lower":{"type":"integer","format":"int32"},"dst_port_upper":{"type":"integer","format":"int32"},"src_port_lower":{"type":"integer","format":"int32"},"src_port_upper":{"type":"integer","format":"int32"}},"additionalProperties":false}`,
+	"Version":                    `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/VersionMeta"},"spec":{"$ref":"#/components/schemas/VersionSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"VersionMeta":                `{"type":"object","properties":{"client_name":{"type":"string"},"client_protocol":{"type":"string"},"client_version":{"type":"string"}}}`,
+	"VersionSpec":                `{"type":"object","properties":{"service_protocol":{"type":"string"},"service_version":{"type":"string"}}}`,
+	"VirtualFunction":            `{"type":"object","properties":{"name":{"type":"string"}}}`,
+	"VirtualIP":                  `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/VirtualIPMeta"},"spec":{"$ref":"#/components/schemas/VirtualIPSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"VirtualIPMeta":              `{"type":"object","properties":{"interface_id":{"type":"string"}}}`,
+	"VirtualIPSpec":              `{"type":"object","properties":{"underlay_route":{"type":"string","format":"ip"},"vip_ip":{"type":"string","format":"ip"}}}`,
+	"Vni":                        `{"type":"object","properties":{"kind":{"type":"string"},"metadata":{"$ref":"#/components/schemas/VniMeta"},"spec":{"$ref":"#/components/schemas/VniSpec"},"status":{"$ref":"#/components/schemas/Status"}}}`,
+	"VniMeta":                    `{"type":"object","properties":{"vni":{"type":"integer","format":"int64","minimum":0,"maximum":4294967295},"vni_type":{"type":"integer","format":"int32","minimum":0,"maximum":255}}}`,
+	"VniSpec":                    `{"type":"object","properties":{"in_use":{"type":"boolean"}}}`,
+}
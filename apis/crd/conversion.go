@@ -0,0 +1,363 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	proto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// MachineSpecToMachine converts spec to the machine with the interface id.
+func MachineSpecToMachine(id string, spec *MachineSpec) (*api.Machine, error) {
+	iface, err := interfaceSpecToInterfaceSpec(&spec.Interface)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interface: %w", err)
+	}
+	machine := &api.Machine{
+		Interface: api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: id},
+			Spec:          *iface,
+		},
+	}
+	if spec.VirtualIP != "" {
+		ip, err := netip.ParseAddr(spec.VirtualIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid virtual ip: %w", err)
+		}
+		machine.VirtualIP = &api.VirtualIP{Spec: api.VirtualIPSpec{IP: &ip}}
+	}
+	if spec.Nat != nil {
+		ip, err := netip.ParseAddr(spec.Nat.IP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nat ip: %w", err)
+		}
+		machine.Nat = &api.Nat{Spec: api.NatSpec{NatIP: &ip, MinPort: uint32(spec.Nat.MinPort), MaxPort: uint32(spec.Nat.MaxPort)}}
+	}
+	for _, s := range spec.Prefixes {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		machine.Prefixes = append(machine.Prefixes, api.Prefix{Spec: api.PrefixSpec{Prefix: prefix}})
+	}
+	for _, s := range spec.LoadBalancerPrefixes {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid load balancer prefix: %w", err)
+		}
+		machine.LoadBalancerPrefixes = append(machine.LoadBalancerPrefixes, api.LoadBalancerPrefix{Spec: api.LoadBalancerPrefixSpec{Prefix: prefix}})
+	}
+	for _, target := range spec.LoadBalancerTargets {
+		ip, err := netip.ParseAddr(target.TargetIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target ip of load balancer %s: %w", target.LoadBalancerID, err)
+		}
+		machine.LoadBalancerTargets = append(machine.LoadBalancerTargets, api.LoadBalancerTarget{
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: target.LoadBalancerID},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &ip},
+		})
+	}
+	for i := range spec.FirewallRules {
+		rule, err := FirewallRuleSpecToFirewallRule(id, &spec.FirewallRules[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid firewall rule %s: %w", spec.FirewallRules[i].ID, err)
+		}
+		machine.FirewallRules = append(machine.FirewallRules, *rule)
+	}
+	return machine, nil
+}
+
+// MachineToMachineSpec converts machine to its spec, dropping the fields populated by dpservice.
+func MachineToMachineSpec(machine *api.Machine) (*MachineSpec, error) {
+	iface := machine.Interface.Spec
+	spec := &MachineSpec{
+		Interface: InterfaceSpec{
+			VNI:    int32(iface.VNI),
+			Device: iface.Device,
+			IPv4:   addrString(iface.IPv4),
+			IPv6:   addrString(iface.IPv6),
+		},
+	}
+	if iface.PXE != nil {
+		spec.Interface.PXE = &PXE{Server: iface.PXE.Server, FileName: iface.PXE.FileName}
+	}
+	if iface.Metering != nil {
+		spec.Interface.Metering = &Metering{TotalRate: int64(iface.Metering.TotalRate), PublicRate: int64(iface.Metering.PublicRate)}
+	}
+	if machine.VirtualIP != nil {
+		spec.VirtualIP = addrString(machine.VirtualIP.Spec.IP)
+	}
+	if machine.Nat != nil {
+		spec.Nat = &NatSpec{
+			IP:      addrString(machine.Nat.Spec.NatIP),
+			MinPort: int32(machine.Nat.Spec.MinPort),
+			MaxPort: int32(machine.Nat.Spec.MaxPort),
+		}
+	}
+	for _, prefix := range machine.Prefixes {
+		spec.Prefixes = append(spec.Prefixes, prefix.Spec.Prefix.String())
+	}
+	for _, prefix := range machine.LoadBalancerPrefixes {
+		spec.LoadBalancerPrefixes = append(spec.LoadBalancerPrefixes, prefix.Spec.Prefix.String())
+	}
+	for _, target := range machine.LoadBalancerTargets {
+		spec.LoadBalancerTargets = append(spec.LoadBalancerTargets, LoadBalancerTarget{
+			LoadBalancerID: target.LoadbalancerID,
+			TargetIP:       addrString(target.Spec.TargetIP),
+		})
+	}
+	for i := range machine.FirewallRules {
+		rule, err := FirewallRuleToFirewallRuleSpec(&machine.FirewallRules[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid firewall rule %s: %w", machine.FirewallRules[i].Spec.RuleID, err)
+		}
+		spec.FirewallRules = append(spec.FirewallRules, *rule)
+	}
+	return spec, nil
+}
+
+func interfaceSpecToInterfaceSpec(spec *InterfaceSpec) (*api.InterfaceSpec, error) {
+	ipv4, err := netip.ParseAddr(spec.IPv4)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ipv4: %w", err)
+	}
+	ipv6, err := netip.ParseAddr(spec.IPv6)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ipv6: %w", err)
+	}
+	iface := &api.InterfaceSpec{
+		VNI:    uint32(spec.VNI),
+		Device: spec.Device,
+		IPv4:   &ipv4,
+		IPv6:   &ipv6,
+	}
+	if spec.PXE != nil {
+		iface.PXE = &api.PXE{Server: spec.PXE.Server, FileName: spec.PXE.FileName}
+	}
+	if spec.Metering != nil {
+		iface.Metering = &api.MeteringParams{TotalRate: uint64(spec.Metering.TotalRate), PublicRate: uint64(spec.Metering.PublicRate)}
+	}
+	return iface, nil
+}
+
+// FirewallRuleSpecToFirewallRule converts spec to a rule of the interface interfaceID.
+func FirewallRuleSpecToFirewallRule(interfaceID string, spec *FirewallRuleSpec) (*api.FirewallRule, error) {
+	direction, err := api.ParseTrafficDirection(spec.Direction)
+	if err != nil {
+		return nil, err
+	}
+	action, err := api.ParseFirewallAction(spec.Action)
+	if err != nil {
+		return nil, err
+	}
+	rule := &api.FirewallRule{
+		TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+		FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: interfaceID},
+		Spec: api.FirewallRuleSpec{
+			RuleID:           spec.ID,
+			TrafficDirection: direction.String(),
+			FirewallAction:   action.String(),
+			Priority:         uint32(spec.Priority),
+		},
+	}
+	if rule.Spec.SourcePrefix, err = parseOptionalPrefix(spec.SourcePrefix); err != nil {
+		return nil, fmt.Errorf("invalid source prefix: %w", err)
+	}
+	if rule.Spec.DestinationPrefix, err = parseOptionalPrefix(spec.DestinationPrefix); err != nil {
+		return nil, fmt.Errorf("invalid destination prefix: %w", err)
+	}
+
+	hasPorts := spec.SourcePorts != nil || spec.DestinationPorts != nil
+	hasICMP := spec.ICMPType != nil || spec.ICMPCode != nil
+	var protocol api.Protocol
+	if spec.Protocol != "" {
+		if protocol, err = api.ParseProtocol(spec.Protocol); err != nil {
+			return nil, err
+		}
+	}
+	switch protocol {
+	case 0:
+		if hasPorts || hasICMP {
+			return nil, fmt.Errorf("ports, icmp type and code require a protocol")
+		}
+	case api.ProtocolICMP, api.ProtocolICMPv6:
+		if hasPorts {
+			return nil, fmt.Errorf("ports require tcp or udp")
+		}
+		rule.Spec.ProtocolFilter = api.ICMPFilter(optionalInt32(spec.ICMPType), optionalInt32(spec.ICMPCode))
+	case api.ProtocolTCP, api.ProtocolUDP:
+		if hasICMP {
+			return nil, fmt.Errorf("icmp type and code require icmp or icmpv6")
+		}
+		srcLower, srcUpper := portRangeBounds(spec.SourcePorts)
+		dstLower, dstUpper := portRangeBounds(spec.DestinationPorts)
+		if protocol == api.ProtocolTCP {
+			rule.Spec.ProtocolFilter = api.TCPFilter(srcLower, srcUpper, dstLower, dstUpper)
+		} else {
+			rule.Spec.ProtocolFilter = api.UDPFilter(srcLower, srcUpper, dstLower, dstUpper)
+		}
+	default:
+		return nil, fmt.Errorf("protocol %s is not supported by firewall filters", protocol)
+	}
+	if err := api.ValidateProtocolFilter(rule.Spec.ProtocolFilter); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// FirewallRuleToFirewallRuleSpec converts rule to its spec, dropping its expiry.
+func FirewallRuleToFirewallRuleSpec(fwRule *api.FirewallRule) (*FirewallRuleSpec, error) {
+	rule := &fwRule.Spec
+	direction, err := api.ParseTrafficDirection(rule.TrafficDirection)
+	if err != nil {
+		return nil, err
+	}
+	action, err := api.ParseFirewallAction(rule.FirewallAction)
+	if err != nil {
+		return nil, err
+	}
+	spec := &FirewallRuleSpec{
+		ID:                rule.RuleID,
+		Direction:         direction.String(),
+		Action:            action.String(),
+		Priority:          int32(rule.Priority),
+		SourcePrefix:      prefixString(rule.SourcePrefix),
+		DestinationPrefix: prefixString(rule.DestinationPrefix),
+	}
+	ipv6 := rule.SourcePrefix != nil && rule.SourcePrefix.Addr().Is6()
+	if protocol := api.ProtoFilterToProtocolNumber(rule.ProtocolFilter, ipv6); protocol != 0 {
+		spec.Protocol = api.Protocol(protocol).String()
+	}
+	switch f := rule.ProtocolFilter.GetFilter().(type) {
+	case *proto.ProtocolFilter_Icmp:
+		spec.ICMPType = optionalInt32Ptr(f.Icmp.GetIcmpType())
+		spec.ICMPCode = optionalInt32Ptr(f.Icmp.GetIcmpCode())
+	case *proto.ProtocolFilter_Tcp:
+		spec.SourcePorts = portRangeFromBounds(f.Tcp.GetSrcPortLower(), f.Tcp.GetSrcPortUpper())
+		spec.DestinationPorts = portRangeFromBounds(f.Tcp.GetDstPortLower(), f.Tcp.GetDstPortUpper())
+	case *proto.ProtocolFilter_Udp:
+		spec.SourcePorts = portRangeFromBounds(f.Udp.GetSrcPortLower(), f.Udp.GetSrcPortUpper())
+		spec.DestinationPorts = portRangeFromBounds(f.Udp.GetDstPortLower(), f.Udp.GetDstPortUpper())
+	}
+	return spec, nil
+}
+
+// LoadBalancerSpecToLoadBalancer converts spec to the load balancer id.
+func LoadBalancerSpecToLoadBalancer(id string, spec *LoadBalancerSpec) (*api.LoadBalancer, error) {
+	ip, err := netip.ParseAddr(spec.IP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip: %w", err)
+	}
+	lb := &api.LoadBalancer{
+		TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+		LoadBalancerMeta: api.LoadBalancerMeta{ID: id},
+		Spec:             api.LoadBalancerSpec{VNI: uint32(spec.VNI), LbVipIP: &ip},
+	}
+	for _, port := range spec.Ports {
+		protocol, err := api.ParseProtocol(port.Protocol)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protocol of port %d: %w", port.Port, err)
+		}
+		lb.Spec.Lbports = append(lb.Spec.Lbports, api.LBPort{Protocol: uint32(protocol), Port: uint32(port.Port)})
+	}
+	return lb, nil
+}
+
+// LoadBalancerToLoadBalancerSpec converts lb to its spec, dropping the underlay route.
+func LoadBalancerToLoadBalancerSpec(lb *api.LoadBalancer) *LoadBalancerSpec {
+	spec := &LoadBalancerSpec{VNI: int32(lb.Spec.VNI), IP: addrString(lb.Spec.LbVipIP)}
+	for _, port := range lb.Spec.Lbports {
+		spec.Ports = append(spec.Ports, LoadBalancerPort{Protocol: api.Protocol(port.Protocol).String(), Port: int32(port.Port)})
+	}
+	return spec
+}
+
+// RouteSpecToRoute converts spec to a route.
+func RouteSpecToRoute(spec *RouteSpec) (*api.Route, error) {
+	prefix, err := netip.ParsePrefix(spec.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix: %w", err)
+	}
+	nextHopIP, err := netip.ParseAddr(spec.NextHopIP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next hop ip: %w", err)
+	}
+	return &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: api.RouteMeta{VNI: uint32(spec.VNI)},
+		Spec: api.RouteSpec{
+			Prefix:  &prefix,
+			NextHop: &api.RouteNextHop{VNI: uint32(spec.NextHopVNI), IP: &nextHopIP},
+		},
+	}, nil
+}
+
+// RouteToRouteSpec converts route to its spec.
+func RouteToRouteSpec(route *api.Route) *RouteSpec {
+	spec := &RouteSpec{VNI: int32(route.VNI), Prefix: prefixString(route.Spec.Prefix)}
+	if route.Spec.NextHop != nil {
+		spec.NextHopVNI = int32(route.Spec.NextHop.VNI)
+		spec.NextHopIP = addrString(route.Spec.NextHop.IP)
+	}
+	return spec
+}
+
+func parseOptionalPrefix(s string) (*netip.Prefix, error) {
+	if s == "" {
+		return nil, nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return nil, err
+	}
+	return &prefix, nil
+}
+
+func addrString(addr *netip.Addr) string {
+	if addr == nil || !addr.IsValid() {
+		return ""
+	}
+	return addr.String()
+}
+
+func prefixString(prefix *netip.Prefix) string {
+	if prefix == nil || !prefix.IsValid() {
+		return ""
+	}
+	return prefix.String()
+}
+
+// portRangeBounds returns the bounds of r for a protocol filter, -1 matching all ports.
+func portRangeBounds(r *PortRange) (int32, int32) {
+	if r == nil {
+		return -1, -1
+	}
+	return r.From, r.To
+}
+
+func portRangeFromBounds(lower, upper int32) *PortRange {
+	if lower == -1 {
+		return nil
+	}
+	return &PortRange{From: lower, To: upper}
+}
+
+// optionalInt32 returns *v, -1 matching all values if v is nil.
+func optionalInt32(v *int32) int32 {
+	if v == nil {
+		return -1
+	}
+	return *v
+}
+
+func optionalInt32Ptr(v int32) *int32 {
+	if v == -1 {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("conversion", func() {
+	icmpType := int32(api.ICMPv6EchoRequest)
+	machineSpec := func() *MachineSpec {
+		return &MachineSpec{
+			Interface: InterfaceSpec{
+				VNI:      100,
+				Device:   "net_tap2",
+				IPv4:     "10.0.0.1",
+				IPv6:     "2001:db8::1",
+				Metering: &Metering{TotalRate: 100},
+			},
+			VirtualIP:            "20.0.0.1",
+			Nat:                  &NatSpec{IP: "20.0.0.2", MinPort: 1024, MaxPort: 2048},
+			Prefixes:             []string{"10.0.1.0/24"},
+			LoadBalancerPrefixes: []string{"10.0.2.0/24"},
+			LoadBalancerTargets:  []LoadBalancerTarget{{LoadBalancerID: "lb1", TargetIP: "2001:db8::2"}},
+			FirewallRules: []FirewallRuleSpec{
+				{ID: "https", Direction: "Ingress", Action: "Accept", Priority: 100, SourcePrefix: "0.0.0.0/0", DestinationPrefix: "10.0.0.1/32",
+					Protocol: "tcp", DestinationPorts: &PortRange{From: 443, To: 443}},
+				{ID: "ping", Direction: "Ingress", Action: "Accept", SourcePrefix: "::/0", DestinationPrefix: "::/0",
+					Protocol: "icmpv6", ICMPType: &icmpType},
+				{ID: "all", Direction: "Egress", Action: "Drop", SourcePrefix: "0.0.0.0/0", DestinationPrefix: "0.0.0.0/0"},
+			},
+		}
+	}
+
+	It("should convert machines", func() {
+		machine, err := MachineSpecToMachine("vm1", machineSpec())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machine.Interface.ID).To(Equal("vm1"))
+		Expect(*machine.Interface.Spec.IPv4).To(Equal(netip.MustParseAddr("10.0.0.1")))
+		Expect(machine.Nat.Spec.MaxPort).To(Equal(uint32(2048)))
+		Expect(machine.FirewallRules).To(HaveLen(3))
+		Expect(machine.FirewallRules[0].InterfaceID).To(Equal("vm1"))
+		Expect(api.FormatFirewallRule(&machine.FirewallRules[0])).To(Equal("ingress accept tcp any -> 10.0.0.1/32/443 prio 100 id https"))
+		Expect(api.FormatFirewallRule(&machine.FirewallRules[1])).To(Equal("ingress accept icmpv6/128 any -> any prio 0 id ping"))
+		Expect(machine.Objects()).To(HaveLen(9))
+
+		spec, err := MachineToMachineSpec(machine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(machineSpec()))
+	})
+
+	It("should reject invalid specs", func() {
+		spec := machineSpec()
+		spec.Interface.IPv4 = "10.0.0"
+		_, err := MachineSpecToMachine("vm1", spec)
+		Expect(err).To(MatchError(ContainSubstring("invalid interface: invalid ipv4")))
+
+		spec = machineSpec()
+		spec.FirewallRules[0].ICMPType = &icmpType
+		_, err = MachineSpecToMachine("vm1", spec)
+		Expect(err).To(MatchError("invalid firewall rule https: icmp type and code require icmp or icmpv6"))
+
+		spec = machineSpec()
+		spec.FirewallRules[0].DestinationPorts = &PortRange{From: 443, To: 80}
+		_, err = MachineSpecToMachine("vm1", spec)
+		Expect(err).To(MatchError(ContainSubstring("invalid tcp destination port range 443-80")))
+	})
+
+	It("should convert load balancers and routes", func() {
+		lbSpec := &LoadBalancerSpec{VNI: 100, IP: "20.0.0.3", Ports: []LoadBalancerPort{{Protocol: "tcp", Port: 80}, {Protocol: "udp", Port: 53}}}
+		lb, err := LoadBalancerSpecToLoadBalancer("lb1", lbSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lb.Spec.Lbports).To(Equal([]api.LBPort{{Protocol: uint32(api.ProtocolTCP), Port: 80}, {Protocol: uint32(api.ProtocolUDP), Port: 53}}))
+		Expect(LoadBalancerToLoadBalancerSpec(lb)).To(Equal(lbSpec))
+
+		routeSpec := &RouteSpec{VNI: 100, Prefix: "10.1.0.0/16", NextHopVNI: 200, NextHopIP: "2001:db8::3"}
+		route, err := RouteSpecToRoute(routeSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*route.Spec.Prefix).To(Equal(netip.MustParsePrefix("10.1.0.0/16")))
+		Expect(RouteToRouteSpec(route)).To(Equal(routeSpec))
+	})
+
+	It("should deep copy specs", func() {
+		spec := machineSpec()
+		copied := spec.DeepCopy()
+		Expect(copied).To(Equal(spec))
+		*copied.FirewallRules[1].ICMPType = 0
+		copied.Interface.Metering.TotalRate = 0
+		Expect(spec).To(Equal(machineSpec()))
+	})
+})
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+// The DeepCopy methods follow the conventions of controller-gen, so CRD types embedding the
+// specs can generate their own.
+
+func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
+	*out = *in
+	in.Interface.DeepCopyInto(&out.Interface)
+	if in.Nat != nil {
+		out.Nat = new(NatSpec)
+		*out.Nat = *in.Nat
+	}
+	if in.Prefixes != nil {
+		out.Prefixes = append([]string(nil), in.Prefixes...)
+	}
+	if in.LoadBalancerPrefixes != nil {
+		out.LoadBalancerPrefixes = append([]string(nil), in.LoadBalancerPrefixes...)
+	}
+	if in.LoadBalancerTargets != nil {
+		out.LoadBalancerTargets = append([]LoadBalancerTarget(nil), in.LoadBalancerTargets...)
+	}
+	if in.FirewallRules != nil {
+		out.FirewallRules = make([]FirewallRuleSpec, len(in.FirewallRules))
+		for i := range in.FirewallRules {
+			in.FirewallRules[i].DeepCopyInto(&out.FirewallRules[i])
+		}
+	}
+}
+
+func (in *MachineSpec) DeepCopy() *MachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *InterfaceSpec) DeepCopyInto(out *InterfaceSpec) {
+	*out = *in
+	if in.PXE != nil {
+		out.PXE = new(PXE)
+		*out.PXE = *in.PXE
+	}
+	if in.Metering != nil {
+		out.Metering = new(Metering)
+		*out.Metering = *in.Metering
+	}
+}
+
+func (in *InterfaceSpec) DeepCopy() *InterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *FirewallRuleSpec) DeepCopyInto(out *FirewallRuleSpec) {
+	*out = *in
+	if in.SourcePorts != nil {
+		out.SourcePorts = new(PortRange)
+		*out.SourcePorts = *in.SourcePorts
+	}
+	if in.DestinationPorts != nil {
+		out.DestinationPorts = new(PortRange)
+		*out.DestinationPorts = *in.DestinationPorts
+	}
+	if in.ICMPType != nil {
+		out.ICMPType = new(int32)
+		*out.ICMPType = *in.ICMPType
+	}
+	if in.ICMPCode != nil {
+		out.ICMPCode = new(int32)
+		*out.ICMPCode = *in.ICMPCode
+	}
+}
+
+func (in *FirewallRuleSpec) DeepCopy() *FirewallRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.Ports != nil {
+		out.Ports = append([]LoadBalancerPort(nil), in.Ports...)
+	}
+}
+
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
+	*out = *in
+}
+
+func (in *RouteSpec) DeepCopy() *RouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package crd mirrors the api types in a form custom resources can store, so teams can keep
+// the desired dpservice state of a node as CRs and realize it with this module. Addresses and
+// prefixes are strings, numbers are signed, and the fields carry kubebuilder validation
+// markers. The types are meant to be embedded in the spec of CRD types defined elsewhere:
+//
+//	type DataplaneMachineSpec struct {
+//		crd.MachineSpec `json:",inline"`
+//	}
+//
+// The conversion functions turn them into api objects and back, e.g. for client.ApplyMachine.
+// The package does not depend on Kubernetes; the DeepCopy methods controller-gen expects of
+// embedded types are provided.
+package crd
+
+// MachineSpec mirrors api.Machine: an interface and the objects configured on it. The ID of
+// the interface is passed to the conversion, e.g. the name of the custom resource.
+type MachineSpec struct {
+	Interface InterfaceSpec `json:"interface"`
+	// VirtualIP is the public IPv4 address of the interface.
+	// +optional
+	// +kubebuilder:validation:Format=ipv4
+	VirtualIP string `json:"virtualIP,omitempty"`
+	// +optional
+	Nat *NatSpec `json:"nat,omitempty"`
+	// Prefixes are routed to the interface.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+	// LoadBalancerPrefixes are load balanced by the interface.
+	// +optional
+	LoadBalancerPrefixes []string `json:"loadBalancerPrefixes,omitempty"`
+	// LoadBalancerTargets registers the interface as target of load balancers.
+	// +optional
+	LoadBalancerTargets []LoadBalancerTarget `json:"loadBalancerTargets,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	FirewallRules []FirewallRuleSpec `json:"firewallRules,omitempty"`
+}
+
+// InterfaceSpec mirrors api.InterfaceSpec without the fields populated by dpservice.
+type InterfaceSpec struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	VNI int32 `json:"vni"`
+	// Device is the PCI address or name of the VF or tap device.
+	// +optional
+	Device string `json:"device,omitempty"`
+	// +kubebuilder:validation:Format=ipv4
+	IPv4 string `json:"ipv4"`
+	// +kubebuilder:validation:Format=ipv6
+	IPv6 string `json:"ipv6"`
+	// +optional
+	PXE *PXE `json:"pxe,omitempty"`
+	// +optional
+	Metering *Metering `json:"metering,omitempty"`
+}
+
+// PXE mirrors api.PXE.
+type PXE struct {
+	// +optional
+	Server string `json:"server,omitempty"`
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+}
+
+// Metering mirrors api.MeteringParams, the rates are in Mbit/s.
+type Metering struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TotalRate int64 `json:"totalRate,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PublicRate int64 `json:"publicRate,omitempty"`
+}
+
+// NatSpec mirrors api.NatSpec. MaxPort is exclusive.
+type NatSpec struct {
+	// +kubebuilder:validation:Format=ipv4
+	IP string `json:"ip"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	MinPort int32 `json:"minPort"`
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=65536
+	MaxPort int32 `json:"maxPort"`
+}
+
+// LoadBalancerTarget references a load balancer the interface is a target of.
+type LoadBalancerTarget struct {
+	// +kubebuilder:validation:MinLength=1
+	LoadBalancerID string `json:"loadBalancerID"`
+	// TargetIP is the IPv6 address the load balanced traffic is sent to, usually the underlay
+	// route of the interface.
+	// +kubebuilder:validation:Format=ipv6
+	TargetIP string `json:"targetIP"`
+}
+
+// FirewallRuleSpec mirrors api.FirewallRuleSpec with a structured protocol filter.
+type FirewallRuleSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+	// +kubebuilder:validation:Enum=Ingress;Egress
+	Direction string `json:"direction"`
+	// +kubebuilder:validation:Enum=Accept;Drop
+	Action string `json:"action"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority,omitempty"`
+	// +optional
+	SourcePrefix string `json:"sourcePrefix,omitempty"`
+	// +optional
+	DestinationPrefix string `json:"destinationPrefix,omitempty"`
+	// Protocol is matched by the rule, all protocols if empty.
+	// +optional
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;icmpv6
+	Protocol string `json:"protocol,omitempty"`
+	// SourcePorts and DestinationPorts are matched for tcp and udp, all ports if unset.
+	// +optional
+	SourcePorts *PortRange `json:"sourcePorts,omitempty"`
+	// +optional
+	DestinationPorts *PortRange `json:"destinationPorts,omitempty"`
+	// ICMPType and ICMPCode are matched for icmp and icmpv6, all types and codes if unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	ICMPType *int32 `json:"icmpType,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	ICMPCode *int32 `json:"icmpCode,omitempty"`
+}
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	To int32 `json:"to"`
+}
+
+// LoadBalancerSpec mirrors api.LoadBalancerSpec without the underlay route. The ID of the load
+// balancer is passed to the conversion.
+type LoadBalancerSpec struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	VNI int32  `json:"vni"`
+	IP  string `json:"ip"`
+	// +optional
+	Ports []LoadBalancerPort `json:"ports,omitempty"`
+}
+
+// LoadBalancerPort is a port a load balancer forwards.
+type LoadBalancerPort struct {
+	// +kubebuilder:validation:Enum=tcp;udp
+	Protocol string `json:"protocol"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// RouteSpec mirrors api.Route.
+type RouteSpec struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	VNI    int32  `json:"vni"`
+	Prefix string `json:"prefix"`
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16777215
+	NextHopVNI int32 `json:"nextHopVNI"`
+	// +kubebuilder:validation:Format=ipv6
+	NextHopIP string `json:"nextHopIP"`
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+type options struct {
+	prune      bool
+	owner      string
+	ownerStore OwnerStore
+	rollback   bool
+}
+
+type Option func(*options)
+
+// WithPrune deletes live objects that are not part of the desired manifests.
+// Load balancers cannot be listed by dpservice and are therefore never pruned.
+func WithPrune() Option {
+	return func(o *options) {
+		o.prune = true
+	}
+}
+
+// rollbackTimeout bounds the rollback of a failed apply, which does not end with its context.
+var rollbackTimeout = time.Minute
+
+// WithRollback makes Apply undo the changes made so far if a change fails: created objects are
+// deleted and deleted objects are recreated from their live state, in reverse order. Objects
+// removed together with a deleted parent, like the prefixes of an interface, are part of the
+// plan and recreated as well. The rollback also runs if the context of Apply is done, bounded
+// by its own timeout. The error of the failed change is returned joined with the errors of the
+// rollback. Note that dpservice assigns new underlay routes to recreated objects and that the
+// PXE config of recreated interfaces is lost, as dpservice never returns it. For plans, the
+// option is passed to ApplyPlan.
+func WithRollback() Option {
+	return func(o *options) {
+		o.rollback = true
+	}
+}
+
+// stage returns the position of an object in the creation order; objects
+// of a later stage may depend on objects of an earlier one.
+func stage(obj api.Object) int {
+	switch obj.(type) {
+	case *api.Interface, *api.LoadBalancer:
+		return 0
+	case *api.VirtualIP, *api.Nat, *api.Prefix, *api.LoadBalancerPrefix, *api.FirewallRule, *api.LoadBalancerTarget:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// parentKey returns the key of the object whose deletion implicitly removes obj.
+func parentKey(obj api.Object) string {
+	switch o := obj.(type) {
+	case *api.VirtualIP:
+		return "Interface/" + o.InterfaceID
+	case *api.Nat:
+		return "Interface/" + o.InterfaceID
+	case *api.Prefix:
+		return "Interface/" + o.InterfaceID
+	case *api.LoadBalancerPrefix:
+		return "Interface/" + o.InterfaceID
+	case *api.FirewallRule:
+		return "Interface/" + o.InterfaceID
+	case *api.LoadBalancerTarget:
+		return "LoadBalancer/" + o.LoadbalancerID
+	default:
+		return ""
+	}
+}
+
+// Apply makes dpservice match the desired objects. Missing objects are created and
+// objects whose spec differs are recreated, as dpservice does not support updates.
+func Apply(ctx context.Context, c client.Client, desired []api.Object, opts ...Option) error {
+	plan, err := NewPlan(ctx, c, desired, opts...)
+	if err != nil {
+		return err
+	}
+	return ApplyPlan(ctx, c, plan, opts...)
+}
+
+func create(ctx context.Context, c client.Client, obj api.Object) error {
+	_, err := client.NewResource[api.Object](c).Create(ctx, obj)
+	return err
+}
+
+func remove(ctx context.Context, c client.Client, obj api.Object) error {
+	notFound := errors.Ignore(errors.NOT_FOUND, errors.SNAT_NO_DATA, errors.DNAT_NO_DATA, errors.ROUTE_NOT_FOUND)
+	return client.NewResource[api.Object](c).Delete(ctx, obj, notFound)
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"gopkg.in/yaml.v3"
+)
+
+// Decode reads a stream of YAML or JSON manifests separated by "---". Manifests are validated
+// like by Validate first, so malformed ones are rejected with a *ValidationError locating the
+// failing fields.
+func Decode(r io.Reader) ([]api.Object, error) {
+	var objs []api.Object
+	dec := yaml.NewDecoder(r)
+	for i := 0; ; i++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("error decoding document %d: %w", i, err)
+		}
+		if fields := validateDocument(i, &node); len(fields) > 0 {
+			return nil, fmt.Errorf("error decoding document %d: %w", i, &ValidationError{Fields: fields})
+		}
+		var doc interface{}
+		if err := node.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("error decoding document %d: %w", i, err)
+		}
+		if doc == nil {
+			continue
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("error converting document %d to json: %w", i, err)
+		}
+		obj, err := DecodeObject(data)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding document %d: %w", i, err)
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// DecodeObject decodes a single JSON manifest into its typed api object.
+func DecodeObject(data []byte) (api.Object, error) {
+	meta := &api.TypeMeta{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, err
+	}
+	obj, err := api.DefaultScheme.New(meta.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := objectKey(obj); err != nil {
+		return nil, fmt.Errorf("unsupported kind %q", meta.Kind)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(obj); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", meta.Kind, err)
+	}
+	return obj, nil
+}
+
+// ReadPath reads all manifests of a file, or of all *.yaml, *.yml and *.json files
+// of a directory in lexical order. A path of "-" reads from stdin.
+func ReadPath(path string) ([]api.Object, error) {
+	if path == "-" {
+		return Decode(os.Stdin)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readFile(path)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	var objs []api.Object
+	for _, file := range files {
+		fileObjs, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, fileObjs...)
+	}
+	return objs, nil
+}
+
+func readFile(path string) ([]api.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	objs, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return objs, nil
+}
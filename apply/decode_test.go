@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const manifests = `
+kind: Interface
+metadata:
+  id: vm1
+spec:
+  vni: 100
+  device: net_tap2
+  primary_ipv4: 10.200.1.4
+  primary_ipv6: 2000:200:1::4
+---
+{"kind": "Route", "metadata": {"vni": 100}, "spec": {"prefix": "10.0.0.0/24", "next_hop": {"vni": 100, "address": "fc00::1"}}}
+---
+kind: FirewallRule
+metadata:
+  interface_id: vm1
+spec:
+  id: fr1
+  direction: Ingress
+  action: Accept
+  source_prefix: 0.0.0.0/0
+  destination_prefix: 10.200.1.4/32
+  protocol_filter:
+    tcp:
+      src_port_lower: -1
+      dst_port_lower: 22
+      dst_port_upper: 22
+`
+
+var _ = Describe("decoding manifests", func() {
+	It("should decode a yaml and json stream", func() {
+		objs, err := Decode(strings.NewReader(manifests))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(3))
+
+		iface := objs[0].(*api.Interface)
+		Expect(iface.ID).To(Equal("vm1"))
+		Expect(*iface.Spec.IPv4).To(Equal(netip.MustParseAddr("10.200.1.4")))
+
+		route := objs[1].(*api.Route)
+		Expect(route.VNI).To(Equal(uint32(100)))
+		Expect(route.Spec.NextHop.IP.String()).To(Equal("fc00::1"))
+
+		rule := objs[2].(*api.FirewallRule)
+		Expect(rule.Spec.ProtocolFilter.GetTcp().GetDstPortLower()).To(Equal(int32(22)))
+	})
+
+	It("should reject unknown kinds and fields", func() {
+		_, err := Decode(strings.NewReader("kind: Foo\n"))
+		Expect(err).To(MatchError(ContainSubstring(`unsupported kind "Foo"`)))
+
+		_, err = Decode(strings.NewReader("kind: Interface\nspec:\n  foo: bar\n"))
+		Expect(err).To(MatchError(ContainSubstring("unknown field")))
+	})
+})
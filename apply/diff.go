@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// objectKey identifies an object within dpservice independent of its spec.
+func objectKey(obj api.Object) (string, error) {
+	switch obj.(type) {
+	case *api.Interface, *api.VirtualIP, *api.Nat, *api.NeighborNat, *api.Prefix, *api.LoadBalancer,
+		*api.LoadBalancerTarget, *api.LoadBalancerPrefix, *api.FirewallRule, *api.Route:
+		kind, err := api.DefaultScheme.KindOf(obj)
+		if err != nil {
+			return "", err
+		}
+		return kind + "/" + obj.GetID(), nil
+	default:
+		return "", fmt.Errorf("unsupported object %T", obj)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// OwnerRecord is the ownership of an object recorded by a FileOwnerStore.
+type OwnerRecord struct {
+	Owner      string    `json:"owner"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// FileOwnerStore is an OwnerStore persisting the owners in a JSON file, so an agent knows
+// after a crash or restart which objects it created and prunes neither too little nor too
+// much. The file is rewritten atomically on every change. A store must only be used by one
+// process at a time.
+type FileOwnerStore struct {
+	path string
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+// NewFileOwnerStore creates a store persisting the owners in the file at path. A missing file
+// is treated as an empty store and created on the first change.
+func NewFileOwnerStore(path string) *FileOwnerStore {
+	return &FileOwnerStore{path: path, now: time.Now}
+}
+
+// Records returns the recorded owners with the time they were recorded, keyed by object key.
+func (s *FileOwnerStore) Records(context.Context) (map[string]OwnerRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, _, err := s.read()
+	return records, err
+}
+
+// Owners returns the recorded owners. The revision is the checksum of the file.
+func (s *FileOwnerStore) Owners(context.Context) (map[string]string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, revision, err := s.read()
+	if err != nil {
+		return nil, "", err
+	}
+	owners := make(map[string]string, len(records))
+	for key, record := range records {
+		owners[key] = record.Owner
+	}
+	return owners, revision, nil
+}
+
+// SetOwned replaces the object keys recorded for owner. Keys that stay owned keep the time
+// they were first recorded.
+func (s *FileOwnerStore) SetOwned(_ context.Context, owner string, keys []string, revision string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, current, err := s.read()
+	if err != nil {
+		return err
+	}
+	if revision != current {
+		return ErrOwnersChanged
+	}
+	previous := make(map[string]OwnerRecord)
+	for key, record := range records {
+		if record.Owner == owner {
+			previous[key] = record
+			delete(records, key)
+		}
+	}
+	now := s.now().UTC()
+	for _, key := range keys {
+		record, ok := previous[key]
+		if !ok {
+			record = OwnerRecord{Owner: owner, RecordedAt: now}
+		}
+		records[key] = record
+	}
+	return s.write(records)
+}
+
+// read returns the records of the file and the checksum of its content as revision.
+func (s *FileOwnerStore) read() (map[string]OwnerRecord, string, error) {
+	records := make(map[string]OwnerRecord)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return records, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading owner store: %w", err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, "", fmt.Errorf("error decoding owner store %s: %w", s.path, err)
+	}
+	sum := sha256.Sum256(data)
+	return records, hex.EncodeToString(sum[:]), nil
+}
+
+func (s *FileOwnerStore) write(records map[string]OwnerRecord) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing owner store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing owner store: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing owner store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing owner store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error writing owner store: %w", err)
+	}
+	return nil
+}
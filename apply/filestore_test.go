@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileOwnerStore", func() {
+	ctx := context.TODO()
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "owners.json")
+	})
+
+	It("should persist owners across store instances", func() {
+		t0 := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+		store := NewFileOwnerStore(path)
+		store.now = func() time.Time { return t0 }
+		Expect(ownersOf(store)).To(BeEmpty())
+
+		setOwned(store, "a", "Interface/vm1", "Nat/vm1")
+		setOwned(store, "b", "Interface/vm2")
+
+		store = NewFileOwnerStore(path)
+		store.now = func() time.Time { return t0.Add(time.Hour) }
+		setOwned(store, "a", "Interface/vm1", "VirtualIP/vm1")
+
+		Expect(NewFileOwnerStore(path).Records(ctx)).To(Equal(map[string]OwnerRecord{
+			"Interface/vm1": {Owner: "a", RecordedAt: t0},
+			"VirtualIP/vm1": {Owner: "a", RecordedAt: t0.Add(time.Hour)},
+			"Interface/vm2": {Owner: "b", RecordedAt: t0},
+		}))
+	})
+
+	It("should reject outdated writes", func() {
+		store := NewFileOwnerStore(path)
+		_, revision, err := store.Owners(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		setOwned(NewFileOwnerStore(path), "b", "Interface/vm1")
+
+		Expect(store.SetOwned(ctx, "a", []string{"Interface/vm1"}, revision)).To(MatchError(ErrOwnersChanged))
+		Expect(ownersOf(store)).To(Equal(map[string]string{"Interface/vm1": "b"}))
+	})
+
+	It("should reject corrupt files", func() {
+		Expect(os.WriteFile(path, []byte("{"), 0o600)).To(Succeed())
+		_, _, err := NewFileOwnerStore(path).Owners(ctx)
+		Expect(err).To(MatchError(ContainSubstring("error decoding owner store")))
+	})
+})
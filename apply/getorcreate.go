@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// ErrOwnerConflict is returned by GetOrCreate if the object belongs to another owner.
+var ErrOwnerConflict = stderrors.New("owner conflict")
+
+// alreadyExists are the status codes dpservice reports when creating an object that exists.
+var alreadyExists = errors.Ignore(errors.ALREADY_EXISTS, errors.SNAT_EXISTS, errors.DNAT_EXISTS, errors.ROUTE_EXISTS)
+
+// GetOrCreate returns the live object of obj if it is recorded for owner in store, creating it
+// if it does not exist. New objects are recorded for owner before they are created. Objects
+// recorded for another owner and existing objects without owner are refused with an error
+// wrapping ErrOwnerConflict, so two controllers cannot silently fight over an object ID. The
+// owner is recorded with a revision check, so of two controllers claiming the same object
+// concurrently only one succeeds.
+// Kinds dpservice cannot get, e.g. prefixes, are identified by their spec, so obj is returned
+// for existing objects of them.
+func GetOrCreate[T api.Object](ctx context.Context, c client.Client, obj T, owner string, store OwnerStore) (T, error) {
+	var zero T
+	key, err := objectKey(obj)
+	if err != nil {
+		return zero, err
+	}
+	var isRecorded bool
+	if err := updateOwned(ctx, store, owner, func(owners map[string]string) ([]string, error) {
+		recorded, ok := owners[key]
+		if ok && recorded != owner {
+			return nil, fmt.Errorf("%w: object %s is owned by %s", ErrOwnerConflict, key, recorded)
+		}
+		isRecorded = ok
+		return union(owned(owners, owner), []string{key}), nil
+	}); err != nil {
+		return zero, fmt.Errorf("error recording owner of %s: %w", key, err)
+	}
+
+	objs := client.NewResource[T](c)
+	res, err := objs.Create(ctx, obj, alreadyExists)
+	if err == nil && res.GetStatus().Code == 0 {
+		return res, nil
+	}
+	if !isRecorded {
+		if setErr := updateOwned(ctx, store, owner, func(owners map[string]string) ([]string, error) {
+			return without(owned(owners, owner), []string{key}), nil
+		}); setErr != nil {
+			err = stderrors.Join(err, fmt.Errorf("error forgetting owner of %s: %w", key, setErr))
+		}
+		if err == nil {
+			err = fmt.Errorf("%w: object %s exists without owner", ErrOwnerConflict, key)
+		}
+		return zero, err
+	}
+	if err != nil {
+		return zero, err
+	}
+	if !gettable(obj) {
+		return obj, nil
+	}
+	return objs.Get(ctx, obj)
+}
+
+// gettable reports whether dpservice can get objects of the kind of obj.
+func gettable(obj api.Object) bool {
+	switch obj.(type) {
+	case *api.Interface, *api.VirtualIP, *api.Nat, *api.LoadBalancer, *api.FirewallRule:
+		return true
+	default:
+		return false
+	}
+}
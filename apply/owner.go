@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// ErrOwnersChanged is returned by OwnerStore.SetOwned when the owners were changed since they
+// were read.
+var ErrOwnersChanged = errors.New("owners changed concurrently")
+
+// maxOwnerConflictRetries is the number of times an update of the owners is retried after
+// ErrOwnersChanged.
+const maxOwnerConflictRetries = 5
+
+// OwnerStore records which owner applied which objects. dpservice stores no metadata with
+// its objects, so the owners are kept on the client side, keyed by the object keys of plans.
+// A store shared by several controllers must reject outdated writes, so that two controllers
+// never record the same object.
+type OwnerStore interface {
+	// Owners returns the owner of every recorded object key and the revision they were read at.
+	Owners(ctx context.Context) (owners map[string]string, revision string, err error)
+	// SetOwned replaces the object keys recorded for owner if the owners are still at revision,
+	// and returns ErrOwnersChanged otherwise.
+	SetOwned(ctx context.Context, owner string, keys []string, revision string) error
+}
+
+// updateOwned replaces the keys recorded for owner with the result of fn, retrying on
+// conflicts.
+func updateOwned(ctx context.Context, store OwnerStore, owner string, fn func(owners map[string]string) ([]string, error)) error {
+	for attempt := 0; ; attempt++ {
+		owners, revision, err := store.Owners(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading owners: %w", err)
+		}
+		keys, err := fn(owners)
+		if err != nil {
+			return err
+		}
+		err = store.SetOwned(ctx, owner, keys, revision)
+		if !errors.Is(err, ErrOwnersChanged) || attempt >= maxOwnerConflictRetries {
+			return err
+		}
+	}
+}
+
+// WithOwner applies the desired objects on behalf of owner. Live objects recorded for owner
+// in store that are no longer desired are deleted, objects of other owners and objects
+// without owner are never touched. Desiring an object owned by another owner is an error.
+// Plans created with WithOwner must be applied with the same option, so ApplyPlan can record
+// the owned objects in store.
+func WithOwner(owner string, store OwnerStore) Option {
+	return func(o *options) {
+		o.owner = owner
+		o.ownerStore = store
+	}
+}
+
+// owned returns the keys of owners owned by owner.
+func owned(owners map[string]string, owner string) []string {
+	var keys []string
+	for key, o := range owners {
+		if o == owner {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkOwners returns an error if one of keys is owned by another owner than owner.
+func checkOwners(owners map[string]string, owner string, keys []string) error {
+	for _, key := range keys {
+		if o, ok := owners[key]; ok && o != owner {
+			return fmt.Errorf("object %s is owned by %s", key, o)
+		}
+	}
+	return nil
+}
+
+// without returns the keys of keys not contained in removed.
+func without(keys, removed []string) []string {
+	set := make(map[string]struct{}, len(removed))
+	for _, key := range removed {
+		set[key] = struct{}{}
+	}
+	var remaining []string
+	for _, key := range keys {
+		if _, ok := set[key]; !ok {
+			remaining = append(remaining, key)
+		}
+	}
+	return remaining
+}
+
+// union returns the sorted union of a and b.
+func union(a, b []string) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for _, key := range append(append([]string(nil), a...), b...) {
+		set[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MemoryOwnerStore is an OwnerStore keeping the owners in memory, e.g. for controllers
+// sharing a process.
+type MemoryOwnerStore struct {
+	mu       sync.Mutex
+	owners   map[string]string
+	revision int
+}
+
+// NewMemoryOwnerStore creates an empty MemoryOwnerStore.
+func NewMemoryOwnerStore() *MemoryOwnerStore {
+	return &MemoryOwnerStore{owners: make(map[string]string)}
+}
+
+func (s *MemoryOwnerStore) Owners(context.Context) (map[string]string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owners := make(map[string]string, len(s.owners))
+	for key, owner := range s.owners {
+		owners[key] = owner
+	}
+	return owners, strconv.Itoa(s.revision), nil
+}
+
+func (s *MemoryOwnerStore) SetOwned(_ context.Context, owner string, keys []string, revision string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if revision != strconv.Itoa(s.revision) {
+		return ErrOwnersChanged
+	}
+	s.revision++
+	for key, o := range s.owners {
+		if o == owner {
+			delete(s.owners, key)
+		}
+	}
+	for _, key := range keys {
+		s.owners[key] = owner
+	}
+	return nil
+}
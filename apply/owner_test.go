@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var _ = Describe("owners", func() {
+	ctx := context.TODO()
+	var c *client.ConnectedClient
+
+	BeforeEach(func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		c, err = client.Dial(ctx, sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	iface := func(id string, n byte) *api.Interface {
+		ipv4 := netip.AddrFrom4([4]byte{10, 0, 0, n})
+		ipv6 := netip.AddrFrom16([16]byte{0x20, 0x01, 0x0d, 0xb8, 15: n})
+		return &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: id},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap" + id},
+		}
+	}
+
+	It("should only prune objects of the same owner", func() {
+		store := NewMemoryOwnerStore()
+		_, err := c.CreateInterface(ctx, iface("unowned", 1))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(Apply(ctx, c, []api.Object{iface("a1", 2), iface("a2", 3)}, WithOwner("a", store))).To(Succeed())
+		Expect(Apply(ctx, c, []api.Object{iface("b1", 4)}, WithOwner("b", store))).To(Succeed())
+		Expect(ownersOf(store)).To(Equal(map[string]string{
+			"Interface/a1": "a", "Interface/a2": "a", "Interface/b1": "b",
+		}))
+
+		plan, err := NewPlan(ctx, c, []api.Object{iface("a1", 2)}, WithOwner("a", store))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Owner).To(Equal("a"))
+		Expect(plan.Changes).To(ConsistOf(HaveField("Key", "Interface/a2")))
+
+		By("applying the decoded plan")
+		data, err := json.Marshal(plan)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := &Plan{}
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(ApplyPlan(ctx, c, decoded)).To(MatchError("plan of owner a must be applied with an owner store"))
+		Expect(ApplyPlan(ctx, c, decoded, WithOwner("b", store))).To(MatchError(`plan of owner "a" cannot be applied for owner "b"`))
+		Expect(ApplyPlan(ctx, c, decoded, WithOwner("a", store))).To(Succeed())
+
+		for _, id := range []string{"unowned", "a1", "b1"} {
+			_, err := c.GetInterface(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err = c.GetInterface(ctx, "a2")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+		Expect(ownersOf(store)).To(Equal(map[string]string{"Interface/a1": "a", "Interface/b1": "b"}))
+	})
+
+	It("should refuse objects of other owners", func() {
+		store := NewMemoryOwnerStore()
+		Expect(Apply(ctx, c, []api.Object{iface("b1", 4)}, WithOwner("b", store))).To(Succeed())
+
+		_, err := NewPlan(ctx, c, []api.Object{iface("b1", 4)}, WithOwner("a", store))
+		Expect(err).To(MatchError("object Interface/b1 is owned by b"))
+	})
+
+	It("should get or create objects of the same owner", func() {
+		store := NewMemoryOwnerStore()
+		created, err := GetOrCreate(ctx, c, iface("a1", 2), "a", store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Spec.UnderlayRoute).NotTo(BeNil())
+		Expect(ownersOf(store)).To(Equal(map[string]string{"Interface/a1": "a"}))
+
+		got, err := GetOrCreate(ctx, c, iface("a1", 2), "a", store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Spec.UnderlayRoute).To(Equal(created.Spec.UnderlayRoute))
+
+		_, err = GetOrCreate(ctx, c, iface("a1", 2), "b", store)
+		Expect(err).To(MatchError(ErrOwnerConflict))
+		Expect(err).To(MatchError(ContainSubstring("object Interface/a1 is owned by a")))
+	})
+
+	It("should let only one of concurrent owners claim an object", func() {
+		store := NewMemoryOwnerStore()
+		errs := make(chan error, 8)
+		for i := 0; i < cap(errs); i++ {
+			owner := fmt.Sprintf("o%d", i)
+			go func() {
+				defer GinkgoRecover()
+				_, err := GetOrCreate(ctx, c, iface("vm1", 1), owner, store)
+				errs <- err
+			}()
+		}
+
+		var claimed int
+		for i := 0; i < cap(errs); i++ {
+			if err := <-errs; err == nil {
+				claimed++
+			} else {
+				Expect(err).To(MatchError(ErrOwnerConflict))
+			}
+		}
+		Expect(claimed).To(Equal(1))
+		Expect(ownersOf(store)).To(HaveLen(1))
+	})
+
+	It("should keep concurrent claims of the same owner", func() {
+		store := NewMemoryOwnerStore()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			id := fmt.Sprintf("vm%d", i)
+			n := byte(i + 1)
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := GetOrCreate(ctx, c, iface(id, n), "a", store)
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		Expect(ownersOf(store)).To(HaveLen(8))
+	})
+
+	It("should reject outdated owner writes", func() {
+		store := NewMemoryOwnerStore()
+		_, revision, err := store.Owners(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		setOwned(store, "b", "Interface/vm1")
+
+		Expect(store.SetOwned(ctx, "a", []string{"Interface/vm1"}, revision)).To(MatchError(ErrOwnersChanged))
+		Expect(ownersOf(store)).To(Equal(map[string]string{"Interface/vm1": "b"}))
+	})
+
+	It("should refuse existing objects without owner", func() {
+		store := NewMemoryOwnerStore()
+		_, err := c.CreateInterface(ctx, iface("unowned", 1))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = GetOrCreate(ctx, c, iface("unowned", 1), "a", store)
+		Expect(err).To(MatchError(ErrOwnerConflict))
+		Expect(ownersOf(store)).To(BeEmpty())
+	})
+})
+
+// ownersOf returns the owners recorded in store.
+func ownersOf(store OwnerStore) map[string]string {
+	owners, _, err := store.Owners(context.TODO())
+	Expect(err).NotTo(HaveOccurred())
+	return owners
+}
+
+// setOwned records keys for owner in store at its current revision.
+func setOwned(store OwnerStore, owner string, keys ...string) {
+	_, revision, err := store.Owners(context.TODO())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(store.SetOwned(context.TODO(), owner, keys, revision)).To(Succeed())
+}
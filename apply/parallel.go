@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// Operation is a client operation executed by ApplyAll.
+type Operation struct {
+	// ID identifies the operation in the errors returned by ApplyAll.
+	ID string
+	// Group orders operations: a group only starts once all operations of lower groups finished.
+	Group int
+	// Do executes the operation.
+	Do func(ctx context.Context) error
+}
+
+var (
+	// ErrSkipped is the error of operations ApplyAll never started, as ctx was done or an
+	// operation failed with WithFailFast.
+	ErrSkipped = stderrors.New("operation skipped")
+	// ErrAborted is the error of operations in flight that were canceled because another
+	// operation failed with WithFailFast.
+	ErrAborted = stderrors.New("operation aborted after another operation failed")
+)
+
+type applyAllOptions struct {
+	concurrency int
+	failFast    bool
+}
+
+// ApplyAllOption configures ApplyAll.
+type ApplyAllOption func(*applyAllOptions)
+
+// WithConcurrency limits ApplyAll to n operations in flight. Defaults to 1.
+func WithConcurrency(n int) ApplyAllOption {
+	return func(o *applyAllOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithFailFast makes ApplyAll stop at the first failed operation. Operations in flight are
+// canceled and fail with ErrAborted, operations not started yet fail with ErrSkipped.
+func WithFailFast(failFast bool) ApplyAllOption {
+	return func(o *applyAllOptions) {
+		o.failFast = failFast
+	}
+}
+
+// CreateOperations returns operations creating objs, grouped so that objects are only
+// created once the objects they depend on exist.
+func CreateOperations(c client.Client, objs []api.Object) ([]Operation, error) {
+	ops := make([]Operation, 0, len(objs))
+	for _, obj := range objs {
+		key, err := objectKey(obj)
+		if err != nil {
+			return nil, err
+		}
+		obj := obj
+		ops = append(ops, Operation{
+			ID:    key,
+			Group: stage(obj),
+			Do: func(ctx context.Context) error {
+				return create(ctx, c, obj)
+			},
+		})
+	}
+	return ops, nil
+}
+
+// ApplyAll executes ops group by group in ascending order, running the operations of a
+// group in parallel. Failed operations are returned as an errors.Aggregate keyed by
+// operation ID. Unless WithFailFast is set, later groups run despite failures. Operations not
+// started because ctx is done fail with ErrSkipped wrapping the error of ctx.
+func ApplyAll(ctx context.Context, ops []Operation, opts ...ApplyAllOption) error {
+	o := &applyAllOptions{concurrency: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+
+	groups := make(map[int][]Operation)
+	var order []int
+	for _, op := range ops {
+		if _, ok := groups[op.Group]; !ok {
+			order = append(order, op.Group)
+		}
+		groups[op.Group] = append(groups[op.Group], op)
+	}
+	sort.Ints(order)
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		agg     = &errors.Aggregate{}
+		skipped []Operation
+		aborted bool
+	)
+	for i, group := range order {
+		sem := make(chan struct{}, o.concurrency)
+		var wg sync.WaitGroup
+	ops:
+		for j, op := range groups[group] {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				skipped = append(skipped, groups[group][j:]...)
+				break ops
+			}
+			if ctx.Err() != nil {
+				<-sem
+				skipped = append(skipped, groups[group][j:]...)
+				break
+			}
+			wg.Add(1)
+			go func(op Operation) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if err := op.Do(ctx); err != nil {
+					mu.Lock()
+					defer mu.Unlock()
+					if aborted && parent.Err() == nil {
+						err = fmt.Errorf("%w: %w", ErrAborted, err)
+					}
+					agg.Add(op.ID, err)
+					if o.failFast && !aborted {
+						aborted = true
+						cancel()
+					}
+				}
+			}(op)
+		}
+		wg.Wait()
+
+		if ctx.Err() != nil {
+			for _, group := range order[i+1:] {
+				skipped = append(skipped, groups[group]...)
+			}
+			break
+		}
+	}
+	for _, op := range skipped {
+		if err := parent.Err(); err != nil {
+			agg.Add(op.ID, fmt.Errorf("%w: %w", ErrSkipped, err))
+		} else {
+			agg.Add(op.ID, ErrSkipped)
+		}
+	}
+	return agg.ErrorOrNil()
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("ApplyAll", func() {
+	It("should bound parallelism and order groups", func() {
+		var (
+			mu       sync.Mutex
+			finished []int
+			inFlight atomic.Int32
+			maxSeen  atomic.Int32
+		)
+		var ops []Operation
+		for i := 0; i < 12; i++ {
+			group := i % 3
+			ops = append(ops, Operation{
+				ID:    fmt.Sprint(i),
+				Group: group,
+				Do: func(ctx context.Context) error {
+					n := inFlight.Add(1)
+					for {
+						seen := maxSeen.Load()
+						if n <= seen || maxSeen.CompareAndSwap(seen, n) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					inFlight.Add(-1)
+					mu.Lock()
+					finished = append(finished, group)
+					mu.Unlock()
+					return nil
+				},
+			})
+		}
+
+		Expect(ApplyAll(context.TODO(), ops, WithConcurrency(2))).To(Succeed())
+		Expect(maxSeen.Load()).To(BeNumerically("<=", 2))
+		Expect(finished).To(Equal([]int{0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2}))
+	})
+
+	It("should collect failures and continue with later groups", func() {
+		var ran atomic.Bool
+		err := ApplyAll(context.TODO(), []Operation{
+			{ID: "a", Do: func(context.Context) error { return fmt.Errorf("boom") }},
+			{ID: "b", Group: 1, Do: func(context.Context) error { ran.Store(true); return nil }},
+		})
+		Expect(err).To(MatchError("a: boom"))
+		Expect(ran.Load()).To(BeTrue())
+	})
+
+	It("should stop at the first failure with fail fast", func() {
+		var ran atomic.Bool
+		err := ApplyAll(context.TODO(), []Operation{
+			{ID: "a", Do: func(context.Context) error { return fmt.Errorf("boom") }},
+			{ID: "b", Group: 1, Do: func(context.Context) error { ran.Store(true); return nil }},
+		}, WithFailFast(true))
+		agg, ok := err.(*errors.Aggregate)
+		Expect(ok).To(BeTrue())
+		Expect(agg.IDs()).To(Equal([]string{"a", "b"}))
+		Expect(agg.Get("a")).To(MatchError("boom"))
+		Expect(agg.Get("b")).To(MatchError(ErrSkipped))
+		Expect(ran.Load()).To(BeFalse())
+	})
+
+	It("should tell aborted operations apart with fail fast", func() {
+		started := make(chan struct{})
+		err := ApplyAll(context.TODO(), []Operation{
+			{ID: "a", Do: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+			{ID: "b", Do: func(context.Context) error {
+				<-started
+				return fmt.Errorf("boom")
+			}},
+			{ID: "c", Do: func(context.Context) error { return nil }},
+		}, WithConcurrency(2), WithFailFast(true))
+		agg, ok := err.(*errors.Aggregate)
+		Expect(ok).To(BeTrue())
+		Expect(agg.IDs()).To(ConsistOf("a", "b", "c"))
+		Expect(agg.Get("a")).To(MatchError(ErrAborted))
+		Expect(agg.Get("a")).To(MatchError(context.Canceled))
+		Expect(agg.Get("b")).To(MatchError("boom"))
+		Expect(agg.Get("c")).To(MatchError(ErrSkipped))
+	})
+
+	It("should fail operations skipped because the context is done", func() {
+		ctx, cancel := context.WithCancel(context.TODO())
+		err := ApplyAll(ctx, []Operation{
+			{ID: "a", Do: func(context.Context) error { cancel(); return nil }},
+			{ID: "b", Group: 1, Do: func(context.Context) error { return nil }},
+			{ID: "c", Group: 1, Do: func(context.Context) error { return nil }},
+		})
+		agg, ok := err.(*errors.Aggregate)
+		Expect(ok).To(BeTrue())
+		Expect(agg.IDs()).To(Equal([]string{"b", "c"}))
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(err).To(MatchError(ErrSkipped))
+	})
+
+	It("should group create operations by dependency", func() {
+		ops, err := CreateOperations(nil, []api.Object{
+			&api.Prefix{TypeMeta: api.TypeMeta{Kind: api.PrefixKind}, PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"}},
+			&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm1"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ops).To(HaveLen(2))
+		Expect(ops[0].Group).To(Equal(1))
+		Expect(ops[1].ID).To(Equal("Interface/vm1"))
+		Expect(ops[1].Group).To(Equal(0))
+	})
+})
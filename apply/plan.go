@@ -0,0 +1,363 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/snapshot"
+	"github.com/ironcore-dev/dpservice-go/tx"
+)
+
+type ChangeType string
+
+const (
+	ChangeCreate ChangeType = "create"
+	// ChangeUpdate replaces an object, as dpservice does not support in-place updates.
+	ChangeUpdate ChangeType = "update"
+	ChangeDelete ChangeType = "delete"
+)
+
+// Change is a single pending modification of dpservice.
+type Change struct {
+	Type ChangeType `json:"type"`
+	Key  string     `json:"key"`
+	// Desired is the object to create, unset for deletions.
+	Desired api.Object `json:"desired,omitempty"`
+	// Live is the object currently present in dpservice, unset for creations.
+	Live api.Object `json:"live,omitempty"`
+	// Diff lists the changed spec fields of an update.
+	Diff []string `json:"diff,omitempty"`
+}
+
+type encodedChange struct {
+	Type    ChangeType      `json:"type"`
+	Key     string          `json:"key"`
+	Kind    string          `json:"kind"`
+	Desired json.RawMessage `json:"desired,omitempty"`
+	Live    json.RawMessage `json:"live,omitempty"`
+	Diff    []string        `json:"diff,omitempty"`
+}
+
+// MarshalJSON encodes the change together with the kind of its objects, so that it can be
+// decoded again.
+func (c Change) MarshalJSON() ([]byte, error) {
+	encoded := encodedChange{Type: c.Type, Key: c.Key, Diff: c.Diff}
+	for _, o := range []struct {
+		obj api.Object
+		raw *json.RawMessage
+	}{{c.Desired, &encoded.Desired}, {c.Live, &encoded.Live}} {
+		if o.obj == nil {
+			continue
+		}
+		kind, err := api.DefaultScheme.KindOf(o.obj)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(o.obj)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Kind, *o.raw = kind, data
+	}
+	return json.Marshal(encoded)
+}
+
+func (c *Change) UnmarshalJSON(data []byte) error {
+	var encoded encodedChange
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decode := func(raw json.RawMessage) (api.Object, error) {
+		if len(raw) == 0 || string(raw) == "null" {
+			return nil, nil
+		}
+		obj, err := api.DefaultScheme.New(encoded.Kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", encoded.Kind, err)
+		}
+		return obj, nil
+	}
+	desired, err := decode(encoded.Desired)
+	if err != nil {
+		return err
+	}
+	live, err := decode(encoded.Live)
+	if err != nil {
+		return err
+	}
+	*c = Change{Type: encoded.Type, Key: encoded.Key, Desired: desired, Live: live, Diff: encoded.Diff}
+	return nil
+}
+
+// Plan is the set of changes needed to make dpservice match the desired objects.
+type Plan struct {
+	// Owner is the owner the plan was created for with WithOwner.
+	Owner string `json:"owner,omitempty"`
+	// Owned are the keys recorded for Owner once the plan is applied, Disowned the keys
+	// recorded for Owner that are no longer desired.
+	Owned    []string `json:"owned,omitempty"`
+	Disowned []string `json:"disowned,omitempty"`
+	Changes  []Change `json:"changes"`
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes of the given type.
+func (p *Plan) Count(typ ChangeType) int {
+	n := 0
+	for _, change := range p.Changes {
+		if change.Type == typ {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *Plan) String() string {
+	sb := &strings.Builder{}
+	for _, change := range p.Changes {
+		switch change.Type {
+		case ChangeCreate:
+			fmt.Fprintf(sb, "  + %s\n", change.Key)
+		case ChangeUpdate:
+			fmt.Fprintf(sb, "  ~ %s\n", change.Key)
+		case ChangeDelete:
+			fmt.Fprintf(sb, "  - %s\n", change.Key)
+		}
+		for _, diff := range change.Diff {
+			fmt.Fprintf(sb, "      %s\n", diff)
+		}
+	}
+	if !p.Empty() {
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(sb, "Plan: %d to create, %d to update, %d to delete.\n",
+		p.Count(ChangeCreate), p.Count(ChangeUpdate), p.Count(ChangeDelete))
+	return sb.String()
+}
+
+// NewPlan compares the desired objects with the live state of dpservice and returns
+// the changes needed without executing them.
+func NewPlan(ctx context.Context, c client.Client, desired []api.Object, opts ...Option) (*Plan, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var lbIDs []string
+	var vnis []uint32
+	desiredByKey := make(map[string]api.Object, len(desired))
+	desiredKeys := make([]string, 0, len(desired))
+	for _, obj := range desired {
+		key, err := objectKey(obj)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := desiredByKey[key]; ok {
+			return nil, fmt.Errorf("duplicate object %s", key)
+		}
+		desiredByKey[key] = obj
+		desiredKeys = append(desiredKeys, key)
+
+		switch obj := obj.(type) {
+		case *api.LoadBalancer:
+			lbIDs = append(lbIDs, obj.ID)
+		case *api.Route:
+			vnis = append(vnis, obj.VNI)
+		}
+	}
+
+	var owners map[string]string
+	if o.ownerStore != nil {
+		var err error
+		owners, _, err = o.ownerStore.Owners(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error reading owners: %w", err)
+		}
+		if err := checkOwners(owners, o.owner, desiredKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	live, err := snapshot.Take(ctx, c, snapshot.WithLoadBalancers(lbIDs...), snapshot.WithVNIs(vnis...))
+	if err != nil {
+		return nil, fmt.Errorf("error reading live state: %w", err)
+	}
+	liveByKey := map[string]api.Object{}
+	for _, obj := range live.Objects() {
+		key, err := objectKey(obj)
+		if err != nil {
+			return nil, err
+		}
+		liveByKey[key] = obj
+	}
+
+	plan := &Plan{}
+	if o.ownerStore != nil {
+		sort.Strings(desiredKeys)
+		plan.Owner = o.owner
+		plan.Owned = desiredKeys
+		plan.Disowned = without(owned(owners, o.owner), desiredKeys)
+	}
+	replaced := map[string]struct{}{}
+	for key, obj := range desiredByKey {
+		liveObj, ok := liveByKey[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Type: ChangeCreate, Key: key, Desired: obj})
+			continue
+		}
+		diffs, err := api.SpecDiff(obj, liveObj)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) > 0 {
+			replaced[key] = struct{}{}
+			plan.Changes = append(plan.Changes, Change{Type: ChangeUpdate, Key: key, Desired: obj, Live: liveObj, Diff: diffs})
+		}
+	}
+
+	for key, liveObj := range liveByKey {
+		if _, ok := replaced[key]; ok {
+			continue
+		}
+		desiredObj, isDesired := desiredByKey[key]
+		parent := parentKey(liveObj)
+		_, parentReplaced := replaced[parent]
+		switch {
+		case parentReplaced && isDesired:
+			plan.Changes = append(plan.Changes, Change{Type: ChangeUpdate, Key: key, Desired: desiredObj, Live: liveObj,
+				Diff: []string{"replaced together with " + parent}})
+		case parentReplaced:
+			plan.Changes = append(plan.Changes, Change{Type: ChangeDelete, Key: key, Live: liveObj,
+				Diff: []string{"removed together with " + parent}})
+		case !isDesired && o.ownerStore != nil:
+			if owners[key] == o.owner {
+				plan.Changes = append(plan.Changes, Change{Type: ChangeDelete, Key: key, Live: liveObj})
+			}
+		case !isDesired && o.prune:
+			plan.Changes = append(plan.Changes, Change{Type: ChangeDelete, Key: key, Live: liveObj})
+		}
+	}
+
+	// deleting a parent implicitly removes its children, list them so they can be rolled back
+	changed := make(map[string]ChangeType, len(plan.Changes))
+	for _, change := range plan.Changes {
+		changed[change.Key] = change.Type
+	}
+	for key, liveObj := range liveByKey {
+		parent := parentKey(liveObj)
+		if _, ok := changed[key]; ok || changed[parent] != ChangeDelete {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Type: ChangeDelete, Key: key, Live: liveObj,
+			Diff: []string{"removed together with " + parent}})
+	}
+
+	sort.Slice(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].Key < plan.Changes[j].Key
+	})
+	return plan, nil
+}
+
+// ApplyPlan executes a plan created by NewPlan. All deletions, including those of
+// replaced objects, run first in reverse dependency order, followed by all creations.
+// Plans of an owner must be applied WithOwner the same owner. Their objects are recorded for
+// the owner before any change, so a failed apply leaves no unowned objects behind, and the
+// desired objects once it succeeded. WithRollback undoes the changes made so far if a change
+// fails. Other options are ignored.
+func ApplyPlan(ctx context.Context, c client.Client, plan *Plan, opts ...Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch {
+	case plan.Owner != "" && o.ownerStore == nil:
+		return fmt.Errorf("plan of owner %s must be applied with an owner store", plan.Owner)
+	case plan.Owner != o.owner:
+		return fmt.Errorf("plan of owner %q cannot be applied for owner %q", plan.Owner, o.owner)
+	}
+	if plan.Owner != "" {
+		if err := updateOwned(ctx, o.ownerStore, plan.Owner, func(owners map[string]string) ([]string, error) {
+			if err := checkOwners(owners, plan.Owner, plan.Owned); err != nil {
+				return nil, err
+			}
+			return union(owned(owners, plan.Owner), plan.Owned), nil
+		}); err != nil {
+			return fmt.Errorf("error recording owned objects: %w", err)
+		}
+	}
+	var deletes, creates []Change
+	for _, change := range plan.Changes {
+		if change.Live != nil {
+			deletes = append(deletes, change)
+		}
+		if change.Desired != nil {
+			creates = append(creates, change)
+		}
+	}
+	sort.SliceStable(deletes, func(i, j int) bool {
+		return stage(deletes[i].Live) > stage(deletes[j].Live)
+	})
+	sort.SliceStable(creates, func(i, j int) bool {
+		return stage(creates[i].Desired) < stage(creates[j].Desired)
+	})
+
+	r := &tx.Recorder{}
+	if err := applyChanges(ctx, c, r, deletes, creates); err != nil {
+		if o.rollback {
+			// roll back even if the apply failed because ctx is done
+			rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+			defer cancel()
+			return errors.Join(err, r.Rollback(rollbackCtx))
+		}
+		return err
+	}
+	if plan.Owner != "" {
+		if err := updateOwned(ctx, o.ownerStore, plan.Owner, func(owners map[string]string) ([]string, error) {
+			return union(without(owned(owners, plan.Owner), plan.Disowned), plan.Owned), nil
+		}); err != nil {
+			return fmt.Errorf("error recording owned objects: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyChanges deletes and creates the objects of the changes, recording the inverse of every
+// successful change in r.
+func applyChanges(ctx context.Context, c client.Client, r *tx.Recorder, deletes, creates []Change) error {
+	for _, change := range deletes {
+		if err := remove(ctx, c, change.Live); err != nil {
+			return fmt.Errorf("error deleting %s: %w", change.Key, err)
+		}
+		live := change.Live
+		r.Record(change.Key, func(ctx context.Context) error {
+			return create(ctx, c, live)
+		})
+	}
+	for _, change := range creates {
+		if err := create(ctx, c, change.Desired); err != nil {
+			return fmt.Errorf("error creating %s: %w", change.Key, err)
+		}
+		desired := change.Desired
+		r.Record(change.Key, func(ctx context.Context) error {
+			return remove(ctx, c, desired)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plan", func() {
+	It("should render a readable summary", func() {
+		plan := &Plan{Changes: []Change{
+			{Type: ChangeCreate, Key: "Interface/vm1", Desired: &api.Interface{}},
+			{Type: ChangeUpdate, Key: "Nat/vm2", Desired: &api.Nat{}, Live: &api.Nat{}, Diff: []string{"spec.min_port: 100 -> 200"}},
+			{Type: ChangeDelete, Key: "VirtualIP/vm3", Live: &api.VirtualIP{}},
+		}}
+
+		Expect(plan.String()).To(Equal(`  + Interface/vm1
+  ~ Nat/vm2
+      spec.min_port: 100 -> 200
+  - VirtualIP/vm3
+
+Plan: 1 to create, 1 to update, 1 to delete.
+`))
+	})
+
+	It("should decode an encoded plan", func() {
+		natIP := netip.MustParseAddr("10.0.0.1")
+		plan := &Plan{Owner: "agent", Changes: []Change{
+			{Type: ChangeCreate, Key: "Interface/vm1", Desired: &api.Interface{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			}},
+			{
+				Type:    ChangeUpdate,
+				Key:     "Nat/vm2",
+				Desired: &api.Nat{TypeMeta: api.TypeMeta{Kind: api.NatKind}, NatMeta: api.NatMeta{InterfaceID: "vm2"}, Spec: api.NatSpec{NatIP: &natIP, MinPort: 200, MaxPort: 300}},
+				Live:    &api.Nat{TypeMeta: api.TypeMeta{Kind: api.NatKind}, NatMeta: api.NatMeta{InterfaceID: "vm2"}, Spec: api.NatSpec{NatIP: &natIP, MinPort: 100, MaxPort: 300}},
+				Diff:    []string{"spec.min_port: 100 -> 200"},
+			},
+			{Type: ChangeDelete, Key: "VirtualIP/vm3", Live: &api.VirtualIP{
+				TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+				VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm3"},
+			}},
+		}}
+
+		data, err := json.Marshal(plan)
+		Expect(err).NotTo(HaveOccurred())
+		decoded := &Plan{}
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.Owner).To(Equal("agent"))
+		Expect(decoded.Changes).To(Equal(plan.Changes))
+	})
+
+	It("should report an empty plan", func() {
+		plan := &Plan{}
+		Expect(plan.Empty()).To(BeTrue())
+		Expect(plan.String()).To(Equal("Plan: 0 to create, 0 to update, 0 to delete.\n"))
+	})
+})
+
+var _ = Describe("plan against dpservice", func() {
+	ctx := context.TODO()
+	var c *client.ConnectedClient
+
+	BeforeEach(func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		c, err = client.Dial(ctx, sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not update objects because of fields dpservice does not return", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		iface := &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec: api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap0",
+				PXE: &api.PXE{Server: "10.0.0.2", FileName: "boot.ipxe"}},
+		}
+		nat := &api.Nat{
+			TypeMeta: api.TypeMeta{Kind: api.NatKind},
+			NatMeta:  api.NatMeta{InterfaceID: "vm1"},
+			Spec:     api.NatSpec{NatIP: &ipv4, MinPort: 1000, MaxPort: 2000, Vni: 100},
+		}
+		Expect(Apply(ctx, c, []api.Object{iface, nat})).To(Succeed())
+
+		plan, err := NewPlan(ctx, c, []api.Object{iface, nat})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Empty()).To(BeTrue(), plan.String())
+	})
+})
+
+var _ = Describe("rollback", func() {
+	ctx := context.TODO()
+	var c *client.ConnectedClient
+
+	BeforeEach(func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		c, err = client.Dial(ctx, sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should undo the changes of a failed apply", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		live := &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "old"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap0"},
+		}
+		_, err := c.CreateInterface(ctx, live)
+		Expect(err).NotTo(HaveOccurred())
+
+		ipv4, ipv6 = netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("2001:db8::2")
+		desired := []api.Object{
+			&api.Interface{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "new"},
+				Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap1"},
+			},
+			&api.Prefix{
+				TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+				PrefixMeta: api.PrefixMeta{InterfaceID: "missing"},
+				Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.1.0.0/24")},
+			},
+		}
+		err = Apply(ctx, c, desired, WithPrune(), WithRollback())
+		Expect(errors.IsStatusErrorCode(err, errors.NO_VM)).To(BeTrue())
+
+		_, err = c.GetInterface(ctx, "old")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.GetInterface(ctx, "new")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+	})
+
+	It("should recreate the children of deleted parents after the context is done", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		_, err := c.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "old"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap0"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.CreatePrefix(ctx, &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: "old"},
+			Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.1.0.0/24")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		store := NewMemoryOwnerStore()
+		setOwned(store, "owner", "Interface/old")
+		ipv4, ipv6 = netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("2001:db8::2")
+		desired := []api.Object{&api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "new"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap1"},
+		}}
+		plan, err := NewPlan(ctx, c, desired, WithOwner("owner", store))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Count(ChangeDelete)).To(Equal(2))
+
+		applyCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		err = ApplyPlan(applyCtx, &cancelingClient{Client: c, cancel: cancel}, plan, WithOwner("owner", store), WithRollback())
+		Expect(err).To(MatchError(context.Canceled))
+
+		_, err = c.GetInterface(ctx, "old")
+		Expect(err).NotTo(HaveOccurred())
+		prefixes, err := c.ListPrefixes(ctx, "old")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes.Items).To(HaveLen(1))
+	})
+})
+
+// cancelingClient cancels the context of the apply when it creates an interface.
+type cancelingClient struct {
+	client.Client
+	cancel context.CancelFunc
+}
+
+func (c *cancelingClient) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	if iface.ID == "new" {
+		c.cancel()
+		return nil, ctx.Err()
+	}
+	return c.Client.CreateInterface(ctx, iface, ignoredErrors...)
+}
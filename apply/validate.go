@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// FieldError is a field of a manifest failing validation.
+type FieldError struct {
+	// Document is the index of the manifest in the stream.
+	Document int
+	// Line and Column locate the field in the stream, starting at 1.
+	Line, Column int
+	// Field is the path of the field, e.g. "spec.vni".
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("document %d, line %d: %s: %s", e.Document, e.Line, e.Field, e.Message)
+}
+
+// ValidationError lists the fields of manifests failing validation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		msgs[i] = field.Error()
+	}
+	return "invalid manifest: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks a stream of YAML or JSON manifests against the schemas of their kinds, see
+// api.OpenAPISchema, without decoding them. All failing fields are returned as a
+// *ValidationError locating them by line. Documents of unknown kinds are not validated,
+// Decode rejects them.
+func Validate(r io.Reader) error {
+	dec := yaml.NewDecoder(r)
+	var fields []FieldError
+	for i := 0; ; i++ {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("error decoding document %d: %w", i, err)
+		}
+		fields = append(fields, validateDocument(i, &doc)...)
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validateDocument validates the manifest doc with the index i.
+func validateDocument(i int, doc *yaml.Node) []FieldError {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	var kind string
+	if doc.Kind == yaml.MappingNode {
+		for j := 0; j+1 < len(doc.Content); j += 2 {
+			if doc.Content[j].Value == "kind" {
+				kind = doc.Content[j+1].Value
+			}
+		}
+	}
+	s, err := loadSchema(kind)
+	if err != nil {
+		return nil
+	}
+	v := &validator{document: i}
+	v.validate("", doc, s)
+	return v.fields
+}
+
+// schema is the subset of the OpenAPI v3 schemas of the api types used for validation.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Minimum    *int64             `json:"minimum"`
+	Maximum    *int64             `json:"maximum"`
+	Items      *schema            `json:"items"`
+	Properties map[string]*schema `json:"properties"`
+}
+
+func loadSchema(name string) (*schema, error) {
+	raw, ok := api.OpenAPISchema(name)
+	if !ok {
+		return nil, fmt.Errorf("no schema for %q", name)
+	}
+	s := &schema{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type validator struct {
+	document int
+	fields   []FieldError
+}
+
+func (v *validator) fail(path string, node *yaml.Node, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{
+		Document: v.document,
+		Line:     node.Line,
+		Column:   node.Column,
+		Field:    path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(path string, node *yaml.Node, s *schema) {
+	if s.Ref != "" {
+		var err error
+		if s, err = loadSchema(strings.TrimPrefix(s.Ref, "#/components/schemas/")); err != nil {
+			v.fail(path, node, "%v", err)
+			return
+		}
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			v.fail(path, node, "expected object")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field := key.Value
+			if path != "" {
+				field = path + "." + key.Value
+			}
+			property, ok := s.Properties[key.Value]
+			if !ok {
+				v.fail(field, key, "unknown field")
+				continue
+			}
+			v.validate(field, value, property)
+		}
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.fail(path, node, "expected array")
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), item, s.Items)
+		}
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.fail(path, node, "expected integer")
+			return
+		}
+		n, err := strconv.ParseInt(node.Value, 0, 64)
+		if err != nil {
+			v.fail(path, node, "invalid integer %s", node.Value)
+			return
+		}
+		v.validateRange(path, node, n, s)
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.fail(path, node, "expected boolean")
+		}
+	case "string":
+		if node.Kind != yaml.ScalarNode {
+			v.fail(path, node, "expected string")
+			return
+		}
+		if err := validateFormat(node.Value, s.Format); err != nil {
+			v.fail(path, node, "%v", err)
+		}
+	}
+}
+
+func (v *validator) validateRange(path string, node *yaml.Node, n int64, s *schema) {
+	lower, upper := s.Minimum, s.Maximum
+	if s.Format == "int32" {
+		if lower == nil {
+			lower = &minInt32
+		}
+		if upper == nil {
+			upper = &maxInt32
+		}
+	}
+	if lower != nil && n < *lower {
+		v.fail(path, node, "%d is less than %d", n, *lower)
+	}
+	if upper != nil && n > *upper {
+		v.fail(path, node, "%d is greater than %d", n, *upper)
+	}
+}
+
+var (
+	minInt32 int64 = -1 << 31
+	maxInt32 int64 = 1<<31 - 1
+)
+
+func validateFormat(value, format string) error {
+	var err error
+	switch format {
+	case "ip":
+		_, err = netip.ParseAddr(value)
+	case "cidr":
+		_, err = netip.ParsePrefix(value)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validating manifests", func() {
+	It("should accept valid manifests", func() {
+		Expect(Validate(strings.NewReader(manifests))).To(Succeed())
+	})
+
+	It("should locate all failing fields", func() {
+		err := Validate(strings.NewReader(`kind: Interface
+metadata:
+  id: vm1
+spec:
+  vni: one hundred
+  primary_ipv4: 10.200.1
+  prefixes: []
+---
+{"kind": "Route", "metadata": {"vni": -1}, "spec": {"next_hop": {"vni": 100, "address": "fc00::1"}}}
+---
+kind: FirewallRule
+spec:
+  protocol_filter:
+    tcp:
+      dst_port_lower: 4294967296
+`))
+		var validationErr *ValidationError
+		Expect(errors.As(err, &validationErr)).To(BeTrue())
+		Expect(validationErr.Fields).To(Equal([]FieldError{
+			{Document: 0, Line: 5, Column: 8, Field: "spec.vni", Message: "expected integer"},
+			{Document: 0, Line: 6, Column: 17, Field: "spec.primary_ipv4", Message: `ParseAddr("10.200.1"): IPv4 address too short`},
+			{Document: 0, Line: 7, Column: 3, Field: "spec.prefixes", Message: "unknown field"},
+			{Document: 1, Line: 9, Column: 39, Field: "metadata.vni", Message: "-1 is less than 0"},
+			{Document: 2, Line: 15, Column: 23, Field: "spec.protocol_filter.tcp.dst_port_lower", Message: "4294967296 is greater than 2147483647"},
+		}))
+		Expect(err).To(MatchError(HavePrefix("invalid manifest: document 0, line 5: spec.vni: expected integer; ")))
+	})
+
+	It("should be applied when decoding", func() {
+		_, err := Decode(strings.NewReader("kind: Interface\nspec:\n  vni: []\n"))
+		Expect(err).To(MatchError("error decoding document 0: invalid manifest: document 0, line 3: spec.vni: expected integer"))
+	})
+})
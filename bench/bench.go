@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bench measures the throughput and latency of client operations against dpservice.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+// Config configures a benchmark run.
+type Config struct {
+	// VNI the benchmark routes are created in. It should not be used by anything else,
+	// Routes puts it in use with a placeholder loadbalancer for the duration of the run.
+	VNI uint32
+	// Requests is the number of requests per operation, at most 65536.
+	Requests int
+	// Concurrency is the number of requests in flight. Defaults to 1.
+	Concurrency int
+}
+
+// Result is the outcome of benchmarking a single operation.
+type Result struct {
+	Operation string
+	Requests  int
+	Errors    int
+	Duration  time.Duration
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+}
+
+// Throughput returns the requests per second.
+func (r Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Duration.Seconds()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%-6s requests=%d errors=%d throughput=%.1f/s p50=%s p95=%s p99=%s",
+		r.Operation, r.Requests, r.Errors, r.Throughput(), r.P50, r.P95, r.P99)
+}
+
+// Route returns the i-th benchmark route of vni.
+func Route(vni uint32, i int) *api.Route {
+	prefix := netip.PrefixFrom(netip.AddrFrom4([4]byte{10, byte(i >> 8), byte(i), 0}), 24)
+	nextHop := netip.MustParseAddr("fc00::1")
+	return &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: api.RouteMeta{VNI: vni},
+		Spec: api.RouteSpec{
+			Prefix:  &prefix,
+			NextHop: &api.RouteNextHop{VNI: vni, IP: &nextHop},
+		},
+	}
+}
+
+// ReserveVNI puts vni in use by creating a loadbalancer in it, as dpservice only accepts
+// routes in VNIs in use. The returned function deletes the loadbalancer again.
+func ReserveVNI(ctx context.Context, c client.Client, vni uint32) (release func(context.Context) error, err error) {
+	id := fmt.Sprintf("dpbench-%d", vni)
+	vip := netip.AddrFrom4([4]byte{198, 18, byte(vni >> 8), byte(vni)})
+	if _, err := c.CreateLoadBalancer(ctx, &api.LoadBalancer{
+		TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+		LoadBalancerMeta: api.LoadBalancerMeta{ID: id},
+		Spec:             api.LoadBalancerSpec{VNI: vni, LbVipIP: &vip},
+	}); err != nil {
+		return nil, fmt.Errorf("error reserving vni %d: %w", vni, err)
+	}
+	return func(ctx context.Context) error {
+		_, err := c.DeleteLoadBalancer(ctx, id)
+		return err
+	}, nil
+}
+
+// Routes benchmarks creating, listing and deleting cfg.Requests routes.
+func Routes(ctx context.Context, c client.Client, cfg Config) (results []Result, err error) {
+	if cfg.Requests <= 0 || cfg.Requests > 1<<16 {
+		return nil, fmt.Errorf("requests must be between 1 and %d", 1<<16)
+	}
+	release, err := ReserveVNI(ctx, c, cfg.VNI)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if releaseErr := release(context.WithoutCancel(ctx)); err == nil && releaseErr != nil {
+			err = fmt.Errorf("error releasing vni %d: %w", cfg.VNI, releaseErr)
+		}
+	}()
+
+	create := Run(ctx, "create", cfg.Requests, cfg.Concurrency, func(ctx context.Context, i int) error {
+		_, err := c.CreateRoute(ctx, Route(cfg.VNI, i))
+		return err
+	})
+	list := Run(ctx, "list", cfg.Requests, cfg.Concurrency, func(ctx context.Context, _ int) error {
+		_, err := c.ListRoutes(ctx, cfg.VNI)
+		return err
+	})
+	del := Run(ctx, "delete", cfg.Requests, cfg.Concurrency, func(ctx context.Context, i int) error {
+		_, err := c.DeleteRoute(ctx, cfg.VNI, Route(cfg.VNI, i).Spec.Prefix)
+		return err
+	})
+	return []Result{create, list, del}, ctx.Err()
+}
+
+// Run calls op for 0 <= i < requests with the given concurrency and measures its latencies.
+func Run(ctx context.Context, operation string, requests, concurrency int, op func(ctx context.Context, i int) error) Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	latencies := make([]time.Duration, requests)
+	var (
+		next   atomic.Int64
+		errors atomic.Int64
+		wg     sync.WaitGroup
+	)
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= requests || ctx.Err() != nil {
+					return
+				}
+				callStart := time.Now()
+				if err := op(ctx, i); err != nil {
+					errors.Add(1)
+				}
+				latencies[i] = time.Since(callStart)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return Result{
+		Operation: operation,
+		Requests:  requests,
+		Errors:    int(errors.Load()),
+		Duration:  time.Since(start),
+		P50:       percentile(latencies, 0.50),
+		P95:       percentile(latencies, 0.95),
+		P99:       percentile(latencies, 0.99),
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
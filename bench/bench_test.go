@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var _ = Describe("Run", func() {
+	It("should count errors and compute percentiles", func() {
+		res := Run(context.TODO(), "op", 100, 4, func(_ context.Context, i int) error {
+			time.Sleep(time.Duration(i%10) * 100 * time.Microsecond)
+			if i%10 == 0 {
+				return errors.New("boom")
+			}
+			return nil
+		})
+		Expect(res.Requests).To(Equal(100))
+		Expect(res.Errors).To(Equal(10))
+		Expect(res.P50).To(BeNumerically("<=", res.P95))
+		Expect(res.P95).To(BeNumerically("<=", res.P99))
+		Expect(res.Throughput()).To(BeNumerically(">", 0))
+	})
+
+	It("should benchmark routes against the simulator", func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+		c, err := client.Dial(context.TODO(), sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(context.TODO(), c)
+		Expect(err).NotTo(HaveOccurred())
+
+		results, err := Routes(context.TODO(), c, Config{VNI: 4242, Requests: 50, Concurrency: 4})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(3))
+		for _, res := range results {
+			Expect(res.Errors).To(BeZero(), res.String())
+		}
+	})
+})
+
+// benchClient connects to the dpservice at DPSERVICE_ADDRESS, or to a simulator if unset.
+func benchClient(b *testing.B) client.Client {
+	address := os.Getenv("DPSERVICE_ADDRESS")
+	if address == "" {
+		sim, err := simulator.Start("")
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Cleanup(sim.Stop)
+		address = sim.Addr()
+	}
+
+	c, err := client.Dial(context.Background(), address)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = c.Close() })
+	if _, err := client.EnsureInitialized(context.Background(), c); err != nil {
+		b.Fatal(err)
+	}
+	return c
+}
+
+func reportResult(b *testing.B, res Result) {
+	if res.Errors > 0 {
+		b.Errorf("%d of %d requests failed", res.Errors, res.Requests)
+	}
+	b.ReportMetric(float64(res.P50.Microseconds()), "p50-µs")
+	b.ReportMetric(float64(res.P95.Microseconds()), "p95-µs")
+	b.ReportMetric(float64(res.P99.Microseconds()), "p99-µs")
+}
+
+const (
+	benchVNI         = 4243
+	benchConcurrency = 8
+)
+
+// benchRoute returns distinct routes for any number of requests by spreading them over VNIs.
+func benchRoute(i int) *api.Route {
+	return Route(benchVNI+uint32(i>>16), i&0xffff)
+}
+
+// reserveVNIs puts the VNIs of the first n benchmark routes in use until the benchmark ends.
+func reserveVNIs(b *testing.B, c client.Client, n int) {
+	for vni := uint32(benchVNI); vni <= benchVNI+uint32((n-1)>>16); vni++ {
+		release, err := ReserveVNI(context.Background(), c, vni)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Cleanup(func() { _ = release(context.Background()) })
+	}
+}
+
+func createRoutes(c client.Client, n int) Result {
+	return Run(context.Background(), "create", n, benchConcurrency, func(ctx context.Context, i int) error {
+		_, err := c.CreateRoute(ctx, benchRoute(i))
+		return err
+	})
+}
+
+func deleteRoutes(c client.Client, n int) Result {
+	return Run(context.Background(), "delete", n, benchConcurrency, func(ctx context.Context, i int) error {
+		route := benchRoute(i)
+		_, err := c.DeleteRoute(ctx, route.VNI, route.Spec.Prefix)
+		return err
+	})
+}
+
+func BenchmarkCreateRoute(b *testing.B) {
+	c := benchClient(b)
+	reserveVNIs(b, c, b.N)
+	b.ResetTimer()
+	reportResult(b, createRoutes(c, b.N))
+	b.StopTimer()
+	deleteRoutes(c, b.N)
+}
+
+func BenchmarkDeleteRoute(b *testing.B) {
+	c := benchClient(b)
+	reserveVNIs(b, c, b.N)
+	createRoutes(c, b.N)
+	b.ResetTimer()
+	reportResult(b, deleteRoutes(c, b.N))
+}
+
+func BenchmarkListRoutes(b *testing.B) {
+	c := benchClient(b)
+	const routes = 100
+	reserveVNIs(b, c, routes)
+	createRoutes(c, routes)
+	b.ResetTimer()
+	reportResult(b, Run(context.Background(), "list", b.N, benchConcurrency, func(ctx context.Context, _ int) error {
+		_, err := c.ListRoutes(ctx, benchVNI)
+		return err
+	}))
+	b.StopTimer()
+	deleteRoutes(c, routes)
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bgputil derives the underlay routes owned by a dpservice node and renders them as
+// announcements for the BGP speaker advertising them to the metal router.
+//
+//	anns, err := bgputil.Collect(ctx, c)
+//	if err != nil { ... }
+//	err = bgputil.WriteBirdConfig(w, anns, bgputil.BirdOptions{})
+package bgputil
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/snapshot"
+)
+
+// Announcement is an underlay route of this node that has to be announced via BGP.
+type Announcement struct {
+	// Prefix is the /128 underlay route.
+	Prefix netip.Prefix `json:"prefix"`
+	// Owner names the object the underlay route was allocated for, e.g. "interface/vm1"
+	// or "loadbalancer/lb1".
+	Owner string `json:"owner"`
+}
+
+// Collect takes a snapshot with c and returns the announcements of its objects.
+func Collect(ctx context.Context, c client.Client, opts ...snapshot.TakeOption) ([]Announcement, error) {
+	snap, err := snapshot.Take(ctx, c, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return FromSnapshot(snap), nil
+}
+
+// FromSnapshot returns the announcements of the underlay routes of interfaces, virtual IPs,
+// NATs, prefixes, load balancer prefixes and load balancers in snap, sorted by prefix.
+// Neighbor NATs are skipped, their underlay routes belong to other nodes.
+func FromSnapshot(snap *snapshot.Snapshot) []Announcement {
+	var anns []Announcement
+	add := func(route *netip.Addr, owner string) {
+		if route == nil || !route.IsValid() {
+			return
+		}
+		anns = append(anns, Announcement{Prefix: netip.PrefixFrom(*route, route.BitLen()), Owner: owner})
+	}
+
+	for _, iface := range snap.Interfaces {
+		add(iface.Spec.UnderlayRoute, "interface/"+iface.ID)
+	}
+	for _, vip := range snap.VirtualIPs {
+		add(vip.Spec.UnderlayRoute, "vip/"+vip.InterfaceID)
+	}
+	for _, nat := range snap.Nats {
+		add(nat.Spec.UnderlayRoute, "nat/"+nat.InterfaceID)
+	}
+	for _, prefix := range snap.Prefixes {
+		add(prefix.Spec.UnderlayRoute, fmt.Sprintf("prefix/%s/%s", prefix.InterfaceID, prefix.Spec.Prefix))
+	}
+	for _, prefix := range snap.LoadBalancerPrefixes {
+		add(prefix.Spec.UnderlayRoute, fmt.Sprintf("lbprefix/%s/%s", prefix.InterfaceID, prefix.Spec.Prefix))
+	}
+	for _, lb := range snap.LoadBalancers {
+		add(lb.Spec.UnderlayRoute, "loadbalancer/"+lb.ID)
+	}
+
+	sort.SliceStable(anns, func(i, j int) bool {
+		if c := anns[i].Prefix.Addr().Compare(anns[j].Prefix.Addr()); c != 0 {
+			return c < 0
+		}
+		return anns[i].Owner < anns[j].Owner
+	})
+	return anns
+}
+
+// Diff returns the announcements of desired missing in current, and the ones of current no
+// longer in desired. Announcements are compared by prefix only.
+func Diff(current, desired []Announcement) (announce, withdraw []Announcement) {
+	currentPrefixes := make(map[netip.Prefix]struct{}, len(current))
+	for _, ann := range current {
+		currentPrefixes[ann.Prefix] = struct{}{}
+	}
+	desiredPrefixes := make(map[netip.Prefix]struct{}, len(desired))
+	for _, ann := range desired {
+		desiredPrefixes[ann.Prefix] = struct{}{}
+		if _, ok := currentPrefixes[ann.Prefix]; !ok {
+			announce = append(announce, ann)
+		}
+	}
+	for _, ann := range current {
+		if _, ok := desiredPrefixes[ann.Prefix]; !ok {
+			withdraw = append(withdraw, ann)
+		}
+	}
+	return announce, withdraw
+}
+
+// DefaultBirdProtocol is the name of the static protocol written by WriteBirdConfig unless
+// configured otherwise.
+const DefaultBirdProtocol = "dpservice"
+
+// BirdOptions configures WriteBirdConfig.
+type BirdOptions struct {
+	// Protocol is the name of the static protocol.
+	Protocol string
+	// Via is the next hop of the routes. Without it, the routes are written as unreachable,
+	// which is enough for BIRD to originate them.
+	Via *netip.Addr
+}
+
+// WriteBirdConfig writes anns as a BIRD 2 static protocol, meant to be included from the BIRD
+// configuration and exported by its BGP protocol, e.g.
+//
+//	protocol static dpservice {
+//		ipv6;
+//		route fc00::1/128 unreachable; # interface/vm1
+//	}
+func WriteBirdConfig(w io.Writer, anns []Announcement, opts BirdOptions) error {
+	protocol := opts.Protocol
+	if protocol == "" {
+		protocol = DefaultBirdProtocol
+	}
+	target := "unreachable"
+	if opts.Via != nil {
+		target = "via " + opts.Via.String()
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "protocol static %s {\n\tipv6;\n", protocol)
+	for _, ann := range anns {
+		fmt.Fprintf(bw, "\troute %s %s; # %s\n", ann.Prefix, target, ann.Owner)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteGoBGPCommands writes the gobgp CLI commands adding announce to and deleting withdraw
+// from the global RIB, as returned by Diff. Users of the GoBGP API build an api.Path with an
+// IPAddressPrefix NLRI of the announcement's prefix instead.
+func WriteGoBGPCommands(w io.Writer, announce, withdraw []Announcement) error {
+	bw := bufio.NewWriter(w)
+	for _, ann := range withdraw {
+		fmt.Fprintf(bw, "gobgp global rib -a %s del %s\n", family(ann.Prefix), ann.Prefix)
+	}
+	for _, ann := range announce {
+		fmt.Fprintf(bw, "gobgp global rib -a %s add %s\n", family(ann.Prefix), ann.Prefix)
+	}
+	return bw.Flush()
+}
+
+func family(prefix netip.Prefix) string {
+	if prefix.Addr().Is4() {
+		return "ipv4"
+	}
+	return "ipv6"
+}
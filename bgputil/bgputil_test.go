@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgputil
+
+import (
+	"bytes"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/snapshot"
+)
+
+var _ = Describe("bgputil", func() {
+	route := func(s string) *netip.Addr {
+		addr := netip.MustParseAddr(s)
+		return &addr
+	}
+	prefix := netip.MustParsePrefix("10.0.1.0/24")
+
+	snap := &snapshot.Snapshot{
+		Interfaces: []api.Interface{
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{UnderlayRoute: route("fc00::3")}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}},
+		},
+		VirtualIPs: []api.VirtualIP{
+			{VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm1"}, Spec: api.VirtualIPSpec{UnderlayRoute: route("fc00::1")}},
+		},
+		Prefixes: []api.Prefix{
+			{PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"}, Spec: api.PrefixSpec{Prefix: prefix, UnderlayRoute: route("fc00::2")}},
+		},
+		NeighborNats: []api.NeighborNat{
+			{Spec: api.NeighborNatSpec{UnderlayRoute: route("fc00::99")}},
+		},
+		LoadBalancers: []api.LoadBalancer{
+			{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{UnderlayRoute: route("fc00::4")}},
+		},
+	}
+
+	It("should announce the underlay routes owned by the node", func() {
+		Expect(FromSnapshot(snap)).To(Equal([]Announcement{
+			{Prefix: netip.MustParsePrefix("fc00::1/128"), Owner: "vip/vm1"},
+			{Prefix: netip.MustParsePrefix("fc00::2/128"), Owner: "prefix/vm1/10.0.1.0/24"},
+			{Prefix: netip.MustParsePrefix("fc00::3/128"), Owner: "interface/vm1"},
+			{Prefix: netip.MustParsePrefix("fc00::4/128"), Owner: "loadbalancer/lb1"},
+		}))
+	})
+
+	It("should diff announcements by prefix", func() {
+		current := []Announcement{
+			{Prefix: netip.MustParsePrefix("fc00::1/128"), Owner: "vip/vm1"},
+			{Prefix: netip.MustParsePrefix("fc00::5/128"), Owner: "interface/vm5"},
+		}
+		announce, withdraw := Diff(current, FromSnapshot(snap))
+		Expect(announce).To(HaveLen(3))
+		Expect(withdraw).To(Equal(current[1:]))
+	})
+
+	It("should write a BIRD static protocol", func() {
+		var buf bytes.Buffer
+		Expect(WriteBirdConfig(&buf, FromSnapshot(snap)[:1], BirdOptions{})).To(Succeed())
+		Expect(buf.String()).To(Equal("protocol static dpservice {\n\tipv6;\n\troute fc00::1/128 unreachable; # vip/vm1\n}\n"))
+
+		buf.Reset()
+		Expect(WriteBirdConfig(&buf, FromSnapshot(snap)[:1], BirdOptions{Protocol: "underlay", Via: route("fe80::1")})).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("protocol static underlay {"))
+		Expect(buf.String()).To(ContainSubstring("route fc00::1/128 via fe80::1;"))
+	})
+
+	It("should write gobgp commands", func() {
+		var buf bytes.Buffer
+		anns := FromSnapshot(snap)
+		Expect(WriteGoBGPCommands(&buf, anns[:1], anns[1:2])).To(Succeed())
+		Expect(buf.String()).To(Equal("gobgp global rib -a ipv6 del fc00::2/128\ngobgp global rib -a ipv6 add fc00::1/128\n"))
+	})
+})
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capture records the packets dpservice captures on its interfaces. dpservice
+// sends captured Ethernet frames as UDP datagrams to a sink node, ToFile starts a capture
+// with this node as sink, receives the frames and writes them to pcap files, Stream writes
+// them as pcap stream, e.g. to Wireshark.
+//
+//	err := capture.ToFile(ctx, c, capture.Options{
+//		SinkNodeIP: underlayIP,
+//		UdpDstPort: 3000,
+//		Interfaces: []api.CaptureInterface{{InterfaceType: "vf", InterfaceInfo: "vm1"}},
+//	}, "/tmp/vm1.pcap")
+package capture
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+// stopTimeout bounds the CaptureStop call after the context of ToFile is done.
+const stopTimeout = 10 * time.Second
+
+// Options configures a capture.
+type Options struct {
+	// SinkNodeIP is the underlay IPv6 address of this node dpservice sends the packets to.
+	SinkNodeIP netip.Addr
+	// UdpSrcPort and UdpDstPort are the ports of the UDP datagrams carrying the packets.
+	UdpSrcPort uint32
+	UdpDstPort uint32
+	// Interfaces are the interfaces to capture, see api.CaptureInterface.
+	Interfaces []api.CaptureInterface
+
+	// ListenAddress is the UDP address the packets are received on. Defaults to all
+	// addresses with port UdpDstPort.
+	ListenAddress string
+	// SnapLen truncates packets longer than it, defaults to DefaultSnapLen.
+	SnapLen uint32
+	// MaxFileSize and MaxFileDuration rotate the capture file once it would grow larger or
+	// was written to longer than them. Zero values disable rotation.
+	MaxFileSize     int64
+	MaxFileDuration time.Duration
+}
+
+// ToFile captures packets into the pcap file at path until ctx is done, then stops the
+// capture and returns nil. Rotated files are named path.1, path.2 and so on. dpservice
+// captures once at a time, ToFile fails if another capture is active.
+func ToFile(ctx context.Context, c client.Client, opts Options, path string) error {
+	files := &rotatingFile{path: path, opts: opts}
+	if err := files.rotate(time.Now()); err != nil {
+		return err
+	}
+	defer files.close()
+
+	err := run(ctx, c, opts, files.writePacket)
+	if closeErr := files.close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Stream captures packets until ctx is done and writes them as pcap stream to w, e.g. a
+// FIFO read by Wireshark. The rotation options are ignored.
+func Stream(ctx context.Context, c client.Client, opts Options, w io.Writer) error {
+	pcap, err := NewPcapWriter(w, opts.SnapLen)
+	if err != nil {
+		return fmt.Errorf("error writing pcap header: %w", err)
+	}
+	return run(ctx, c, opts, pcap.WritePacket)
+}
+
+// run captures packets and passes them to write until ctx is done, then stops the capture.
+func run(ctx context.Context, c client.Client, opts Options, write func(ts time.Time, data []byte) error) error {
+	listenAddress := opts.ListenAddress
+	if listenAddress == "" {
+		listenAddress = net.JoinHostPort("::", strconv.Itoa(int(opts.UdpDstPort)))
+	}
+	conn, err := net.ListenPacket("udp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("error listening for captured packets: %w", err)
+	}
+	defer conn.Close()
+
+	sinkNodeIP := opts.SinkNodeIP
+	if _, err := c.CaptureStart(ctx, &api.CaptureStart{
+		TypeMeta: api.TypeMeta{Kind: api.CaptureStartKind},
+		CaptureStartMeta: api.CaptureStartMeta{Config: &api.CaptureConfig{
+			SinkNodeIP: &sinkNodeIP,
+			UdpSrcPort: opts.UdpSrcPort,
+			UdpDstPort: opts.UdpDstPort,
+		}},
+		Spec: api.CaptureStartSpec{Interfaces: opts.Interfaces},
+	}); err != nil {
+		return fmt.Errorf("error starting capture: %w", err)
+	}
+
+	err = receive(ctx, conn, write)
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stopTimeout)
+	defer cancel()
+	if _, stopErr := c.CaptureStop(stopCtx); stopErr != nil && err == nil {
+		err = fmt.Errorf("error stopping capture: %w", stopErr)
+	}
+	return err
+}
+
+// receive passes the datagrams received on conn to write until ctx is done.
+func receive(ctx context.Context, conn net.PacketConn, write func(ts time.Time, data []byte) error) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// unblock ReadFrom
+			_ = conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	buf := make([]byte, 1<<16)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("error receiving captured packets: %w", err)
+		}
+		if err := write(time.Now(), buf[:n]); err != nil {
+			return err
+		}
+	}
+}
+
+// rotatingFile writes packets to pcap files, rotating them according to the options.
+type rotatingFile struct {
+	path string
+	opts Options
+
+	index  int
+	file   *os.File
+	buf    *bufio.Writer
+	pcap   *PcapWriter
+	size   int64
+	opened time.Time
+}
+
+func (f *rotatingFile) writePacket(ts time.Time, data []byte) error {
+	size := int64(pcapRecordSize + len(data))
+	if f.size > pcapHeaderSize &&
+		(f.opts.MaxFileSize > 0 && f.size+size > f.opts.MaxFileSize ||
+			f.opts.MaxFileDuration > 0 && ts.Sub(f.opened) >= f.opts.MaxFileDuration) {
+		if err := f.rotate(ts); err != nil {
+			return err
+		}
+	}
+	if err := f.pcap.WritePacket(ts, data); err != nil {
+		return fmt.Errorf("error writing %s: %w", f.file.Name(), err)
+	}
+	f.size += size
+	return nil
+}
+
+// rotate closes the current file and opens the next one.
+func (f *rotatingFile) rotate(now time.Time) error {
+	if err := f.close(); err != nil {
+		return err
+	}
+	path := f.path
+	if f.index > 0 {
+		path = fmt.Sprintf("%s.%d", f.path, f.index)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating capture file: %w", err)
+	}
+	buf := bufio.NewWriter(file)
+	pcap, err := NewPcapWriter(buf, f.opts.SnapLen)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	f.index++
+	f.file, f.buf, f.pcap = file, buf, pcap
+	f.size, f.opened = pcapHeaderSize, now
+	return nil
+}
+
+func (f *rotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	file := f.file
+	f.file = nil
+	if err := f.buf.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error writing %s: %w", file.Name(), err)
+	}
+	return file.Close()
+}
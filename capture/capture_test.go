@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// readPcap returns the packets of the pcap file at path.
+func readPcap(path string) [][]byte {
+	data, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(len(data)).To(BeNumerically(">=", pcapHeaderSize))
+	Expect(binary.LittleEndian.Uint32(data)).To(Equal(uint32(pcapMagic)))
+	Expect(binary.LittleEndian.Uint32(data[20:])).To(Equal(uint32(linkTypeEthernet)))
+
+	var packets [][]byte
+	for data = data[pcapHeaderSize:]; len(data) > 0; {
+		n := binary.LittleEndian.Uint32(data[8:])
+		packets = append(packets, data[pcapRecordSize:pcapRecordSize+n])
+		data = data[pcapRecordSize+n:]
+	}
+	return packets
+}
+
+var _ = Describe("capture", func() {
+	It("should truncate packets to the snap length", func() {
+		var buf bytes.Buffer
+		w, err := NewPcapWriter(&buf, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.WritePacket(time.Unix(10, 5000), []byte("frame"))).To(Succeed())
+
+		record := buf.Bytes()[pcapHeaderSize:]
+		Expect(binary.LittleEndian.Uint32(record[0:])).To(Equal(uint32(10)))
+		Expect(binary.LittleEndian.Uint32(record[4:])).To(Equal(uint32(5)))
+		Expect(binary.LittleEndian.Uint32(record[8:])).To(Equal(uint32(4)))
+		Expect(binary.LittleEndian.Uint32(record[12:])).To(Equal(uint32(5)))
+		Expect(record[pcapRecordSize:]).To(Equal([]byte("fram")))
+	})
+
+	It("should write received packets to rotated files and stop the capture", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "vm1.pcap")
+		listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		listenAddress := listener.LocalAddr().String()
+		Expect(listener.Close()).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done := make(chan error)
+		go func() {
+			done <- ToFile(ctx, c, Options{
+				SinkNodeIP:    netip.MustParseAddr("fc00::1"),
+				UdpDstPort:    3000,
+				Interfaces:    []api.CaptureInterface{{InterfaceType: "vf", InterfaceInfo: "vm1"}},
+				ListenAddress: listenAddress,
+				MaxFileSize:   pcapHeaderSize + 2*(pcapRecordSize+6),
+			}, path)
+		}()
+
+		Eventually(func() bool {
+			status, err := c.CaptureStatus(ctx)
+			return err == nil && status.Spec.OperationStatus
+		}).Should(BeTrue())
+
+		sender, err := net.Dial("udp", listenAddress)
+		Expect(err).NotTo(HaveOccurred())
+		defer sender.Close()
+		for _, frame := range []string{"frame1", "frame2", "frame3"} {
+			_, err := sender.Write([]byte(frame))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		// rotation happens on the third packet
+		Eventually(func() error {
+			_, err := os.Stat(path + ".1")
+			return err
+		}).Should(Succeed())
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+
+		Expect(readPcap(path)).To(Equal([][]byte{[]byte("frame1"), []byte("frame2")}))
+		Expect(readPcap(path + ".1")).To(Equal([][]byte{[]byte("frame3")}))
+
+		status, err := c.CaptureStatus(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Spec.OperationStatus).To(BeFalse())
+	})
+})
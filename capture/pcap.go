@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// DefaultSnapLen is the maximum length of captured packets if none is configured.
+const DefaultSnapLen = 65535
+
+const (
+	pcapMagic        = 0xa1b2c3d4
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+	pcapHeaderSize   = 24
+	pcapRecordSize   = 16
+	linkTypeEthernet = 1
+)
+
+// PcapWriter writes packets in the classic pcap format with microsecond timestamps and
+// Ethernet link type, as read by tcpdump and Wireshark.
+type PcapWriter struct {
+	w       io.Writer
+	snapLen uint32
+}
+
+// NewPcapWriter writes the pcap file header to w. Packets longer than snapLen are truncated,
+// 0 means DefaultSnapLen.
+func NewPcapWriter(w io.Writer, snapLen uint32) (*PcapWriter, error) {
+	if snapLen == 0 {
+		snapLen = DefaultSnapLen
+	}
+	var header [pcapHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:], pcapMagic)
+	binary.LittleEndian.PutUint16(header[4:], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(header[6:], pcapVersionMinor)
+	binary.LittleEndian.PutUint32(header[16:], snapLen)
+	binary.LittleEndian.PutUint32(header[20:], linkTypeEthernet)
+	if _, err := w.Write(header[:]); err != nil {
+		return nil, err
+	}
+	return &PcapWriter{w: w, snapLen: snapLen}, nil
+}
+
+// WritePacket writes the Ethernet frame data captured at ts.
+func (p *PcapWriter) WritePacket(ts time.Time, data []byte) error {
+	captured := data
+	if uint32(len(captured)) > p.snapLen {
+		captured = captured[:p.snapLen]
+	}
+	usec := ts.UnixMicro()
+	var record [pcapRecordSize]byte
+	binary.LittleEndian.PutUint32(record[0:], uint32(usec/1e6))
+	binary.LittleEndian.PutUint32(record[4:], uint32(usec%1e6))
+	binary.LittleEndian.PutUint32(record[8:], uint32(len(captured)))
+	binary.LittleEndian.PutUint32(record[12:], uint32(len(data)))
+	if _, err := p.w.Write(record[:]); err != nil {
+		return err
+	}
+	_, err := p.w.Write(captured)
+	return err
+}
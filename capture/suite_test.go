@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package capture
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var (
+	sim *simulator.Simulator
+	c   client.Client
+)
+
+func TestCapture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Capture Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	sim, err = simulator.Start("")
+	Expect(err).NotTo(HaveOccurred())
+
+	conn, err := client.Dial(context.TODO(), sim.Addr())
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(conn.Close)
+	c = conn
+
+	_, err = client.EnsureInitialized(context.TODO(), c)
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	sim.Stop()
+})
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chaos injects faults into dpservice calls for resilience testing of controllers
+// built on the client.
+//
+//	injector := chaos.New(1).
+//		Add("CreateRoute", chaos.Fault{Probability: 0.1, StatusCode: errors.ROUTE_INSERT}).
+//		Add(chaos.AllMethods, chaos.Fault{Probability: 0.01, Code: codes.Unavailable})
+//	c := client.NewClientWithOptions(conn, client.WithUnaryInterceptors(injector.Interceptor()))
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AllMethods matches every RPC.
+const AllMethods = "*"
+
+// Fault describes a fault injected with a given probability. Latency is added first; then
+// the call fails with Code if set, or dpservice answers with StatusCode if set. Faults
+// failing the call do not reach dpservice.
+type Fault struct {
+	// Probability of the fault in [0, 1].
+	Probability float64
+	// Latency added to the call.
+	Latency time.Duration
+	// Code is the gRPC code of an injected transport error.
+	Code codes.Code
+	// StatusCode is the dpservice status code of an injected error response.
+	StatusCode uint32
+	// StatusMessage is the message of an injected error response.
+	StatusMessage string
+}
+
+// Injector injects faults into the calls of a client.
+type Injector struct {
+	mu     sync.Mutex
+	rand   *rand.Rand
+	faults map[string][]Fault
+}
+
+// New creates an injector without faults. The seed makes injected faults reproducible.
+func New(seed int64) *Injector {
+	return &Injector{
+		rand:   rand.New(rand.NewSource(seed)),
+		faults: make(map[string][]Fault),
+	}
+}
+
+// Add registers a fault for the given RPC name, e.g. "CreateInterface", or AllMethods.
+func (i *Injector) Add(method string, fault Fault) *Injector {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults[method] = append(i.faults[method], fault)
+	return i
+}
+
+// Reset removes all faults.
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = make(map[string][]Fault)
+}
+
+// pick returns the faults hit by a call of method.
+func (i *Injector) pick(method string) []Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var hit []Fault
+	for _, faults := range [][]Fault{i.faults[method], i.faults[AllMethods]} {
+		for _, fault := range faults {
+			if i.rand.Float64() < fault.Probability {
+				hit = append(hit, fault)
+			}
+		}
+	}
+	return hit
+}
+
+// Interceptor returns a unary interceptor injecting the registered faults.
+func (i *Injector) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for _, fault := range i.pick(method[strings.LastIndex(method, "/")+1:]) {
+			if fault.Latency > 0 {
+				timer := time.NewTimer(fault.Latency)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return status.FromContextError(ctx.Err()).Err()
+				case <-timer.C:
+				}
+			}
+			if fault.Code != codes.OK {
+				return status.Error(fault.Code, "injected fault")
+			}
+			if fault.StatusCode != 0 {
+				return setStatus(reply, fault.StatusCode, fault.StatusMessage)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// setStatus sets the status field all dpservice responses have.
+func setStatus(reply interface{}, code uint32, message string) error {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot inject status into %T", reply)
+	}
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("status")
+	if field == nil || field.Kind() != protoreflect.MessageKind {
+		return fmt.Errorf("cannot inject status into %T", reply)
+	}
+	m.Set(field, protoreflect.ValueOfMessage((&dpdkproto.Status{Code: code, Message: message}).ProtoReflect()))
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// okConn answers every call successfully without a dpservice.
+type okConn struct {
+	grpc.ClientConnInterface
+	calls int
+}
+
+func (c *okConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	c.calls++
+	return nil
+}
+
+var _ = Describe("Injector", func() {
+	It("should inject dpservice status errors", func() {
+		conn := &okConn{}
+		injector := New(1).Add("CreateRoute", Fault{Probability: 1, StatusCode: errors.ROUTE_INSERT, StatusMessage: "injected"})
+		c := client.NewClientWithOptions(conn, client.WithUnaryInterceptors(injector.Interceptor()))
+		raw := c.(client.RawClient).Raw()
+
+		_, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = raw.CreateRoute(context.TODO(), &dpdkproto.CreateRouteRequest{})
+		Expect(err).NotTo(HaveOccurred())
+
+		res, err := raw.CreateRoute(context.TODO(), &dpdkproto.CreateRouteRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.GetStatus().GetCode()).To(BeEquivalentTo(errors.ROUTE_INSERT))
+		Expect(conn.calls).To(Equal(1))
+	})
+
+	It("should inject transport errors and latency", func() {
+		injector := New(1).Add(AllMethods, Fault{Probability: 1, Latency: 10 * time.Millisecond, Code: codes.Unavailable})
+		c := client.NewClientWithOptions(&okConn{}, client.WithUnaryInterceptors(injector.Interceptor()))
+
+		start := time.Now()
+		_, err := c.ListInterfaces(context.TODO())
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		Expect(time.Since(start)).To(BeNumerically(">=", 10*time.Millisecond))
+	})
+
+	It("should inject faults with the given probability", func() {
+		injector := New(1).Add(AllMethods, Fault{Probability: 0.5, Code: codes.Unavailable})
+		raw := client.NewClientWithOptions(&okConn{}, client.WithUnaryInterceptors(injector.Interceptor())).(client.RawClient).Raw()
+
+		failures := 0
+		for i := 0; i < 1000; i++ {
+			if _, err := raw.CheckInitialized(context.TODO(), &dpdkproto.CheckInitializedRequest{}); err != nil {
+				failures++
+			}
+		}
+		Expect(failures).To(BeNumerically("~", 500, 60))
+	})
+})
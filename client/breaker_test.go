@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("circuit breaker", Label("breaker"), func() {
+	var (
+		now     time.Time
+		breaker *CircuitBreaker
+		callErr error
+		calls   int
+	)
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return callErr
+	}
+	call := func() error {
+		return breaker.Interceptor()(context.TODO(), "method", nil, nil, nil, invoker)
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		breaker = NewCircuitBreaker(2, time.Minute)
+		breaker.now = func() time.Time { return now }
+		callErr = status.Error(codes.Unavailable, "down")
+		calls = 0
+	})
+
+	It("should trip after consecutive failures and fast-fail", func() {
+		Expect(call()).NotTo(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerClosed))
+		Expect(call()).NotTo(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerOpen))
+
+		Expect(call()).To(MatchError(ErrCircuitOpen))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("should close again after a successful probe", func() {
+		Expect(call()).NotTo(Succeed())
+		Expect(call()).NotTo(Succeed())
+
+		now = now.Add(time.Minute)
+		Expect(breaker.State()).To(Equal(BreakerHalfOpen))
+
+		callErr = nil
+		Expect(call()).To(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerClosed))
+	})
+
+	It("should keep the state if the caller cancels the probe", func() {
+		Expect(call()).NotTo(Succeed())
+		Expect(call()).NotTo(Succeed())
+
+		now = now.Add(time.Minute)
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		callErr = status.Error(codes.Canceled, "context canceled")
+		Expect(breaker.Interceptor()(ctx, "method", nil, nil, nil, invoker)).NotTo(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerHalfOpen))
+
+		callErr = status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+		ctx, cancel = context.WithDeadline(context.TODO(), time.Now())
+		defer cancel()
+		Expect(breaker.Interceptor()(ctx, "method", nil, nil, nil, invoker)).NotTo(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerHalfOpen))
+
+		callErr = nil
+		Expect(call()).To(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerClosed))
+	})
+
+	It("should re-open after a failed probe", func() {
+		Expect(call()).NotTo(Succeed())
+		Expect(call()).NotTo(Succeed())
+
+		now = now.Add(time.Minute)
+		Expect(call()).NotTo(Succeed())
+		Expect(breaker.State()).To(Equal(BreakerOpen))
+	})
+})
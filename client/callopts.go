@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDMetadataKey is the gRPC metadata key carrying the caller's request ID.
+	RequestIDMetadataKey = "x-request-id"
+	// TenantIDMetadataKey is the gRPC metadata key carrying the caller's tenant ID.
+	TenantIDMetadataKey = "x-tenant-id"
+)
+
+// CallOption configures the client calls made with a context returned by WithCallOptions.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	metadata          []string
+	policy            callPolicy
+	lenientConversion *lenientConversion
+	operationType     *OperationType
+}
+
+// WithCallOptions returns a copy of ctx that applies the given options to every
+// client call made with it.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(o.metadata) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, o.metadata...)
+	}
+	if o.lenientConversion != nil {
+		ctx = context.WithValue(ctx, lenientConversionKey{}, o.lenientConversion)
+	}
+	if o.operationType != nil {
+		ctx = context.WithValue(ctx, operationTypeKey{}, *o.operationType)
+	}
+	if !o.policy.isZero() {
+		ctx = context.WithValue(ctx, callPolicyKey{}, callPolicyFromContext(ctx).merge(o.policy))
+	}
+	return ctx
+}
+
+// WithMetadata attaches the given key/value pairs as gRPC metadata to each call.
+// It panics if an odd number of arguments is passed.
+func WithMetadata(kv ...string) CallOption {
+	if len(kv)%2 == 1 {
+		panic("client: WithMetadata got an odd number of arguments")
+	}
+	return func(o *callOptions) {
+		o.metadata = append(o.metadata, kv...)
+	}
+}
+
+// WithRequestID attaches a request ID to each call so dpservice logs can be
+// correlated with the caller.
+func WithRequestID(id string) CallOption {
+	return WithMetadata(RequestIDMetadataKey, id)
+}
+
+// WithTenantID attaches a tenant ID to each call.
+func WithTenantID(id string) CallOption {
+	return WithMetadata(TenantIDMetadataKey, id)
+}
+
+// RequestIDFromContext returns the request ID attached to ctx, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	return lastMetadataValue(ctx, RequestIDMetadataKey)
+}
+
+// TenantIDFromContext returns the tenant ID attached to ctx, if any.
+func TenantIDFromContext(ctx context.Context) string {
+	return lastMetadataValue(ctx, TenantIDMetadataKey)
+}
+
+func lastMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
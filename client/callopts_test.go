@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ = Describe("call options", Label("callopts"), func() {
+	It("should attach request and tenant IDs as metadata", func() {
+		ctx := WithCallOptions(context.Background(), WithRequestID("req-1"), WithTenantID("tenant-a"), WithMetadata("x-foo", "bar"))
+
+		Expect(RequestIDFromContext(ctx)).To(Equal("req-1"))
+		Expect(TenantIDFromContext(ctx)).To(Equal("tenant-a"))
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		Expect(ok).To(BeTrue())
+		Expect(md.Get("x-foo")).To(ConsistOf("bar"))
+	})
+
+	It("should be usable with real calls", func() {
+		ctx := WithCallOptions(context.Background(), WithRequestID("req-2"))
+		_, err := dpdkClient.CheckInitialized(ctx)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
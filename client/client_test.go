@@ -6,6 +6,7 @@ package client
 import (
 	"context"
 	"net/netip"
+	"strings"
 
 	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/ironcore-dev/dpservice-go/errors"
@@ -540,6 +541,33 @@ var _ = Describe("interface related", func() {
 			Expect(fwRules.Items[0].Spec.Priority).To(Equal(uint32(1000)))
 		})
 
+		It("should list and delete by tag successfully", func() {
+			for _, tags := range [][]string{{"policy-a"}, {"policy-a", "policy-b"}, {"policy-b"}} {
+				tagged := fwRule
+				tagged.Spec.RuleID, err = api.FirewallRuleIDWithTags("Rule"+strings.Join(tags, "-"), tags...)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = dpdkClient.CreateFirewallRule(ctx, &tagged)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			fwRules, err := ListFirewallRulesByTag(ctx, dpdkClient, fwRule.InterfaceID, "policy-a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(fwRules.Items)).To(Equal(2))
+
+			fwRules, err = DeleteFirewallRulesByTag(ctx, dpdkClient, fwRule.InterfaceID, "policy-b")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(fwRules.Items)).To(Equal(2))
+
+			fwRules, err = DeleteFirewallRulesByTag(ctx, dpdkClient, fwRule.InterfaceID, "policy-a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(fwRules.Items)).To(Equal(1))
+			Expect(fwRules.Items[0].Spec.RuleID).To(Equal("Rulepolicy-a@policy-a"))
+
+			fwRules, err = dpdkClient.ListFirewallRules(ctx, fwRule.InterfaceID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(fwRules.Items)).To(Equal(1))
+		})
+
 		It("should delete successfully", func() {
 			res, err = dpdkClient.DeleteFirewallRule(ctx, fwRule.InterfaceID, fwRule.Spec.RuleID)
 			Expect(err).ToNot(HaveOccurred())
@@ -685,6 +713,24 @@ var _ = Describe("loadbalancer related", func() {
 			Expect(lbtargets.Items[0].Kind).To(Equal(api.LoadBalancerTargetKind))
 		})
 
+		It("should set targets successfully", func() {
+			targetIp := netip.MustParseAddr("ff80::6")
+			changes, err := SetLoadBalancerTargets(ctx, dpdkClient, lbtarget.LoadbalancerID, []netip.Addr{*lbtarget.Spec.TargetIP, targetIp})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes.Added).To(Equal([]netip.Addr{targetIp}))
+			Expect(changes.Removed).To(BeEmpty())
+			Expect(changes.Unchanged).To(Equal(1))
+
+			changes, err = SetLoadBalancerTargets(ctx, dpdkClient, lbtarget.LoadbalancerID, []netip.Addr{*lbtarget.Spec.TargetIP})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes.Added).To(BeEmpty())
+			Expect(changes.Removed).To(Equal([]netip.Addr{targetIp}))
+
+			lbtargets, err := dpdkClient.ListLoadBalancerTargets(ctx, lbtarget.LoadbalancerID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(lbtargets.Items)).To(Equal(1))
+		})
+
 		It("should delete successfully", func() {
 			res, err = dpdkClient.DeleteLoadBalancerTarget(ctx, lbtarget.LoadbalancerID, lbtarget.Spec.TargetIP)
 			Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,1355 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// ClientV2 is the v2 client surface. Every method takes a request struct embedding
+// RequestOptions instead of positional parameters and variadic ignored errors, so
+// parameters and options can be added without breaking callers. Requests must not be nil.
+// The v1 Client methods are thin adapters on top of it.
+type ClientV2 interface {
+	GetLoadBalancer(ctx context.Context, req *GetLoadBalancerRequest) (*api.LoadBalancer, error)
+	CreateLoadBalancer(ctx context.Context, req *CreateLoadBalancerRequest) (*api.LoadBalancer, error)
+	DeleteLoadBalancer(ctx context.Context, req *DeleteLoadBalancerRequest) (*api.LoadBalancer, error)
+
+	ListLoadBalancerPrefixes(ctx context.Context, req *ListLoadBalancerPrefixesRequest) (*api.PrefixList, error)
+	CreateLoadBalancerPrefix(ctx context.Context, req *CreateLoadBalancerPrefixRequest) (*api.LoadBalancerPrefix, error)
+	DeleteLoadBalancerPrefix(ctx context.Context, req *DeleteLoadBalancerPrefixRequest) (*api.LoadBalancerPrefix, error)
+
+	ListLoadBalancerTargets(ctx context.Context, req *ListLoadBalancerTargetsRequest) (*api.LoadBalancerTargetList, error)
+	CreateLoadBalancerTarget(ctx context.Context, req *CreateLoadBalancerTargetRequest) (*api.LoadBalancerTarget, error)
+	DeleteLoadBalancerTarget(ctx context.Context, req *DeleteLoadBalancerTargetRequest) (*api.LoadBalancerTarget, error)
+
+	GetInterface(ctx context.Context, req *GetInterfaceRequest) (*api.Interface, error)
+	ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*api.InterfaceList, error)
+	CreateInterface(ctx context.Context, req *CreateInterfaceRequest) (*api.Interface, error)
+	DeleteInterface(ctx context.Context, req *DeleteInterfaceRequest) (*api.Interface, error)
+
+	GetVirtualIP(ctx context.Context, req *GetVirtualIPRequest) (*api.VirtualIP, error)
+	CreateVirtualIP(ctx context.Context, req *CreateVirtualIPRequest) (*api.VirtualIP, error)
+	DeleteVirtualIP(ctx context.Context, req *DeleteVirtualIPRequest) (*api.VirtualIP, error)
+
+	ListPrefixes(ctx context.Context, req *ListPrefixesRequest) (*api.PrefixList, error)
+	CreatePrefix(ctx context.Context, req *CreatePrefixRequest) (*api.Prefix, error)
+	DeletePrefix(ctx context.Context, req *DeletePrefixRequest) (*api.Prefix, error)
+
+	CreateRoute(ctx context.Context, req *CreateRouteRequest) (*api.Route, error)
+	DeleteRoute(ctx context.Context, req *DeleteRouteRequest) (*api.Route, error)
+	ListRoutes(ctx context.Context, req *ListRoutesRequest) (*api.RouteList, error)
+
+	GetNat(ctx context.Context, req *GetNatRequest) (*api.Nat, error)
+	CreateNat(ctx context.Context, req *CreateNatRequest) (*api.Nat, error)
+	DeleteNat(ctx context.Context, req *DeleteNatRequest) (*api.Nat, error)
+	ListLocalNats(ctx context.Context, req *ListLocalNatsRequest) (*api.NatList, error)
+
+	CreateNeighborNat(ctx context.Context, req *CreateNeighborNatRequest) (*api.NeighborNat, error)
+	ListNats(ctx context.Context, req *ListNatsRequest) (*api.NatList, error)
+	DeleteNeighborNat(ctx context.Context, req *DeleteNeighborNatRequest) (*api.NeighborNat, error)
+	ListNeighborNats(ctx context.Context, req *ListNeighborNatsRequest) (*api.NatList, error)
+
+	ListFirewallRules(ctx context.Context, req *ListFirewallRulesRequest) (*api.FirewallRuleList, error)
+	CreateFirewallRule(ctx context.Context, req *CreateFirewallRuleRequest) (*api.FirewallRule, error)
+	GetFirewallRule(ctx context.Context, req *GetFirewallRuleRequest) (*api.FirewallRule, error)
+	DeleteFirewallRule(ctx context.Context, req *DeleteFirewallRuleRequest) (*api.FirewallRule, error)
+
+	CheckInitialized(ctx context.Context, req *CheckInitializedRequest) (*api.Initialized, error)
+	Initialize(ctx context.Context, req *InitializeRequest) (*api.Initialized, error)
+	GetVni(ctx context.Context, req *GetVniRequest) (*api.Vni, error)
+	ResetVni(ctx context.Context, req *ResetVniRequest) (*api.Vni, error)
+	GetVersion(ctx context.Context, req *GetVersionRequest) (*api.Version, error)
+
+	CaptureStart(ctx context.Context, req *CaptureStartRequest) (*api.CaptureStart, error)
+	CaptureStop(ctx context.Context, req *CaptureStopRequest) (*api.CaptureStop, error)
+	CaptureStatus(ctx context.Context, req *CaptureStatusRequest) (*api.CaptureStatus, error)
+}
+
+// RequestOptions are the options every v2 request embeds.
+type RequestOptions struct {
+	// IgnoredErrors are dpservice status codes that are not returned as error.
+	IgnoredErrors []uint32
+}
+
+func (o RequestOptions) ignored() [][]uint32 {
+	return [][]uint32{o.IgnoredErrors}
+}
+
+// v1RequestOptions converts the variadic ignored errors of the v1 methods. Like
+// errors.GetError, only the first set of ignored errors is used.
+func v1RequestOptions(ignoredErrors [][]uint32) RequestOptions {
+	if len(ignoredErrors) == 0 {
+		return RequestOptions{}
+	}
+	return RequestOptions{IgnoredErrors: ignoredErrors[0]}
+}
+
+// The requests of the ClientV2 methods, named after the method.
+
+type GetLoadBalancerRequest struct {
+	RequestOptions
+	ID string
+}
+
+type CreateLoadBalancerRequest struct {
+	RequestOptions
+	LoadBalancer *api.LoadBalancer
+}
+
+type DeleteLoadBalancerRequest struct {
+	RequestOptions
+	ID string
+}
+
+type ListLoadBalancerPrefixesRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type CreateLoadBalancerPrefixRequest struct {
+	RequestOptions
+	Prefix *api.LoadBalancerPrefix
+}
+
+type DeleteLoadBalancerPrefixRequest struct {
+	RequestOptions
+	InterfaceID string
+	Prefix      *netip.Prefix
+}
+
+type ListLoadBalancerTargetsRequest struct {
+	RequestOptions
+	LoadBalancerID string
+}
+
+type CreateLoadBalancerTargetRequest struct {
+	RequestOptions
+	Target *api.LoadBalancerTarget
+}
+
+type DeleteLoadBalancerTargetRequest struct {
+	RequestOptions
+	LoadBalancerID string
+	TargetIP       *netip.Addr
+}
+
+type GetInterfaceRequest struct {
+	RequestOptions
+	ID string
+}
+
+type ListInterfacesRequest struct {
+	RequestOptions
+}
+
+type CreateInterfaceRequest struct {
+	RequestOptions
+	Interface *api.Interface
+}
+
+type DeleteInterfaceRequest struct {
+	RequestOptions
+	ID string
+}
+
+type GetVirtualIPRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type CreateVirtualIPRequest struct {
+	RequestOptions
+	VirtualIP *api.VirtualIP
+}
+
+type DeleteVirtualIPRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type ListPrefixesRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type CreatePrefixRequest struct {
+	RequestOptions
+	Prefix *api.Prefix
+}
+
+type DeletePrefixRequest struct {
+	RequestOptions
+	InterfaceID string
+	Prefix      *netip.Prefix
+}
+
+type CreateRouteRequest struct {
+	RequestOptions
+	Route *api.Route
+}
+
+type DeleteRouteRequest struct {
+	RequestOptions
+	VNI    uint32
+	Prefix *netip.Prefix
+}
+
+type ListRoutesRequest struct {
+	RequestOptions
+	VNI uint32
+}
+
+type GetNatRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type CreateNatRequest struct {
+	RequestOptions
+	Nat *api.Nat
+}
+
+type DeleteNatRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type ListLocalNatsRequest struct {
+	RequestOptions
+	NatIP *netip.Addr
+}
+
+type CreateNeighborNatRequest struct {
+	RequestOptions
+	NeighborNat *api.NeighborNat
+}
+
+type ListNatsRequest struct {
+	RequestOptions
+	NatIP   *netip.Addr
+	NatType string
+}
+
+type DeleteNeighborNatRequest struct {
+	RequestOptions
+	NeighborNat *api.NeighborNat
+}
+
+type ListNeighborNatsRequest struct {
+	RequestOptions
+	NatIP *netip.Addr
+}
+
+type ListFirewallRulesRequest struct {
+	RequestOptions
+	InterfaceID string
+}
+
+type CreateFirewallRuleRequest struct {
+	RequestOptions
+	FirewallRule *api.FirewallRule
+}
+
+type GetFirewallRuleRequest struct {
+	RequestOptions
+	InterfaceID string
+	RuleID      string
+}
+
+type DeleteFirewallRuleRequest struct {
+	RequestOptions
+	InterfaceID string
+	RuleID      string
+}
+
+type CheckInitializedRequest struct {
+	RequestOptions
+}
+
+type InitializeRequest struct {
+	RequestOptions
+}
+
+type GetVniRequest struct {
+	RequestOptions
+	VNI     uint32
+	VNIType uint8
+}
+
+type ResetVniRequest struct {
+	RequestOptions
+	VNI     uint32
+	VNIType uint8
+}
+
+type GetVersionRequest struct {
+	RequestOptions
+	Version *api.Version
+}
+
+type CaptureStartRequest struct {
+	RequestOptions
+	Capture *api.CaptureStart
+}
+
+type CaptureStopRequest struct {
+	RequestOptions
+}
+
+type CaptureStatusRequest struct {
+	RequestOptions
+}
+
+type clientV2 struct {
+	dpdkproto.DPDKironcoreClient
+	identity *ClientIdentity
+	skew     *skewTracker
+
+	underlayRouteCallbacks []UnderlayRouteCallback
+}
+
+// NewClientV2 creates a ClientV2 on top of the generated gRPC client.
+func NewClientV2(protoClient dpdkproto.DPDKironcoreClient) ClientV2 {
+	return &clientV2{DPDKironcoreClient: protoClient}
+}
+
+// V2Provider is implemented by the clients created by this package. V2 returns the v2 surface
+// of the client, taking request structs. It shares the connection, interceptors and statistics
+// of the client. Clients decorated by middlewares do not implement it, as the v2 surface would
+// bypass the middlewares.
+type V2Provider interface {
+	V2() ClientV2
+}
+
+func (c *client) V2() ClientV2 {
+	return &clientV2{DPDKironcoreClient: c.DPDKironcoreClient, identity: c.identity, skew: c.skew, underlayRouteCallbacks: c.underlayRouteCallbacks}
+}
+
+func (c *clientV2) GetLoadBalancer(ctx context.Context, req *GetLoadBalancerRequest) (*api.LoadBalancer, error) {
+	res, err := c.DPDKironcoreClient.GetLoadBalancer(ctx, &dpdkproto.GetLoadBalancerRequest{
+		LoadbalancerId: []byte(req.ID),
+	})
+	if err != nil {
+		return &api.LoadBalancer{}, err
+	}
+	retLoadBalancer := &api.LoadBalancer{
+		TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+		LoadBalancerMeta: api.LoadBalancerMeta{ID: req.ID},
+		Status:           api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLoadBalancer, errors.GetError(res.Status, req.ignored())
+	}
+	return api.ProtoLoadBalancerToLoadBalancer(res, req.ID)
+}
+
+func (c *clientV2) CreateLoadBalancer(ctx context.Context, req *CreateLoadBalancerRequest) (*api.LoadBalancer, error) {
+	res, err := c.DPDKironcoreClient.CreateLoadBalancer(ctx, api.LoadBalancerToProtoCreateRequest(req.LoadBalancer))
+	if err != nil {
+		return &api.LoadBalancer{}, err
+	}
+	retLoadBalancer := &api.LoadBalancer{
+		TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+		LoadBalancerMeta: req.LoadBalancer.LoadBalancerMeta,
+		Status:           api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLoadBalancer, errors.GetError(res.Status, req.ignored())
+	}
+
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retLoadBalancer, err
+	}
+	retLoadBalancer.Spec = req.LoadBalancer.Spec
+	retLoadBalancer.Spec.UnderlayRoute = &underlayRoute
+
+	c.notifyUnderlayRoute(ctx, underlayRoute, retLoadBalancer)
+	return retLoadBalancer, nil
+}
+
+func (c *clientV2) DeleteLoadBalancer(ctx context.Context, req *DeleteLoadBalancerRequest) (*api.LoadBalancer, error) {
+	res, err := c.DPDKironcoreClient.DeleteLoadBalancer(ctx, &dpdkproto.DeleteLoadBalancerRequest{
+		LoadbalancerId: []byte(req.ID),
+	})
+	if err != nil {
+		return &api.LoadBalancer{}, err
+	}
+	retLoadBalancer := &api.LoadBalancer{
+		TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+		LoadBalancerMeta: api.LoadBalancerMeta{ID: req.ID},
+		Status:           api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLoadBalancer, errors.GetError(res.Status, req.ignored())
+	}
+	return retLoadBalancer, nil
+}
+
+func (c *clientV2) ListLoadBalancerPrefixes(ctx context.Context, req *ListLoadBalancerPrefixesRequest) (*api.PrefixList, error) {
+	res, err := c.DPDKironcoreClient.ListLoadBalancerPrefixes(ctx, &dpdkproto.ListLoadBalancerPrefixesRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]api.Prefix, 0, len(res.GetPrefixes()))
+	for _, dpdkPrefix := range res.GetPrefixes() {
+		prefix, err := api.ProtoPrefixToPrefix(req.InterfaceID, dpdkPrefix)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		prefix.Kind = api.LoadBalancerPrefixKind
+
+		prefixes = append(prefixes, *prefix)
+	}
+
+	return &api.PrefixList{
+		TypeMeta:       api.TypeMeta{Kind: "LoadBalancerPrefixList"},
+		PrefixListMeta: api.PrefixListMeta{InterfaceID: req.InterfaceID},
+		Items:          prefixes,
+		Status:         api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) CreateLoadBalancerPrefix(ctx context.Context, req *CreateLoadBalancerPrefixRequest) (*api.LoadBalancerPrefix, error) {
+	res, err := c.DPDKironcoreClient.CreateLoadBalancerPrefix(ctx, api.LoadBalancerPrefixToProtoCreateRequest(req.Prefix))
+	if err != nil {
+		return &api.LoadBalancerPrefix{}, err
+	}
+	retLBPrefix := &api.LoadBalancerPrefix{
+		TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+		LoadBalancerPrefixMeta: req.Prefix.LoadBalancerPrefixMeta,
+		Spec: api.LoadBalancerPrefixSpec{
+			Prefix: req.Prefix.Spec.Prefix,
+		},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLBPrefix, errors.GetError(res.Status, req.ignored())
+	}
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retLBPrefix, err
+	}
+	retLBPrefix.Spec.UnderlayRoute = &underlayRoute
+	c.notifyUnderlayRoute(ctx, underlayRoute, retLBPrefix)
+	return retLBPrefix, nil
+}
+
+func (c *clientV2) DeleteLoadBalancerPrefix(ctx context.Context, req *DeleteLoadBalancerPrefixRequest) (*api.LoadBalancerPrefix, error) {
+	lbPrefixAddr := req.Prefix.Addr()
+	res, err := c.DPDKironcoreClient.DeleteLoadBalancerPrefix(ctx, &dpdkproto.DeleteLoadBalancerPrefixRequest{
+		InterfaceId: []byte(req.InterfaceID),
+		Prefix: &dpdkproto.Prefix{
+			Ip:     api.NetIPAddrToProtoIpAddress(&lbPrefixAddr),
+			Length: uint32(req.Prefix.Bits()),
+		},
+	})
+	if err != nil {
+		return &api.LoadBalancerPrefix{}, err
+	}
+	retLBPrefix := &api.LoadBalancerPrefix{
+		TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+		LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: req.InterfaceID},
+		Spec:                   api.LoadBalancerPrefixSpec{Prefix: *req.Prefix},
+		Status:                 api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLBPrefix, errors.GetError(res.Status, req.ignored())
+	}
+	return retLBPrefix, nil
+}
+
+func (c *clientV2) ListLoadBalancerTargets(ctx context.Context, req *ListLoadBalancerTargetsRequest) (*api.LoadBalancerTargetList, error) {
+	res, err := c.DPDKironcoreClient.ListLoadBalancerTargets(ctx, &dpdkproto.ListLoadBalancerTargetsRequest{
+		LoadbalancerId: []byte(req.LoadBalancerID),
+	})
+	if err != nil {
+		return &api.LoadBalancerTargetList{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.LoadBalancerTargetList{
+			TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetListKind},
+			Status:   api.ProtoStatusToStatus(res.Status)}, errors.GetError(res.Status, req.ignored())
+	}
+
+	lbtargets := make([]api.LoadBalancerTarget, 0, len(res.GetTargetIps()))
+	for _, dpdkLBtarget := range res.GetTargetIps() {
+		var lbtarget api.LoadBalancerTarget
+		lbtarget.TypeMeta.Kind = api.LoadBalancerTargetKind
+		lbtarget.Spec.TargetIP, err = api.ProtoIpAddressToNetIPAddr(dpdkLBtarget)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		lbtarget.LoadBalancerTargetMeta.LoadbalancerID = req.LoadBalancerID
+
+		lbtargets = append(lbtargets, lbtarget)
+	}
+
+	return &api.LoadBalancerTargetList{
+		TypeMeta:                   api.TypeMeta{Kind: api.LoadBalancerTargetListKind},
+		LoadBalancerTargetListMeta: api.LoadBalancerTargetListMeta{LoadBalancerID: req.LoadBalancerID},
+		Items:                      lbtargets,
+		Status:                     api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) CreateLoadBalancerTarget(ctx context.Context, req *CreateLoadBalancerTargetRequest) (*api.LoadBalancerTarget, error) {
+	res, err := c.DPDKironcoreClient.CreateLoadBalancerTarget(ctx, api.LoadBalancerTargetToProtoCreateRequest(req.Target))
+	if err != nil {
+		return &api.LoadBalancerTarget{}, err
+	}
+	retLBTarget := &api.LoadBalancerTarget{
+		TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+		LoadBalancerTargetMeta: req.Target.LoadBalancerTargetMeta,
+		Status:                 api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retLBTarget, errors.GetError(res.Status, req.ignored())
+	}
+	retLBTarget.Spec = req.Target.Spec
+	return retLBTarget, nil
+}
+
+func (c *clientV2) DeleteLoadBalancerTarget(ctx context.Context, req *DeleteLoadBalancerTargetRequest) (*api.LoadBalancerTarget, error) {
+	res, err := c.DPDKironcoreClient.DeleteLoadBalancerTarget(ctx, &dpdkproto.DeleteLoadBalancerTargetRequest{
+		LoadbalancerId: []byte(req.LoadBalancerID),
+		TargetIp:       api.NetIPAddrToProtoIpAddress(req.TargetIP),
+	})
+	if err != nil {
+		return &api.LoadBalancerTarget{}, err
+	}
+	retLBTarget := &api.LoadBalancerTarget{
+		TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+		LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: req.LoadBalancerID},
+		Status:                 api.ProtoStatusToStatus(res.Status),
+	}
+	if res.Status.GetCode() != 0 {
+		return retLBTarget, errors.GetError(res.Status, req.ignored())
+	}
+	return retLBTarget, nil
+}
+
+func (c *clientV2) GetInterface(ctx context.Context, req *GetInterfaceRequest) (*api.Interface, error) {
+	res, err := c.DPDKironcoreClient.GetInterface(ctx, &dpdkproto.GetInterfaceRequest{
+		InterfaceId: []byte(req.ID),
+	})
+	if err != nil {
+		return &api.Interface{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: req.ID},
+			Status:        api.ProtoStatusToStatus(res.Status)}, errors.GetError(res.Status, req.ignored())
+	}
+	return api.ProtoInterfaceToInterface(res.GetInterface())
+}
+
+func (c *clientV2) ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*api.InterfaceList, error) {
+	res, err := c.DPDKironcoreClient.ListInterfaces(ctx, &dpdkproto.ListInterfacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	ifaces := make([]api.Interface, 0, len(res.GetInterfaces()))
+	for _, dpdkIface := range res.GetInterfaces() {
+		iface, err := api.ProtoInterfaceToInterface(dpdkIface)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		ifaces = append(ifaces, *iface)
+	}
+
+	return &api.InterfaceList{
+		TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind},
+		Items:    ifaces,
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) CreateInterface(ctx context.Context, req *CreateInterfaceRequest) (*api.Interface, error) {
+	res, err := c.DPDKironcoreClient.CreateInterface(ctx, api.InterfaceToProtoCreateRequest(req.Interface))
+	if err != nil {
+		return &api.Interface{}, err
+	}
+	retInterface := &api.Interface{
+		TypeMeta:      req.Interface.TypeMeta,
+		InterfaceMeta: req.Interface.InterfaceMeta,
+		Status:        api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retInterface, errors.GetError(res.Status, req.ignored())
+	}
+
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retInterface, err
+	}
+	if res.GetVf() == nil {
+		return retInterface, api.MissingField("virtual function")
+	}
+	retInterface.Spec = req.Interface.Spec
+	retInterface.Spec.UnderlayRoute = &underlayRoute
+	retInterface.Spec.VirtualFunction = &api.VirtualFunction{
+		Name: res.GetVf().GetName(),
+	}
+
+	c.notifyUnderlayRoute(ctx, underlayRoute, retInterface)
+	return retInterface, nil
+}
+
+func (c *clientV2) DeleteInterface(ctx context.Context, req *DeleteInterfaceRequest) (*api.Interface, error) {
+	res, err := c.DPDKironcoreClient.DeleteInterface(ctx, &dpdkproto.DeleteInterfaceRequest{
+		InterfaceId: []byte(req.ID),
+	})
+	if err != nil {
+		return &api.Interface{}, err
+	}
+	retInterface := &api.Interface{
+		TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+		InterfaceMeta: api.InterfaceMeta{ID: req.ID},
+		Status:        api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retInterface, errors.GetError(res.Status, req.ignored())
+	}
+	return retInterface, nil
+}
+
+func (c *clientV2) GetVirtualIP(ctx context.Context, req *GetVirtualIPRequest) (*api.VirtualIP, error) {
+	res, err := c.DPDKironcoreClient.GetVip(ctx, &dpdkproto.GetVipRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return &api.VirtualIP{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.VirtualIP{
+			TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+			VirtualIPMeta: api.VirtualIPMeta{InterfaceID: req.InterfaceID},
+			Status:        api.ProtoStatusToStatus(res.Status)}, errors.GetError(res.Status, req.ignored())
+	}
+	return api.ProtoVirtualIPToVirtualIP(req.InterfaceID, res)
+}
+
+func (c *clientV2) CreateVirtualIP(ctx context.Context, req *CreateVirtualIPRequest) (*api.VirtualIP, error) {
+	res, err := c.DPDKironcoreClient.CreateVip(ctx, api.VirtualIPToProtoCreateRequest(req.VirtualIP))
+	if err != nil {
+		return &api.VirtualIP{}, err
+	}
+	retVirtualIP := &api.VirtualIP{
+		TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+		VirtualIPMeta: req.VirtualIP.VirtualIPMeta,
+		Spec: api.VirtualIPSpec{
+			IP: req.VirtualIP.Spec.IP,
+		},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retVirtualIP, errors.GetError(res.Status, req.ignored())
+	}
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retVirtualIP, err
+	}
+	retVirtualIP.Spec.UnderlayRoute = &underlayRoute
+	c.notifyUnderlayRoute(ctx, underlayRoute, retVirtualIP)
+	return retVirtualIP, nil
+}
+
+func (c *clientV2) DeleteVirtualIP(ctx context.Context, req *DeleteVirtualIPRequest) (*api.VirtualIP, error) {
+	res, err := c.DPDKironcoreClient.DeleteVip(ctx, &dpdkproto.DeleteVipRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return &api.VirtualIP{}, err
+	}
+	retVirtualIP := &api.VirtualIP{
+		TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+		VirtualIPMeta: api.VirtualIPMeta{InterfaceID: req.InterfaceID},
+		Status:        api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retVirtualIP, errors.GetError(res.Status, req.ignored())
+	}
+	return retVirtualIP, nil
+}
+
+func (c *clientV2) ListPrefixes(ctx context.Context, req *ListPrefixesRequest) (*api.PrefixList, error) {
+	res, err := c.DPDKironcoreClient.ListPrefixes(ctx, &dpdkproto.ListPrefixesRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]api.Prefix, 0, len(res.GetPrefixes()))
+	for _, dpdkPrefix := range res.GetPrefixes() {
+		prefix, err := api.ProtoPrefixToPrefix(req.InterfaceID, dpdkPrefix)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		prefixes = append(prefixes, *prefix)
+	}
+
+	return &api.PrefixList{
+		TypeMeta:       api.TypeMeta{Kind: api.PrefixListKind},
+		PrefixListMeta: api.PrefixListMeta{InterfaceID: req.InterfaceID},
+		Items:          prefixes,
+		Status:         api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) CreatePrefix(ctx context.Context, req *CreatePrefixRequest) (*api.Prefix, error) {
+	res, err := c.DPDKironcoreClient.CreatePrefix(ctx, api.PrefixToProtoCreateRequest(req.Prefix))
+	if err != nil {
+		return &api.Prefix{}, err
+	}
+	retPrefix := &api.Prefix{
+		TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+		PrefixMeta: req.Prefix.PrefixMeta,
+		Spec:       api.PrefixSpec{Prefix: req.Prefix.Spec.Prefix},
+		Status:     api.ProtoStatusToStatus(res.Status),
+	}
+
+	if res.GetStatus().GetCode() != 0 {
+		return retPrefix, errors.GetError(res.Status, req.ignored())
+	}
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retPrefix, err
+	}
+	retPrefix.Spec.UnderlayRoute = &underlayRoute
+	c.notifyUnderlayRoute(ctx, underlayRoute, retPrefix)
+	return retPrefix, nil
+}
+
+func (c *clientV2) DeletePrefix(ctx context.Context, req *DeletePrefixRequest) (*api.Prefix, error) {
+	prefixAddr := req.Prefix.Addr()
+	res, err := c.DPDKironcoreClient.DeletePrefix(ctx, &dpdkproto.DeletePrefixRequest{
+		InterfaceId: []byte(req.InterfaceID),
+		Prefix: &dpdkproto.Prefix{
+			Ip:     api.NetIPAddrToProtoIpAddress(&prefixAddr),
+			Length: uint32(req.Prefix.Bits()),
+		},
+	})
+	if err != nil {
+		return &api.Prefix{}, err
+	}
+	retPrefix := &api.Prefix{
+		TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+		PrefixMeta: api.PrefixMeta{InterfaceID: req.InterfaceID},
+		Spec:       api.PrefixSpec{Prefix: *req.Prefix},
+		Status:     api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retPrefix, errors.GetError(res.Status, req.ignored())
+	}
+	return retPrefix, nil
+}
+
+func (c *clientV2) CreateRoute(ctx context.Context, req *CreateRouteRequest) (*api.Route, error) {
+	protoReq, err := api.RouteToProtoCreateRequest(req.Route)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.DPDKironcoreClient.CreateRoute(ctx, protoReq)
+	if err != nil {
+		return &api.Route{}, err
+	}
+	retRoute := &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: req.Route.RouteMeta,
+		Spec: api.RouteSpec{
+			Prefix:  req.Route.Spec.Prefix,
+			NextHop: &api.RouteNextHop{}},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retRoute, errors.GetError(res.Status, req.ignored())
+	}
+	retRoute.Spec = req.Route.Spec
+	return retRoute, nil
+}
+
+func (c *clientV2) DeleteRoute(ctx context.Context, req *DeleteRouteRequest) (*api.Route, error) {
+	routePrefixAddr := req.Prefix.Addr()
+	res, err := c.DPDKironcoreClient.DeleteRoute(ctx, &dpdkproto.DeleteRouteRequest{
+		Vni: req.VNI,
+		Route: &dpdkproto.Route{
+			Weight: 100,
+			Prefix: &dpdkproto.Prefix{
+				Ip:     api.NetIPAddrToProtoIpAddress(&routePrefixAddr),
+				Length: uint32(req.Prefix.Bits()),
+			},
+		},
+	})
+	if err != nil {
+		return &api.Route{}, err
+	}
+	retRoute := &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: api.RouteMeta{VNI: req.VNI},
+		Spec: api.RouteSpec{
+			Prefix:  req.Prefix,
+			NextHop: &api.RouteNextHop{},
+		},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retRoute, errors.GetError(res.Status, req.ignored())
+	}
+	return retRoute, nil
+}
+
+func (c *clientV2) ListRoutes(ctx context.Context, req *ListRoutesRequest) (*api.RouteList, error) {
+	res, err := c.DPDKironcoreClient.ListRoutes(ctx, &dpdkproto.ListRoutesRequest{
+		Vni: req.VNI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]api.Route, 0, len(res.GetRoutes()))
+	for _, dpdkRoute := range res.GetRoutes() {
+		route, err := api.ProtoRouteToRoute(req.VNI, dpdkRoute)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		routes = append(routes, *route)
+	}
+
+	return &api.RouteList{
+		TypeMeta:      api.TypeMeta{Kind: api.RouteListKind},
+		RouteListMeta: api.RouteListMeta{VNI: req.VNI},
+		Items:         routes,
+		Status:        api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) GetNat(ctx context.Context, req *GetNatRequest) (*api.Nat, error) {
+	res, err := c.DPDKironcoreClient.GetNat(ctx, &dpdkproto.GetNatRequest{InterfaceId: []byte(req.InterfaceID)})
+	if err != nil {
+		return &api.Nat{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.Nat{
+			TypeMeta: api.TypeMeta{Kind: api.NatKind},
+			NatMeta:  api.NatMeta{InterfaceID: req.InterfaceID},
+			Status:   api.ProtoStatusToStatus(res.Status)}, errors.GetError(res.Status, req.ignored())
+	}
+	return api.ProtoNatToNat(res, req.InterfaceID)
+}
+
+func (c *clientV2) CreateNat(ctx context.Context, req *CreateNatRequest) (*api.Nat, error) {
+	res, err := c.DPDKironcoreClient.CreateNat(ctx, api.NatToProtoCreateRequest(req.Nat))
+	if err != nil {
+		return &api.Nat{}, err
+	}
+	retNat := &api.Nat{
+		TypeMeta: api.TypeMeta{Kind: api.NatKind},
+		NatMeta:  req.Nat.NatMeta,
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retNat, errors.GetError(res.Status, req.ignored())
+	}
+
+	underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+	if err != nil {
+		return retNat, err
+	}
+
+	retNat.Spec = req.Nat.Spec
+	retNat.Spec.UnderlayRoute = &underlayRoute
+	c.notifyUnderlayRoute(ctx, underlayRoute, retNat)
+	return retNat, nil
+}
+
+func (c *clientV2) DeleteNat(ctx context.Context, req *DeleteNatRequest) (*api.Nat, error) {
+	res, err := c.DPDKironcoreClient.DeleteNat(ctx, &dpdkproto.DeleteNatRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return &api.Nat{}, err
+	}
+	retNat := &api.Nat{
+		TypeMeta: api.TypeMeta{Kind: api.NatKind},
+		NatMeta:  api.NatMeta{InterfaceID: req.InterfaceID},
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.Status.GetCode() != 0 {
+		return retNat, errors.GetError(res.Status, req.ignored())
+	}
+	return retNat, nil
+}
+
+func (c *clientV2) ListLocalNats(ctx context.Context, req *ListLocalNatsRequest) (*api.NatList, error) {
+	return c.ListNats(ctx, &ListNatsRequest{RequestOptions: req.RequestOptions, NatIP: req.NatIP, NatType: api.NatTypeLocal.String()})
+}
+
+func (c *clientV2) CreateNeighborNat(ctx context.Context, req *CreateNeighborNatRequest) (*api.NeighborNat, error) {
+	protoReq, err := api.NeighborNatToProtoCreateRequest(req.NeighborNat)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.DPDKironcoreClient.CreateNeighborNat(ctx, protoReq)
+	if err != nil {
+		return &api.NeighborNat{}, err
+	}
+	retnNat := &api.NeighborNat{
+		TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+		NeighborNatMeta: req.NeighborNat.NeighborNatMeta,
+		Status:          api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retnNat, errors.GetError(res.Status, req.ignored())
+	}
+	retnNat.Spec = req.NeighborNat.Spec
+	return retnNat, nil
+}
+
+func (c *clientV2) ListNats(ctx context.Context, req *ListNatsRequest) (*api.NatList, error) {
+	nType, err := api.ParseNatType(req.NatType)
+	if err != nil {
+		return nil, err
+	}
+
+	natIP := api.NetIPAddrToProtoIpAddress(req.NatIP)
+	// nat type not defined, try both types
+	var natEntries []*dpdkproto.NatEntry
+	var status *dpdkproto.Status
+	switch nType {
+	case api.NatTypeAny:
+		res1, err1 := c.DPDKironcoreClient.ListLocalNats(ctx, &dpdkproto.ListLocalNatsRequest{NatIp: natIP})
+		if err1 != nil {
+			return nil, err1
+		}
+		res2, err2 := c.DPDKironcoreClient.ListNeighborNats(ctx, &dpdkproto.ListNeighborNatsRequest{NatIp: natIP})
+		if err2 != nil {
+			return nil, err2
+		}
+		natEntries = append(natEntries, res1.NatEntries...)
+		natEntries = append(natEntries, res2.NatEntries...)
+	case api.NatTypeLocal:
+		res, err := c.DPDKironcoreClient.ListLocalNats(ctx, &dpdkproto.ListLocalNatsRequest{NatIp: natIP})
+		if err != nil {
+			return nil, err
+		}
+		natEntries = res.GetNatEntries()
+		status = res.Status
+	case api.NatTypeNeighbor:
+		res, err := c.DPDKironcoreClient.ListNeighborNats(ctx, &dpdkproto.ListNeighborNatsRequest{NatIp: natIP})
+		if err != nil {
+			return nil, err
+		}
+		natEntries = res.GetNatEntries()
+		status = res.Status
+	}
+
+	var nats = make([]api.Nat, 0, len(natEntries))
+	var nat api.Nat
+	for _, natEntry := range natEntries {
+
+		var underlayRoute, vipIP netip.Addr
+		if natEntry.GetUnderlayRoute() != nil {
+			underlayRoute, err = api.ParseResponseAddr("underlay route", natEntry.GetUnderlayRoute())
+			if err != nil {
+				if err := conversionError(ctx, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			nat.Spec.UnderlayRoute = &underlayRoute
+			nat.Spec.NatIP = nil
+			nat.Kind = api.NeighborNatKind
+		} else if natEntry.GetNatIp() != nil {
+			vipIP, err = api.ParseResponseAddr("nat ip", natEntry.GetNatIp().GetAddress())
+			if err != nil {
+				if err := conversionError(ctx, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			nat.Spec.NatIP = &vipIP
+			nat.Kind = api.NatKind
+		}
+		nat.Spec.MinPort = natEntry.MinPort
+		nat.Spec.MaxPort = natEntry.MaxPort
+		nat.Spec.Vni = natEntry.Vni
+		nats = append(nats, nat)
+	}
+	return &api.NatList{
+		TypeMeta:    api.TypeMeta{Kind: api.NatListKind},
+		NatListMeta: api.NatListMeta{NatIP: req.NatIP, NatType: req.NatType},
+		Items:       nats,
+		Status:      api.ProtoStatusToStatus(status),
+	}, nil
+}
+
+func (c *clientV2) DeleteNeighborNat(ctx context.Context, req *DeleteNeighborNatRequest) (*api.NeighborNat, error) {
+	res, err := c.DPDKironcoreClient.DeleteNeighborNat(ctx, &dpdkproto.DeleteNeighborNatRequest{
+		NatIp:   api.NetIPAddrToProtoIpAddress(req.NeighborNat.NatIP),
+		Vni:     req.NeighborNat.Spec.Vni,
+		MinPort: req.NeighborNat.Spec.MinPort,
+		MaxPort: req.NeighborNat.Spec.MaxPort,
+	})
+	if err != nil {
+		return &api.NeighborNat{}, err
+	}
+	nnat := &api.NeighborNat{
+		TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+		NeighborNatMeta: req.NeighborNat.NeighborNatMeta,
+		Status:          api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return nnat, errors.GetError(res.Status, req.ignored())
+	}
+	return nnat, nil
+}
+
+func (c *clientV2) ListNeighborNats(ctx context.Context, req *ListNeighborNatsRequest) (*api.NatList, error) {
+	return c.ListNats(ctx, &ListNatsRequest{RequestOptions: req.RequestOptions, NatIP: req.NatIP, NatType: "neigh"})
+}
+
+func (c *clientV2) ListFirewallRules(ctx context.Context, req *ListFirewallRulesRequest) (*api.FirewallRuleList, error) {
+	res, err := c.DPDKironcoreClient.ListFirewallRules(ctx, &dpdkproto.ListFirewallRulesRequest{
+		InterfaceId: []byte(req.InterfaceID),
+	})
+	if err != nil {
+		return &api.FirewallRuleList{}, err
+	}
+
+	fwRules := make([]api.FirewallRule, 0, len(res.GetRules()))
+	for _, dpdkFwRule := range res.GetRules() {
+		fwRule, err := api.ProtoFwRuleToFwRule(dpdkFwRule, req.InterfaceID)
+		if err != nil {
+			if err := conversionError(ctx, err); err != nil {
+				return &api.FirewallRuleList{}, err
+			}
+			continue
+		}
+		fwRules = append(fwRules, *fwRule)
+	}
+
+	return &api.FirewallRuleList{
+		TypeMeta:             api.TypeMeta{Kind: api.FirewallRuleListKind},
+		FirewallRuleListMeta: api.FirewallRuleListMeta{InterfaceID: req.InterfaceID},
+		Items:                fwRules,
+		Status:               api.ProtoStatusToStatus(res.Status),
+	}, nil
+}
+
+func (c *clientV2) CreateFirewallRule(ctx context.Context, req *CreateFirewallRuleRequest) (*api.FirewallRule, error) {
+	protoReq, err := api.FwRuleToProtoCreateRequest(req.FirewallRule)
+	if err != nil {
+		return &api.FirewallRule{}, err
+	}
+	// normalize the spec to the names dpservice reports back
+	req.FirewallRule.Spec.FirewallAction = api.FirewallAction(protoReq.Rule.Action).String()
+	req.FirewallRule.Spec.TrafficDirection = api.TrafficDirection(protoReq.Rule.Direction).String()
+
+	res, err := c.DPDKironcoreClient.CreateFirewallRule(ctx, protoReq)
+	if err != nil {
+		return &api.FirewallRule{}, err
+	}
+	retFwrule := &api.FirewallRule{
+		TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+		FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: req.FirewallRule.InterfaceID},
+		Spec:             api.FirewallRuleSpec{RuleID: req.FirewallRule.Spec.RuleID},
+		Status:           api.ProtoStatusToStatus(res.Status)}
+	if res.GetStatus().GetCode() != 0 {
+		return retFwrule, errors.GetError(res.Status, req.ignored())
+	}
+	retFwrule.Spec = req.FirewallRule.Spec
+	return retFwrule, nil
+}
+
+func (c *clientV2) GetFirewallRule(ctx context.Context, req *GetFirewallRuleRequest) (*api.FirewallRule, error) {
+	res, err := c.DPDKironcoreClient.GetFirewallRule(ctx, &dpdkproto.GetFirewallRuleRequest{
+		InterfaceId: []byte(req.InterfaceID),
+		RuleId:      []byte(req.RuleID),
+	})
+	if err != nil {
+		return &api.FirewallRule{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.FirewallRule{
+			TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+			FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: req.InterfaceID},
+			Spec:             api.FirewallRuleSpec{RuleID: req.RuleID},
+			Status:           api.ProtoStatusToStatus(res.Status),
+		}, errors.GetError(res.Status, req.ignored())
+	}
+
+	return api.ProtoFwRuleToFwRule(res.Rule, req.InterfaceID)
+}
+
+func (c *clientV2) DeleteFirewallRule(ctx context.Context, req *DeleteFirewallRuleRequest) (*api.FirewallRule, error) {
+	res, err := c.DPDKironcoreClient.DeleteFirewallRule(ctx, &dpdkproto.DeleteFirewallRuleRequest{
+		InterfaceId: []byte(req.InterfaceID),
+		RuleId:      []byte(req.RuleID),
+	})
+	if err != nil {
+		return &api.FirewallRule{}, err
+	}
+	retFwrule := &api.FirewallRule{
+		TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+		FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: req.InterfaceID},
+		Spec:             api.FirewallRuleSpec{RuleID: req.RuleID},
+		Status:           api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retFwrule, errors.GetError(res.Status, req.ignored())
+	}
+	return retFwrule, nil
+}
+
+func (c *clientV2) CheckInitialized(ctx context.Context, req *CheckInitializedRequest) (*api.Initialized, error) {
+	res, err := c.DPDKironcoreClient.CheckInitialized(ctx, &dpdkproto.CheckInitializedRequest{})
+	if err != nil {
+		return &api.Initialized{}, err
+	}
+	retInitialized := &api.Initialized{
+		TypeMeta: api.TypeMeta{Kind: api.InitializedKind},
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retInitialized, errors.GetError(res.Status, req.ignored())
+	}
+	retInitialized.Spec.UUID = res.Uuid
+	return retInitialized, nil
+}
+
+func (c *clientV2) Initialize(ctx context.Context, req *InitializeRequest) (*api.Initialized, error) {
+	res, err := c.DPDKironcoreClient.Initialize(ctx, &dpdkproto.InitializeRequest{})
+	if err != nil {
+		return &api.Initialized{}, err
+	}
+	retInit := &api.Initialized{
+		TypeMeta: api.TypeMeta{Kind: api.InitializedKind},
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retInit, errors.GetError(res.Status, req.ignored())
+	}
+	retInit.Spec.UUID = res.Uuid
+	return retInit, nil
+}
+
+func (c *clientV2) GetVni(ctx context.Context, req *GetVniRequest) (*api.Vni, error) {
+	res, err := c.DPDKironcoreClient.CheckVniInUse(ctx, &dpdkproto.CheckVniInUseRequest{
+		Vni:  req.VNI,
+		Type: api.VniType(req.VNIType).Proto(),
+	})
+	if err != nil {
+		return &api.Vni{}, err
+	}
+	retVni := &api.Vni{
+		TypeMeta: api.TypeMeta{Kind: api.VniKind},
+		VniMeta:  api.VniMeta{VNI: req.VNI, VniType: req.VNIType},
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retVni, errors.GetError(res.Status, req.ignored())
+	}
+	retVni.Spec.InUse = res.InUse
+	return retVni, nil
+}
+
+func (c *clientV2) ResetVni(ctx context.Context, req *ResetVniRequest) (*api.Vni, error) {
+	res, err := c.DPDKironcoreClient.ResetVni(ctx, &dpdkproto.ResetVniRequest{
+		Vni:  req.VNI,
+		Type: api.VniType(req.VNIType).Proto(),
+	})
+	if err != nil {
+		return &api.Vni{}, err
+	}
+	retVni := &api.Vni{
+		TypeMeta: api.TypeMeta{Kind: api.VniKind},
+		VniMeta:  api.VniMeta{VNI: req.VNI, VniType: req.VNIType},
+		Status:   api.ProtoStatusToStatus(res.Status),
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return retVni, errors.GetError(res.Status, req.ignored())
+	}
+	return retVni, nil
+}
+
+func (c *clientV2) GetVersion(ctx context.Context, req *GetVersionRequest) (*api.Version, error) {
+	version := req.Version
+	if version == nil {
+		version = &api.Version{TypeMeta: api.TypeMeta{Kind: api.VersionKind}}
+	}
+	if c.identity != nil {
+		if version.ClientName == "" {
+			version.ClientName = c.identity.clientName()
+		}
+		if version.ClientVersion == "" {
+			version.ClientVersion = c.identity.Version
+		}
+	}
+	version.ClientProtocol = strings.TrimSpace(dpdkproto.GeneratedFrom)
+	res, err := c.DPDKironcoreClient.GetVersion(ctx, &dpdkproto.GetVersionRequest{
+		ClientProtocol: version.ClientProtocol,
+		ClientName:     version.ClientName,
+		ClientVersion:  version.ClientVersion,
+	})
+	if err != nil {
+		return &api.Version{}, err
+	}
+	version.Status = api.ProtoStatusToStatus(res.Status)
+	if res.GetStatus().GetCode() != 0 {
+		return version, errors.GetError(res.Status, req.ignored())
+	}
+	version.Spec.ServiceProtocol = res.GetServiceProtocol()
+	version.Spec.ServiceVersion = res.GetServiceVersion()
+	c.skew.observe(version.ClientProtocol, strings.TrimSpace(version.Spec.ServiceProtocol))
+	return version, nil
+}
+
+func (c *clientV2) CaptureStart(ctx context.Context, req *CaptureStartRequest) (*api.CaptureStart, error) {
+	var interfaces = make([]*dpdkproto.CapturedInterface, 0, len(req.Capture.Spec.Interfaces))
+
+	for _, iface := range req.Capture.Spec.Interfaces {
+		protoInterface := &dpdkproto.CapturedInterface{}
+
+		captureIfacetype, err := api.CaptureIfaceTypeToProtoIfaceType(iface.InterfaceType)
+		if err != nil {
+			fmt.Printf("error converting interface type for interface %s\n", iface.InterfaceInfo)
+			continue
+		}
+
+		protoInterface.InterfaceType = captureIfacetype
+		err = api.FillCaptureIfaceInfo(iface.InterfaceInfo, protoInterface)
+		if err != nil {
+			fmt.Printf("error filling interface info for interface %s\n", iface.InterfaceInfo)
+			continue
+		}
+
+		interfaces = append(interfaces, protoInterface)
+	}
+
+	res, err := c.DPDKironcoreClient.CaptureStart(ctx, &dpdkproto.CaptureStartRequest{
+		CaptureConfig: &dpdkproto.CaptureConfig{
+			SinkNodeIp: api.NetIPAddrToProtoIpAddress(req.Capture.CaptureStartMeta.Config.SinkNodeIP),
+			UdpSrcPort: req.Capture.CaptureStartMeta.Config.UdpSrcPort,
+			UdpDstPort: req.Capture.CaptureStartMeta.Config.UdpDstPort,
+			Interfaces: interfaces,
+		},
+	})
+
+	if err != nil {
+		return &api.CaptureStart{}, err
+	}
+	req.Capture.Status = api.ProtoStatusToStatus(res.Status)
+	if res.GetStatus().GetCode() != 0 {
+		return req.Capture, errors.GetError(res.Status, req.ignored())
+	}
+
+	return req.Capture, nil
+}
+
+func (c *clientV2) CaptureStop(ctx context.Context, req *CaptureStopRequest) (*api.CaptureStop, error) {
+	res, err := c.DPDKironcoreClient.CaptureStop(ctx, &dpdkproto.CaptureStopRequest{})
+	if err != nil {
+		return &api.CaptureStop{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.CaptureStop{}, errors.GetError(res.Status, req.ignored())
+	}
+
+	capture := &api.CaptureStop{
+		Spec: api.CaptureStopSpec{
+			InterfaceCount: res.StoppedInterfaceCnt,
+		},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+
+	return capture, nil
+}
+
+func (c *clientV2) CaptureStatus(ctx context.Context, req *CaptureStatusRequest) (*api.CaptureStatus, error) {
+	res, err := c.DPDKironcoreClient.CaptureStatus(ctx, &dpdkproto.CaptureStatusRequest{})
+	if err != nil {
+		return &api.CaptureStatus{}, err
+	}
+	if res.GetStatus().GetCode() != 0 {
+		return &api.CaptureStatus{}, errors.GetError(res.Status, req.ignored())
+	}
+
+	if !res.GetIsActive() {
+		capture := &api.CaptureStatus{
+			Spec: api.CaptureGetStatusSpec{
+				OperationStatus: false,
+			},
+			Status: api.ProtoStatusToStatus(res.Status),
+		}
+		return capture, nil
+	}
+
+	if res.GetCaptureConfig() == nil {
+		return &api.CaptureStatus{}, api.MissingField("capture config")
+	}
+	capture_interfaces := make([]api.CaptureInterface, len(res.CaptureConfig.GetInterfaces()))
+	for i, cap_iface := range res.CaptureConfig.GetInterfaces() {
+		capture_interfaces[i].InterfaceType, err = api.ProtoIfaceTypeToCaptureIfaceType(cap_iface.InterfaceType)
+		if err != nil {
+			return &api.CaptureStatus{}, err
+		}
+		capture_interfaces[i].InterfaceInfo, err = api.ProtoIfaceInfoToCaptureIfaceInfo(cap_iface)
+		if err != nil {
+			return &api.CaptureStatus{}, err
+		}
+	}
+
+	sink_ip, err := api.ProtoIpAddressToNetIPAddr(res.CaptureConfig.GetSinkNodeIp())
+	if err != nil {
+		return &api.CaptureStatus{}, err
+	}
+
+	capture := &api.CaptureStatus{
+		Spec: api.CaptureGetStatusSpec{
+			OperationStatus: true,
+			Config: api.CaptureConfig{
+				SinkNodeIP: sink_ip,
+				UdpSrcPort: res.CaptureConfig.GetUdpSrcPort(),
+				UdpDstPort: res.CaptureConfig.GetUdpDstPort(),
+			},
+			Interfaces: capture_interfaces,
+		},
+		Status: api.ProtoStatusToStatus(res.Status),
+	}
+
+	return capture, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// truncatingProtoClient answers with responses lacking all but their status.
+type truncatingProtoClient struct {
+	dpdkproto.DPDKironcoreClient
+}
+
+func (truncatingProtoClient) CreateInterface(context.Context, *dpdkproto.CreateInterfaceRequest, ...grpc.CallOption) (*dpdkproto.CreateInterfaceResponse, error) {
+	return &dpdkproto.CreateInterfaceResponse{UnderlayRoute: []byte("fc00::1")}, nil
+}
+
+func (truncatingProtoClient) CreateVip(context.Context, *dpdkproto.CreateVipRequest, ...grpc.CallOption) (*dpdkproto.CreateVipResponse, error) {
+	return &dpdkproto.CreateVipResponse{}, nil
+}
+
+func (truncatingProtoClient) CaptureStatus(context.Context, *dpdkproto.CaptureStatusRequest, ...grpc.CallOption) (*dpdkproto.CaptureStatusResponse, error) {
+	return &dpdkproto.CaptureStatusResponse{IsActive: true}, nil
+}
+
+var _ = Describe("client v2", Label("v2"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.201.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:201::1")
+
+	var v2 ClientV2
+	BeforeAll(func() {
+		v2 = dpdkClient.(V2Provider).V2()
+	})
+
+	It("should create and get an interface", func() {
+		iface, err := v2.CreateInterface(ctx, &CreateInterfaceRequest{Interface: &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "v2vm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap11"},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(iface.Spec.UnderlayRoute).ToNot(BeNil())
+
+		// v1 and v2 see the same interface.
+		res, err := dpdkClient.GetInterface(ctx, "v2vm1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Spec.UnderlayRoute).To(Equal(iface.Spec.UnderlayRoute))
+	})
+
+	It("should ignore the errors given in the request options", func() {
+		_, err := v2.GetInterface(ctx, &GetInterfaceRequest{ID: "v2vm-missing"})
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+
+		_, err = v2.GetInterface(ctx, &GetInterfaceRequest{
+			RequestOptions: RequestOptions{IgnoredErrors: []uint32{errors.NOT_FOUND}},
+			ID:             "v2vm-missing",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete the interface", func() {
+		_, err := v2.DeleteInterface(ctx, &DeleteInterfaceRequest{ID: "v2vm1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = v2.DeleteInterface(ctx, &DeleteInterfaceRequest{ID: "v2vm1"})
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+	})
+})
+
+var _ = Describe("client v2 with truncated responses", Label("v2"), func() {
+	ctx := context.TODO()
+	v2 := NewClientV2(truncatingProtoClient{})
+
+	It("should return malformed response errors instead of panicking", func() {
+		_, err := v2.CreateInterface(ctx, &CreateInterfaceRequest{Interface: &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "truncated"},
+		}})
+		Expect(err).To(MatchError(api.ErrMalformedResponse))
+		Expect(err).To(MatchError("malformed response: error parsing virtual function: missing"))
+
+		_, err = v2.CreateVirtualIP(ctx, &CreateVirtualIPRequest{VirtualIP: &api.VirtualIP{}})
+		Expect(err).To(MatchError(api.ErrMalformedResponse))
+
+		_, err = v2.CaptureStatus(ctx, &CaptureStatusRequest{})
+		Expect(err).To(MatchError(api.ErrMalformedResponse))
+	})
+})
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// GzipCompressor is the name of the gzip compressor, which is always registered.
+const GzipCompressor = gzip.Name
+
+// WithCompression compresses the requests of every call with the named compressor, e.g.
+// GzipCompressor. dpservice answers with the same compressor if it supports it.
+// Other compressors must be registered with google.golang.org/grpc/encoding first.
+func WithCompression(name string) Option {
+	return func(o *options) {
+		o.compressor = name
+	}
+}
+
+func compressionInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(name))...)
+	}
+}
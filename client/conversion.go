@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// WithLenientConversion makes list calls skip entries dpservice returned in a malformed
+// state instead of failing the whole list. onSkip, if not nil, is called with the conversion
+// error of every skipped entry. By default conversion is strict and any malformed entry fails the call.
+func WithLenientConversion(onSkip func(err error)) CallOption {
+	return func(o *callOptions) {
+		o.lenientConversion = &lenientConversion{onSkip: onSkip}
+	}
+}
+
+// WithPartialResults makes list calls skip entries dpservice returned in a malformed state,
+// e.g. with an unparsable underlay route, and append their conversion errors to warnings,
+// so one bad entry does not fail the whole list. Callers should check warnings after the call.
+func WithPartialResults(warnings *[]error) CallOption {
+	var mu sync.Mutex
+	return WithLenientConversion(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*warnings = append(*warnings, err)
+	})
+}
+
+type lenientConversion struct {
+	onSkip func(err error)
+}
+
+type lenientConversionKey struct{}
+
+// conversionError handles the failed conversion of a list entry. In strict mode it returns
+// err, in lenient mode it reports err and returns nil so the entry is skipped.
+func conversionError(ctx context.Context, err error) error {
+	lenient, ok := ctx.Value(lenientConversionKey{}).(*lenientConversion)
+	if !ok {
+		return err
+	}
+	if lenient.onSkip != nil {
+		lenient.onSkip(err)
+	}
+	return nil
+}
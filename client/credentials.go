@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// WithMutualTLS makes DialConn authenticate with the client certificate in certFile/keyFile and
+// verify dpservice against the CA bundle in caFile. The client certificate is re-read whenever
+// the files change, so rotated certificates are picked up on the next handshake.
+func WithMutualTLS(certFile, keyFile, caFile string) DialOption {
+	return func(o *dialOptions) {
+		o.mutualTLS = &mutualTLSFiles{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	}
+}
+
+// WithBearerToken sends token as bearer token with every call made over a connection created
+// by DialConn. It requires a secure transport.
+func WithBearerToken(token string) DialOption {
+	return WithBearerTokenFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithBearerTokenFunc sends the token returned by tokenFunc as bearer token with every call
+// made over a connection created by DialConn. tokenFunc is called for every call and should
+// cache tokens itself. It requires a secure transport.
+func WithBearerTokenFunc(tokenFunc func(ctx context.Context) (string, error)) DialOption {
+	return func(o *dialOptions) {
+		o.perRPCCredentials = &bearerToken{tokenFunc: tokenFunc}
+	}
+}
+
+type mutualTLSFiles struct {
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+func (f *mutualTLSFiles) transportCredentials() (credentials.TransportCredentials, error) {
+	caData, err := os.ReadFile(f.caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("no certificates found in ca file %s", f.caFile)
+	}
+
+	reloader := &certReloader{certFile: f.certFile, keyFile: f.keyFile}
+	if _, err := reloader.GetClientCertificate(nil); err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		RootCAs:              pool,
+		GetClientCertificate: reloader.GetClientCertificate,
+		MinVersion:           tls.VersionTLS12,
+	}), nil
+}
+
+// certReloader loads a key pair and reloads it once one of its files was modified.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var modTime time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			if r.cert != nil {
+				// keep the last good certificate while files are being replaced
+				return r.cert, nil
+			}
+			return nil, fmt.Errorf("error reading client certificate: %w", err)
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	if r.cert != nil && !modTime.After(r.modTime) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		if r.cert != nil {
+			return r.cert, nil
+		}
+		return nil, fmt.Errorf("error loading client certificate: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return r.cert, nil
+}
+
+// bearerToken implements credentials.PerRPCCredentials.
+type bearerToken struct {
+	tokenFunc func(ctx context.Context) (string, error)
+}
+
+func (t *bearerToken) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	token, err := t.tokenFunc(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting bearer token: %w", err)
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (t *bearerToken) RequireTransportSecurity() bool {
+	return true
+}
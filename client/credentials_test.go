@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func writeKeyPair(dir, name string, modTime time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)).To(Succeed())
+	Expect(os.Chtimes(certFile, modTime, modTime)).To(Succeed())
+	Expect(os.Chtimes(keyFile, modTime, modTime)).To(Succeed())
+	return certFile, keyFile
+}
+
+var _ = Describe("credentials", Label("credentials"), func() {
+	It("should reload rotated client certificates", func() {
+		dir := GinkgoT().TempDir()
+		certFile, keyFile := writeKeyPair(dir, "first", time.Now().Add(-time.Minute))
+		reloader := &certReloader{certFile: certFile, keyFile: keyFile}
+
+		first, err := reloader.GetClientCertificate(nil)
+		Expect(err).NotTo(HaveOccurred())
+		again, err := reloader.GetClientCertificate(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(first))
+
+		writeKeyPair(dir, "second", time.Now())
+		second, err := reloader.GetClientCertificate(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Certificate[0]).NotTo(Equal(first.Certificate[0]))
+	})
+
+	It("should send bearer tokens", func() {
+		creds := &bearerToken{tokenFunc: func(context.Context) (string, error) { return "secret", nil }}
+		md, err := creds.GetRequestMetadata(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md).To(HaveKeyWithValue("authorization", "Bearer secret"))
+		Expect(creds.RequireTransportSecurity()).To(BeTrue())
+	})
+})
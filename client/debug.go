@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+)
+
+// DebugInfo is the diagnostic state of a client.
+type DebugInfo struct {
+	// State is the connectivity state of the connection, only known for clients created by Dial.
+	State string `json:"state,omitempty"`
+	// DPServiceUUID is the last initialization UUID reported by dpservice.
+	DPServiceUUID string `json:"dpserviceUUID,omitempty"`
+	// ProtocolSkew is the protocol skew revealed by the last GetVersion call.
+	ProtocolSkew *ProtocolSkew `json:"protocolSkew,omitempty"`
+	Stats        Stats         `json:"stats"`
+}
+
+// debugInfoSource is implemented by the clients of this package.
+type debugInfoSource interface {
+	debugInfo() DebugInfo
+}
+
+// ReadDebugInfo returns the diagnostic state of c. Clients not created by this package
+// only report their protocol skew and stats, if they implement ProtocolSkewClient and StatsClient.
+func ReadDebugInfo(c Client) DebugInfo {
+	if source, ok := c.(debugInfoSource); ok {
+		return source.debugInfo()
+	}
+	var info DebugInfo
+	if skew, ok := c.(ProtocolSkewClient); ok {
+		info.ProtocolSkew = skew.ProtocolSkew()
+	}
+	if stats, ok := c.(StatsClient); ok {
+		info.Stats = stats.Stats()
+	}
+	return info
+}
+
+func (c *client) debugInfo() DebugInfo {
+	info := DebugInfo{ProtocolSkew: c.ProtocolSkew(), Stats: c.Stats()}
+	if c.restartTracker != nil {
+		info.DPServiceUUID = c.restartTracker.UUID()
+	}
+	return info
+}
+
+func (c *ConnectedClient) debugInfo() DebugInfo {
+	info := ReadDebugInfo(c.Client)
+	info.State = c.State().String()
+	return info
+}
+
+// DebugHandler returns an HTTP handler serving the DebugInfo of c as JSON, e.g. to be
+// mounted at /debug/dpservice of a node agent.
+func DebugHandler(c Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(ReadDebugInfo(c))
+	})
+}
+
+// PublishExpvar publishes the DebugInfo of c as expvar variable name, served at /debug/vars
+// by the expvar handler. Like expvar.Publish, it panics if name is already registered.
+func PublishExpvar(name string, c Client) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return ReadDebugInfo(c)
+	}))
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("debug info", Label("debug"), func() {
+	var (
+		c    Client
+		uuid string
+	)
+
+	BeforeEach(func() {
+		c = NewClientWithOptions(grpcConn)
+		initialized, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		uuid = initialized.Spec.UUID
+		Expect(uuid).NotTo(BeEmpty())
+	})
+
+	It("should report the stats and the last seen dpservice UUID", func() {
+		info := ReadDebugInfo(c)
+		Expect(info.DPServiceUUID).To(Equal(uuid))
+		Expect(info.Stats.Methods).To(HaveKey("CheckInitialized"))
+		Expect(info.State).To(BeEmpty())
+	})
+
+	It("should serve the debug info as JSON", func() {
+		rec := httptest.NewRecorder()
+		DebugHandler(c).ServeHTTP(rec, httptest.NewRequest("GET", "/debug/dpservice", nil))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var info DebugInfo
+		Expect(json.Unmarshal(rec.Body.Bytes(), &info)).To(Succeed())
+		Expect(info.DPServiceUUID).To(Equal(uuid))
+		Expect(info.Stats.Methods["CheckInitialized"].Calls).To(BeEquivalentTo(1))
+	})
+
+	It("should publish the debug info via expvar", func() {
+		PublishExpvar("dpservice_debug_test", c)
+		Expect(expvar.Get("dpservice_debug_test").String()).To(ContainSubstring(`"dpserviceUUID":"` + uuid + `"`))
+	})
+})
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// The Delete*IfExists helpers delete an object like the Client method of the same name, but
+// treat a missing object, or a missing interface or load balancer it belongs to, as success.
+// They report whether the object existed and was deleted.
+
+// notFound are the status codes dpservice reports when deleting an object that does not exist.
+var notFound = errors.Ignore(errors.NOT_FOUND, errors.NO_VM, errors.NO_LB, errors.NO_VNI,
+	errors.SNAT_NO_DATA, errors.DNAT_NO_DATA, errors.ROUTE_NOT_FOUND)
+
+// deleted reports whether the deletion returning res succeeded, not ignoring an error.
+func deleted(res interface{ GetStatus() api.Status }, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	return res.GetStatus().Code == 0, nil
+}
+
+// DeleteInterfaceIfExists deletes the interface id and the objects configured on it.
+func DeleteInterfaceIfExists(ctx context.Context, c Client, id string) (bool, error) {
+	res, err := c.DeleteInterface(ctx, id, notFound)
+	return deleted(res, err)
+}
+
+// DeleteVirtualIPIfExists deletes the virtual IP of the interface.
+func DeleteVirtualIPIfExists(ctx context.Context, c Client, interfaceID string) (bool, error) {
+	res, err := c.DeleteVirtualIP(ctx, interfaceID, notFound)
+	return deleted(res, err)
+}
+
+// DeleteNatIfExists deletes the NAT of the interface.
+func DeleteNatIfExists(ctx context.Context, c Client, interfaceID string) (bool, error) {
+	res, err := c.DeleteNat(ctx, interfaceID, notFound)
+	return deleted(res, err)
+}
+
+// DeleteNeighborNatIfExists deletes neighborNat.
+func DeleteNeighborNatIfExists(ctx context.Context, c Client, neighborNat *api.NeighborNat) (bool, error) {
+	res, err := c.DeleteNeighborNat(ctx, neighborNat, notFound)
+	return deleted(res, err)
+}
+
+// DeletePrefixIfExists deletes the prefix of the interface.
+func DeletePrefixIfExists(ctx context.Context, c Client, interfaceID string, prefix *netip.Prefix) (bool, error) {
+	res, err := c.DeletePrefix(ctx, interfaceID, prefix, notFound)
+	return deleted(res, err)
+}
+
+// DeleteRouteIfExists deletes the route to prefix of the VNI.
+func DeleteRouteIfExists(ctx context.Context, c Client, vni uint32, prefix *netip.Prefix) (bool, error) {
+	res, err := c.DeleteRoute(ctx, vni, prefix, notFound)
+	return deleted(res, err)
+}
+
+// DeleteLoadBalancerIfExists deletes the load balancer id.
+func DeleteLoadBalancerIfExists(ctx context.Context, c Client, id string) (bool, error) {
+	res, err := c.DeleteLoadBalancer(ctx, id, notFound)
+	return deleted(res, err)
+}
+
+// DeleteLoadBalancerPrefixIfExists deletes the load balancer prefix of the interface.
+func DeleteLoadBalancerPrefixIfExists(ctx context.Context, c Client, interfaceID string, prefix *netip.Prefix) (bool, error) {
+	res, err := c.DeleteLoadBalancerPrefix(ctx, interfaceID, prefix, notFound)
+	return deleted(res, err)
+}
+
+// DeleteLoadBalancerTargetIfExists deletes the target of the load balancer.
+func DeleteLoadBalancerTargetIfExists(ctx context.Context, c Client, lbID string, targetIP *netip.Addr) (bool, error) {
+	res, err := c.DeleteLoadBalancerTarget(ctx, lbID, targetIP, notFound)
+	return deleted(res, err)
+}
+
+// DeleteFirewallRuleIfExists deletes the firewall rule of the interface.
+func DeleteFirewallRuleIfExists(ctx context.Context, c Client, interfaceID, ruleID string) (bool, error) {
+	res, err := c.DeleteFirewallRule(ctx, interfaceID, ruleID, notFound)
+	return deleted(res, err)
+}
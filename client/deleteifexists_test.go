@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("delete if exists", Label("delete"), func() {
+	ctx := context.TODO()
+
+	It("should report whether the object was deleted", func() {
+		ipv4 := netip.MustParseAddr("10.206.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8:206::1")
+		prefix := netip.MustParsePrefix("10.206.1.0/24")
+		_, err := dpdkClient.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "delvm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap16"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = dpdkClient.CreatePrefix(ctx, &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: "delvm1"},
+			Spec:       api.PrefixSpec{Prefix: prefix},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(DeletePrefixIfExists(ctx, dpdkClient, "delvm1", &prefix)).To(BeTrue())
+		Expect(DeletePrefixIfExists(ctx, dpdkClient, "delvm1", &prefix)).To(BeFalse())
+		Expect(DeleteVirtualIPIfExists(ctx, dpdkClient, "delvm1")).To(BeFalse())
+		Expect(DeleteInterfaceIfExists(ctx, dpdkClient, "delvm1")).To(BeTrue())
+		Expect(DeleteInterfaceIfExists(ctx, dpdkClient, "delvm1")).To(BeFalse())
+		Expect(DeleteFirewallRuleIfExists(ctx, dpdkClient, "delvm1", "rule1")).To(BeFalse())
+	})
+})
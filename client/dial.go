@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/grpc/keepalive"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+const (
+	unixScheme  = "unix:"
+	vsockScheme = "vsock://"
+)
+
+// DialOption configures the connection created by DialConn or Dial.
+type DialOption func(*dialOptions)
+
+type dialOptions struct {
+	grpcOptions          []grpc.DialOption
+	transportCredentials credentials.TransportCredentials
+	keepalive            *keepalive.ClientParameters
+	mutualTLS            *mutualTLSFiles
+	perRPCCredentials    credentials.PerRPCCredentials
+
+	stateChangeCallbacks []StateChangeCallback
+	autoReconnect        bool
+}
+
+// DialerOption is an Option or a DialOption, Dial accepts both.
+type DialerOption interface {
+	applyDialer(o *options, d *dialOptions)
+}
+
+func (opt Option) applyDialer(o *options, _ *dialOptions) {
+	opt(o)
+}
+
+func (opt DialOption) applyDialer(_ *options, d *dialOptions) {
+	opt(d)
+}
+
+// WithDialOptions adds gRPC dial options used by DialConn.
+func WithDialOptions(grpcOptions ...grpc.DialOption) DialOption {
+	return func(o *dialOptions) {
+		o.grpcOptions = append(o.grpcOptions, grpcOptions...)
+	}
+}
+
+// WithTransportCredentials overrides the transport credentials used by DialConn.
+func WithTransportCredentials(creds credentials.TransportCredentials) DialOption {
+	return func(o *dialOptions) {
+		o.transportCredentials = creds
+	}
+}
+
+// WithKeepalive configures client side keepalive pings used by DialConn, so a dead dpservice
+// is detected after params.Time+params.Timeout instead of waiting for the TCP timeout.
+// Note that dpservice may close connections pinging more often than its enforcement policy permits.
+func WithKeepalive(params keepalive.ClientParameters) DialOption {
+	return func(o *dialOptions) {
+		o.keepalive = &params
+	}
+}
+
+// DialConn creates a gRPC connection to dpservice. Besides host:port, targets of the form
+// unix:///path/to/socket and vsock://<cid>:<port> are supported. Unless configured
+// otherwise, unix sockets use local credentials and all other targets are insecure.
+// WithStateChangeCallback and WithAutoReconnect need the client of Dial and are rejected.
+func DialConn(ctx context.Context, target string, opts ...DialOption) (*grpc.ClientConn, error) {
+	o := &dialOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.stateChangeCallbacks) > 0 || o.autoReconnect {
+		return nil, fmt.Errorf("state change callbacks and auto reconnect are only supported by Dial")
+	}
+	return dialConn(ctx, target, o)
+}
+
+func dialConn(ctx context.Context, target string, o *dialOptions) (*grpc.ClientConn, error) {
+	var dialOptions []grpc.DialOption
+	creds := o.transportCredentials
+	if creds == nil && o.mutualTLS != nil {
+		var err error
+		if creds, err = o.mutualTLS.transportCredentials(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case strings.HasPrefix(target, unixScheme):
+		if creds == nil {
+			creds = local.NewCredentials()
+		}
+	case strings.HasPrefix(target, vsockScheme):
+		cid, port, err := parseVsockAddress(strings.TrimPrefix(target, vsockScheme))
+		if err != nil {
+			return nil, err
+		}
+		dialOptions = append(dialOptions, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return dialVsock(ctx, cid, port)
+		}))
+		target = fmt.Sprintf("passthrough:///vsock:%d:%d", cid, port)
+	}
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	dialOptions = append(dialOptions, grpc.WithTransportCredentials(creds))
+	if o.perRPCCredentials != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(o.perRPCCredentials))
+	}
+	if o.keepalive != nil {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+	dialOptions = append(dialOptions, o.grpcOptions...)
+
+	return grpc.DialContext(ctx, target, dialOptions...)
+}
+
+// StateChangeCallback is called when the connectivity state of a ConnectedClient changes.
+type StateChangeCallback func(from, to connectivity.State)
+
+// WithStateChangeCallback registers a callback invoked on every connectivity state transition
+// of a client created by Dial, e.g. to mark a node agent unready on TRANSIENT_FAILURE.
+func WithStateChangeCallback(callback StateChangeCallback) DialOption {
+	return func(o *dialOptions) {
+		o.stateChangeCallbacks = append(o.stateChangeCallbacks, callback)
+	}
+}
+
+// WithAutoReconnect makes a client created by Dial re-dial as soon as its connection goes idle,
+// instead of waiting for the next call.
+func WithAutoReconnect() DialOption {
+	return func(o *dialOptions) {
+		o.autoReconnect = true
+	}
+}
+
+// ConnectedClient is a Client owning its gRPC connection to dpservice.
+type ConnectedClient struct {
+	Client
+	conn *grpc.ClientConn
+
+	stopWatch context.CancelFunc
+	watchDone chan struct{}
+}
+
+// Dial connects to dpservice at target (see DialConn) and returns a client owning the connection.
+// It accepts the options of both NewClientWithOptions and DialConn.
+func Dial(ctx context.Context, target string, opts ...DialerOption) (*ConnectedClient, error) {
+	o, d := &options{}, &dialOptions{}
+	for _, opt := range opts {
+		opt.applyDialer(o, d)
+	}
+	conn, err := dialConn(ctx, target, d)
+	if err != nil {
+		return nil, err
+	}
+	c := &ConnectedClient{
+		Client: newClient(conn, o),
+		conn:   conn,
+	}
+	if len(d.stateChangeCallbacks) > 0 || d.autoReconnect {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		c.stopWatch = cancel
+		c.watchDone = make(chan struct{})
+		go c.watch(watchCtx, d.stateChangeCallbacks, d.autoReconnect)
+	}
+	return c, nil
+}
+
+func (c *ConnectedClient) watch(ctx context.Context, callbacks []StateChangeCallback, autoReconnect bool) {
+	defer close(c.watchDone)
+
+	state := c.conn.GetState()
+	for state != connectivity.Shutdown {
+		if autoReconnect && state == connectivity.Idle {
+			c.conn.Connect()
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return
+		}
+		newState := c.conn.GetState()
+		for _, callback := range callbacks {
+			callback(state, newState)
+		}
+		state = newState
+	}
+}
+
+// Conn returns the underlying gRPC connection.
+func (c *ConnectedClient) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// State returns the connectivity state of the underlying connection.
+func (c *ConnectedClient) State() connectivity.State {
+	return c.conn.GetState()
+}
+
+// V2 returns the v2 surface of the client, see V2Provider.
+func (c *ConnectedClient) V2() ClientV2 {
+	return c.Client.(V2Provider).V2()
+}
+
+// Raw returns the generated gRPC client of the connection, see RawClient.
+func (c *ConnectedClient) Raw() dpdkproto.DPDKironcoreClient {
+	return c.Client.(RawClient).Raw()
+}
+
+// ProtocolSkew returns the protocol skew revealed by the last GetVersion call, see
+// ProtocolSkewClient.
+func (c *ConnectedClient) ProtocolSkew() *ProtocolSkew {
+	return c.Client.(ProtocolSkewClient).ProtocolSkew()
+}
+
+// Stats returns the call statistics of the client, see StatsClient.
+func (c *ConnectedClient) Stats() Stats {
+	return c.Client.(StatsClient).Stats()
+}
+
+// ResetStats clears the call statistics of the client, see StatsClient.
+func (c *ConnectedClient) ResetStats() {
+	c.Client.(StatsClient).ResetStats()
+}
+
+// Close closes the underlying connection. The client must not be used afterwards.
+func (c *ConnectedClient) Close() error {
+	if c.stopWatch != nil {
+		c.stopWatch()
+		defer func() { <-c.watchDone }()
+	}
+	return c.conn.Close()
+}
+
+func parseVsockAddress(address string) (uint32, uint32, error) {
+	cidString, portString, ok := strings.Cut(address, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("vsock address %q must be of the form <cid>:<port>", address)
+	}
+	cid, err := strconv.ParseUint(cidString, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing vsock cid: %w", err)
+	}
+	port, err := strconv.ParseUint(portString, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing vsock port: %w", err)
+	}
+	return uint32(cid), uint32(port), nil
+}
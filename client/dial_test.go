@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+)
+
+var _ = Describe("dial", Label("dial"), func() {
+	It("should own and close the connection", func() {
+		c, err := Dial(context.TODO(), dpserviceAddr)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.State()).To(Equal(connectivity.Ready))
+
+		Expect(c.Close()).To(Succeed())
+		Expect(c.State()).To(Equal(connectivity.Shutdown))
+	})
+
+	It("should report connectivity state transitions", func() {
+		transitions := make(chan connectivity.State, 10)
+		c, err := Dial(context.TODO(), dpserviceAddr, WithAutoReconnect(), WithStateChangeCallback(func(_, to connectivity.State) {
+			transitions <- to
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { Expect(c.Close()).To(Succeed()) }()
+
+		Eventually(transitions).Should(Receive(Equal(connectivity.Ready)))
+	})
+
+	It("should dial with keepalive parameters", func() {
+		c, err := Dial(context.TODO(), dpserviceAddr, WithKeepalive(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { Expect(c.Close()).To(Succeed()) }()
+
+		_, err = c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should apply the options once", func() {
+		var applied, dialApplied int
+		c, err := Dial(context.TODO(), dpserviceAddr, Option(func(*options) { applied++ }), DialOption(func(*dialOptions) { dialApplied++ }))
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { Expect(c.Close()).To(Succeed()) }()
+
+		Expect(applied).To(Equal(1))
+		Expect(dialApplied).To(Equal(1))
+	})
+
+	It("should reject options only supported by Dial", func() {
+		_, err := DialConn(context.TODO(), dpserviceAddr, WithAutoReconnect())
+		Expect(err).To(MatchError("state change callbacks and auto reconnect are only supported by Dial"))
+	})
+
+	It("should reject malformed vsock targets", func() {
+		_, err := DialConn(context.TODO(), "vsock://3")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+type vsockAddr struct {
+	cid  uint32
+	port uint32
+}
+
+func (a vsockAddr) Network() string {
+	return "vsock"
+}
+
+func (a vsockAddr) String() string {
+	return fmt.Sprintf("%d:%d", a.cid, a.port)
+}
+
+// vsockConn is a net.Conn backed by a connected AF_VSOCK socket, which the
+// net package does not know how to wrap.
+type vsockConn struct {
+	*os.File
+	local  vsockAddr
+	remote vsockAddr
+}
+
+func (c *vsockConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *vsockConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func dialVsock(ctx context.Context, cid, port uint32) (net.Conn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// a non-blocking descriptor lets os.File use the runtime poller, which supports deadlines,
+	// so the connect can be aborted once ctx is done
+	fd, err := unix.Socket(unix.AF_VSOCK, unix.SOCK_STREAM|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vsock socket: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("vsock:%d:%d", cid, port))
+	if err := connectVsock(ctx, f, cid, port); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error connecting to vsock %d:%d: %w", cid, port, err)
+	}
+
+	local := vsockAddr{}
+	if sa, err := unix.Getsockname(fd); err == nil {
+		if vm, ok := sa.(*unix.SockaddrVM); ok {
+			local = vsockAddr{cid: vm.CID, port: vm.Port}
+		}
+	}
+
+	return &vsockConn{
+		File:   f,
+		local:  local,
+		remote: vsockAddr{cid: cid, port: port},
+	}, nil
+}
+
+// connectVsock connects the non-blocking socket f and waits for the connection to be
+// established until ctx is done.
+func connectVsock(ctx context.Context, f *os.File, cid, port uint32) error {
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var connectErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		connectErr = unix.Connect(int(fd), &unix.SockaddrVM{CID: cid, Port: port})
+	}); err != nil {
+		return err
+	}
+	if !errors.Is(connectErr, unix.EINPROGRESS) {
+		return connectErr
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = f.SetWriteDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	// the socket becomes writable once the connect completed or failed
+	err = rawConn.Write(func(fd uintptr) bool {
+		if errno, err := unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_ERROR); err != nil {
+			connectErr = err
+			return true
+		} else if errno != 0 {
+			connectErr = unix.Errno(errno)
+			return true
+		}
+		_, err := unix.Getpeername(int(fd))
+		connectErr = err
+		return !errors.Is(err, unix.ENOTCONN)
+	})
+	if errors.Is(err, os.ErrDeadlineExceeded) && ctx.Err() != nil {
+		// the deadline was only set to abort the wait
+		return ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+	if connectErr != nil {
+		return connectErr
+	}
+	return f.SetWriteDeadline(time.Time{})
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+func dialVsock(_ context.Context, _, _ uint32) (net.Conn, error) {
+	return nil, fmt.Errorf("vsock is only supported on linux")
+}
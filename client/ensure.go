@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// ensureRetryInterval is the delay between attempts of EnsureInitialized while dpservice is starting.
+var ensureRetryInterval = time.Second
+
+// EnsureInitialized makes sure dpservice is initialized and returns its UUID. It calls Initialize
+// if CheckInitialized reports dpservice as uninitialized and retries while dpservice is not
+// reachable yet, until ctx is done.
+func EnsureInitialized(ctx context.Context, c Client) (string, error) {
+	for {
+		uuid, err := ensureInitialized(ctx, c)
+		if err == nil || !isStarting(err) {
+			return uuid, err
+		}
+
+		timer := time.NewTimer(ensureRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", err
+		case <-timer.C:
+		}
+	}
+}
+
+func ensureInitialized(ctx context.Context, c Client) (string, error) {
+	initialized, err := c.CheckInitialized(ctx)
+	if err == nil && initialized.Spec.UUID != "" {
+		return initialized.Spec.UUID, nil
+	}
+	// a dpservice status error means uninitialized, anything else is a transport failure
+	if err != nil && !errors.IsStatusError(err) {
+		return "", err
+	}
+
+	initialized, err = c.Initialize(ctx)
+	if err != nil {
+		return "", err
+	}
+	return initialized.Spec.UUID, nil
+}
+
+// isStarting reports whether err indicates dpservice is not ready to serve yet.
+func isStarting(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// alreadyExists are the status codes dpservice reports when creating an object that exists.
+var alreadyExists = []uint32{errors.ALREADY_EXISTS, errors.SNAT_EXISTS, errors.DNAT_EXISTS, errors.ROUTE_EXISTS}
+
+// Ensure creates obj unless it already exists. An existing object whose spec differs from obj
+// is deleted and recreated, as dpservice cannot update objects. Specs are compared by
+// api.SpecDiff, so fields not set in obj and fields dpservice populates or never returns, like
+// the underlay route or the PXE config of interfaces, do not cause a recreation. Kinds dpservice cannot get,
+// e.g. prefixes, are identified by their spec, so obj is returned for existing objects of them.
+// Note that recreating an interface also deletes the objects configured on it.
+func Ensure[T api.Object](ctx context.Context, c Client, obj T) (T, error) {
+	objs := NewResource[T](c)
+	created, err := objs.Create(ctx, obj)
+	if !errors.IsStatusErrorCode(err, alreadyExists...) {
+		return created, err
+	}
+	funcs, err := objs.funcs(obj)
+	if err != nil {
+		return created, err
+	}
+	if funcs.get == nil {
+		return obj, nil
+	}
+
+	live, err := objs.Get(ctx, obj)
+	if err != nil {
+		return live, err
+	}
+	diffs, err := api.SpecDiff(obj, live)
+	if err != nil || len(diffs) == 0 {
+		return live, err
+	}
+	if err := objs.Delete(ctx, obj, notFound); err != nil {
+		return created, fmt.Errorf("error deleting %s %s with differing spec: %w", obj.GetKind(), obj.GetID(), err)
+	}
+	return objs.Create(ctx, obj)
+}
+
+// EnsureInterface ensures iface exists with its spec, see Ensure.
+func EnsureInterface(ctx context.Context, c Client, iface *api.Interface) (*api.Interface, error) {
+	return Ensure(ctx, c, iface)
+}
+
+// EnsureVirtualIP ensures vip exists with its spec, see Ensure.
+func EnsureVirtualIP(ctx context.Context, c Client, vip *api.VirtualIP) (*api.VirtualIP, error) {
+	return Ensure(ctx, c, vip)
+}
+
+// EnsureNat ensures nat exists with its spec, see Ensure.
+func EnsureNat(ctx context.Context, c Client, nat *api.Nat) (*api.Nat, error) {
+	return Ensure(ctx, c, nat)
+}
+
+// EnsureLoadBalancer ensures lb exists with its spec, see Ensure. Recreating a load balancer
+// also deletes its targets.
+func EnsureLoadBalancer(ctx context.Context, c Client, lb *api.LoadBalancer) (*api.LoadBalancer, error) {
+	return Ensure(ctx, c, lb)
+}
+
+// EnsureFirewallRule ensures rule exists with its spec, see Ensure.
+func EnsureFirewallRule(ctx context.Context, c Client, rule *api.FirewallRule) (*api.FirewallRule, error) {
+	return Ensure(ctx, c, rule)
+}
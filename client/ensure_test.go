@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// initClient fakes the initialization calls of a starting dpservice, returning no object
+// along with errors.
+type initClient struct {
+	Client
+	unavailable int
+	initialized bool
+}
+
+func (c *initClient) CheckInitialized(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	if c.unavailable > 0 {
+		c.unavailable--
+		return nil, status.Error(codes.Unavailable, "starting")
+	}
+	if !c.initialized {
+		return nil, errors.NewStatusError(1, "not initialized")
+	}
+	return &api.Initialized{Spec: api.InitializedSpec{UUID: "uuid"}}, nil
+}
+
+func (c *initClient) Initialize(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	c.initialized = true
+	return &api.Initialized{Spec: api.InitializedSpec{UUID: "uuid"}}, nil
+}
+
+var _ = Describe("EnsureInitialized", Label("ensure"), func() {
+	BeforeEach(func() {
+		interval := ensureRetryInterval
+		ensureRetryInterval = time.Millisecond
+		DeferCleanup(func() { ensureRetryInterval = interval })
+	})
+
+	It("should wait for dpservice and initialize it", func() {
+		c := &initClient{unavailable: 2}
+		uuid, err := EnsureInitialized(context.TODO(), c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uuid).To(Equal("uuid"))
+		Expect(c.initialized).To(BeTrue())
+	})
+
+	It("should give up when the context is done", func() {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		_, err := EnsureInitialized(ctx, &initClient{unavailable: 1 << 30})
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	})
+})
+
+var _ = Describe("ensure", Label("ensure"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.205.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:205::1")
+	newInterface := func(ipv4 netip.Addr) *api.Interface {
+		return &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "ensurevm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap15"},
+		}
+	}
+	var underlayRoute netip.Addr
+
+	AfterAll(func() {
+		_, err := dpdkClient.DeleteInterface(ctx, "ensurevm1", errors.Ignore(errors.NOT_FOUND))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create missing objects", func() {
+		iface, err := EnsureInterface(ctx, dpdkClient, newInterface(ipv4))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Spec.UnderlayRoute).NotTo(BeNil())
+		underlayRoute = *iface.Spec.UnderlayRoute
+	})
+
+	It("should keep existing objects with the same spec", func() {
+		iface, err := EnsureInterface(ctx, dpdkClient, newInterface(ipv4))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*iface.Spec.UnderlayRoute).To(Equal(underlayRoute))
+
+		prefix := &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: "ensurevm1"},
+			Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.205.1.0/24")},
+		}
+		for i := 0; i < 2; i++ {
+			_, err = Ensure(ctx, dpdkClient, prefix)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should not recreate objects because of fields dpservice does not return", func() {
+		iface := newInterface(ipv4)
+		iface.Spec.PXE = &api.PXE{Server: "10.205.0.254", FileName: "boot.ipxe"}
+		iface, err := EnsureInterface(ctx, dpdkClient, iface)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*iface.Spec.UnderlayRoute).To(Equal(underlayRoute))
+	})
+
+	It("should recreate existing objects with a different spec", func() {
+		iface, err := EnsureInterface(ctx, dpdkClient, newInterface(netip.MustParseAddr("10.205.0.2")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Spec.IPv4.String()).To(Equal("10.205.0.2"))
+
+		iface, err = dpdkClient.GetInterface(ctx, "ensurevm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Spec.IPv4.String()).To(Equal("10.205.0.2"))
+	})
+})
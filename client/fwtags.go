@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// ListFirewallRulesByTag lists the firewall rules of the interface whose ID carries tag, see
+// api.FirewallRuleIDWithTags.
+func ListFirewallRulesByTag(ctx context.Context, c Client, interfaceID string, tag string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	list, err := c.ListFirewallRules(ctx, interfaceID, ignoredErrors...)
+	if err != nil {
+		return list, err
+	}
+	tagged := make([]api.FirewallRule, 0, len(list.Items))
+	for _, rule := range list.Items {
+		if _, tags := api.FirewallRuleIDTags(rule.Spec.RuleID); slices.Contains(tags, tag) {
+			tagged = append(tagged, rule)
+		}
+	}
+	list.Items = tagged
+	return list, nil
+}
+
+// DeleteFirewallRulesByTag deletes the firewall rules of the interface whose ID carries tag
+// and returns the deleted rules. Rules deleted concurrently by others are not an error.
+// Failed deletions are returned as an errors.Aggregate keyed by rule ID.
+func DeleteFirewallRulesByTag(ctx context.Context, c Client, interfaceID string, tag string) (*api.FirewallRuleList, error) {
+	list, err := ListFirewallRulesByTag(ctx, c, interfaceID, tag)
+	if err != nil {
+		return list, err
+	}
+	deleted := make([]api.FirewallRule, 0, len(list.Items))
+	agg := &errors.Aggregate{}
+	for _, rule := range list.Items {
+		if _, err := c.DeleteFirewallRule(ctx, interfaceID, rule.Spec.RuleID, errors.Ignore(errors.NOT_FOUND)); err != nil {
+			agg.Add(rule.Spec.RuleID, err)
+			continue
+		}
+		deleted = append(deleted, rule)
+	}
+	list.Items = deleted
+	return list, agg.ErrorOrNil()
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// Resource provides Get, List, Create and Delete for the objects of type T, so generic tooling
+// like apply or garbage collection needs no switch over the kinds. The kind of an object is
+// looked up in api.DefaultScheme. T may be api.Object to handle objects of any kind; the kind
+// is then taken from the object passed to each call.
+//
+//	prefixes := client.NewResource[*api.Prefix](c)
+//	list, err := prefixes.List(ctx, &api.Prefix{PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"}})
+type Resource[T api.Object] struct {
+	client Client
+}
+
+// NewResource creates a Resource for the objects of type T managed by c.
+func NewResource[T api.Object](c Client) *Resource[T] {
+	return &Resource[T]{client: c}
+}
+
+// Get returns the live state of obj, identified by its metadata.
+func (r *Resource[T]) Get(ctx context.Context, obj T, ignoredErrors ...[]uint32) (T, error) {
+	funcs, err := r.funcs(obj)
+	if err != nil || funcs.get == nil {
+		return r.unsupported(obj, "get", err)
+	}
+	return r.result(funcs.get(ctx, r.client, obj, ignoredErrors))
+}
+
+// List lists the objects in the scope of obj: the objects of its interface, load balancer,
+// VNI or NAT IP, depending on the kind. Interfaces have no scope, obj may be nil for them if
+// T is not api.Object.
+func (r *Resource[T]) List(ctx context.Context, obj T, ignoredErrors ...[]uint32) ([]T, error) {
+	funcs, err := r.funcs(obj)
+	if err != nil || funcs.list == nil {
+		_, err = r.unsupported(obj, "list", err)
+		return nil, err
+	}
+	list, err := funcs.list(ctx, r.client, obj, ignoredErrors)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, 0, len(list))
+	for _, item := range list {
+		items = append(items, item.(T))
+	}
+	return items, nil
+}
+
+// Create creates obj and returns the created object as reported by dpservice.
+func (r *Resource[T]) Create(ctx context.Context, obj T, ignoredErrors ...[]uint32) (T, error) {
+	funcs, err := r.funcs(obj)
+	if err != nil {
+		return r.unsupported(obj, "create", err)
+	}
+	return r.result(funcs.create(ctx, r.client, obj, ignoredErrors))
+}
+
+// Delete deletes obj, identified by its metadata.
+func (r *Resource[T]) Delete(ctx context.Context, obj T, ignoredErrors ...[]uint32) error {
+	funcs, err := r.funcs(obj)
+	if err != nil {
+		_, err = r.unsupported(obj, "delete", err)
+		return err
+	}
+	return funcs.delete(ctx, r.client, obj, ignoredErrors)
+}
+
+func (r *Resource[T]) funcs(obj T) (resourceFuncs, error) {
+	kind, err := api.DefaultScheme.KindOf(obj)
+	if err != nil {
+		return resourceFuncs{}, err
+	}
+	funcs, ok := resourceFuncsByKind[kind]
+	if !ok {
+		return resourceFuncs{}, fmt.Errorf("kind %s is not a resource", kind)
+	}
+	return funcs, nil
+}
+
+func (r *Resource[T]) unsupported(obj T, op string, err error) (T, error) {
+	var zero T
+	if err != nil {
+		return zero, err
+	}
+	return zero, fmt.Errorf("%s is not supported for %T", op, obj)
+}
+
+func (r *Resource[T]) result(obj api.Object, err error) (T, error) {
+	res, _ := obj.(T)
+	return res, err
+}
+
+// resourceFuncs implement the operations of a kind. get and list are nil if dpservice cannot
+// get or list the kind.
+type resourceFuncs struct {
+	get    func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error)
+	list   func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error)
+	create func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error)
+	delete func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error
+}
+
+func listItems(list api.ObjectList, err error) ([]api.Object, error) {
+	if err != nil {
+		return nil, err
+	}
+	return list.GetItems(), nil
+}
+
+var resourceFuncsByKind = map[string]resourceFuncs{
+	api.InterfaceKind: {
+		get: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.GetInterface(ctx, obj.(*api.Interface).ID, ignoredErrors...)
+		},
+		list: func(ctx context.Context, c Client, _ api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListInterfaces(ctx, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateInterface(ctx, obj.(*api.Interface), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			_, err := c.DeleteInterface(ctx, obj.(*api.Interface).ID, ignoredErrors...)
+			return err
+		},
+	},
+	api.VirtualIPKind: {
+		get: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.GetVirtualIP(ctx, obj.(*api.VirtualIP).InterfaceID, ignoredErrors...)
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateVirtualIP(ctx, obj.(*api.VirtualIP), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			_, err := c.DeleteVirtualIP(ctx, obj.(*api.VirtualIP).InterfaceID, ignoredErrors...)
+			return err
+		},
+	},
+	api.NatKind: {
+		get: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.GetNat(ctx, obj.(*api.Nat).InterfaceID, ignoredErrors...)
+		},
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListLocalNats(ctx, obj.(*api.Nat).Spec.NatIP, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateNat(ctx, obj.(*api.Nat), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			_, err := c.DeleteNat(ctx, obj.(*api.Nat).InterfaceID, ignoredErrors...)
+			return err
+		},
+	},
+	api.NeighborNatKind: {
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			natIP := obj.(*api.NeighborNat).NatIP
+			list, err := c.ListNeighborNats(ctx, natIP, ignoredErrors...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]api.Object, 0, len(list.Items))
+			for _, nat := range list.Items {
+				items = append(items, &api.NeighborNat{
+					TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+					NeighborNatMeta: api.NeighborNatMeta{NatIP: natIP},
+					Spec: api.NeighborNatSpec{
+						Vni:           nat.Spec.Vni,
+						MinPort:       nat.Spec.MinPort,
+						MaxPort:       nat.Spec.MaxPort,
+						UnderlayRoute: nat.Spec.UnderlayRoute,
+					},
+				})
+			}
+			return items, nil
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateNeighborNat(ctx, obj.(*api.NeighborNat), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			_, err := c.DeleteNeighborNat(ctx, obj.(*api.NeighborNat), ignoredErrors...)
+			return err
+		},
+	},
+	api.PrefixKind: {
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListPrefixes(ctx, obj.(*api.Prefix).InterfaceID, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreatePrefix(ctx, obj.(*api.Prefix), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			prefix := obj.(*api.Prefix)
+			_, err := c.DeletePrefix(ctx, prefix.InterfaceID, &prefix.Spec.Prefix, ignoredErrors...)
+			return err
+		},
+	},
+	api.LoadBalancerKind: {
+		get: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.GetLoadBalancer(ctx, obj.(*api.LoadBalancer).ID, ignoredErrors...)
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateLoadBalancer(ctx, obj.(*api.LoadBalancer), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			_, err := c.DeleteLoadBalancer(ctx, obj.(*api.LoadBalancer).ID, ignoredErrors...)
+			return err
+		},
+	},
+	api.LoadBalancerTargetKind: {
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListLoadBalancerTargets(ctx, obj.(*api.LoadBalancerTarget).LoadbalancerID, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateLoadBalancerTarget(ctx, obj.(*api.LoadBalancerTarget), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			target := obj.(*api.LoadBalancerTarget)
+			_, err := c.DeleteLoadBalancerTarget(ctx, target.LoadbalancerID, target.Spec.TargetIP, ignoredErrors...)
+			return err
+		},
+	},
+	api.LoadBalancerPrefixKind: {
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			interfaceID := obj.(*api.LoadBalancerPrefix).InterfaceID
+			list, err := c.ListLoadBalancerPrefixes(ctx, interfaceID, ignoredErrors...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]api.Object, 0, len(list.Items))
+			for _, prefix := range list.Items {
+				items = append(items, &api.LoadBalancerPrefix{
+					TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+					LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: interfaceID},
+					Spec: api.LoadBalancerPrefixSpec{
+						Prefix:        prefix.Spec.Prefix,
+						UnderlayRoute: prefix.Spec.UnderlayRoute,
+					},
+				})
+			}
+			return items, nil
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateLoadBalancerPrefix(ctx, obj.(*api.LoadBalancerPrefix), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			prefix := obj.(*api.LoadBalancerPrefix)
+			_, err := c.DeleteLoadBalancerPrefix(ctx, prefix.InterfaceID, &prefix.Spec.Prefix, ignoredErrors...)
+			return err
+		},
+	},
+	api.FirewallRuleKind: {
+		get: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			rule := obj.(*api.FirewallRule)
+			return c.GetFirewallRule(ctx, rule.InterfaceID, rule.Spec.RuleID, ignoredErrors...)
+		},
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListFirewallRules(ctx, obj.(*api.FirewallRule).InterfaceID, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateFirewallRule(ctx, obj.(*api.FirewallRule), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			rule := obj.(*api.FirewallRule)
+			_, err := c.DeleteFirewallRule(ctx, rule.InterfaceID, rule.Spec.RuleID, ignoredErrors...)
+			return err
+		},
+	},
+	api.RouteKind: {
+		list: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) ([]api.Object, error) {
+			return listItems(c.ListRoutes(ctx, obj.(*api.Route).VNI, ignoredErrors...))
+		},
+		create: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) (api.Object, error) {
+			return c.CreateRoute(ctx, obj.(*api.Route), ignoredErrors...)
+		},
+		delete: func(ctx context.Context, c Client, obj api.Object, ignoredErrors [][]uint32) error {
+			route := obj.(*api.Route)
+			_, err := c.DeleteRoute(ctx, route.VNI, route.Spec.Prefix, ignoredErrors...)
+			return err
+		},
+	},
+}
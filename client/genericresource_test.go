@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("generic resource", Label("resource"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.203.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:203::1")
+	prefix := netip.MustParsePrefix("10.203.1.0/24")
+
+	It("should manage objects of a typed kind", func() {
+		ifaces := NewResource[*api.Interface](dpdkClient)
+		iface, err := ifaces.Create(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "genvm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap13"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(iface.ID).To(Equal("genvm1"))
+
+		iface, err = ifaces.Get(ctx, &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "genvm1"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(iface.Spec.VNI).To(Equal(uint32(positiveTestVNI)))
+
+		list, err := ifaces.List(ctx, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(ContainElement(HaveField("ID", "genvm1")))
+	})
+
+	It("should manage objects of any kind", func() {
+		objs := NewResource[api.Object](dpdkClient)
+		lbPrefix := &api.LoadBalancerPrefix{
+			LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: "genvm1"},
+			Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix},
+		}
+		_, err := objs.Create(ctx, lbPrefix)
+		Expect(err).ToNot(HaveOccurred())
+
+		list, err := objs.List(ctx, lbPrefix)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list).To(HaveLen(1))
+		Expect(list[0]).To(BeAssignableToTypeOf(&api.LoadBalancerPrefix{}))
+		Expect(list[0].GetID()).To(Equal(lbPrefix.GetID()))
+
+		Expect(objs.Delete(ctx, lbPrefix)).To(Succeed())
+	})
+
+	It("should reject unsupported operations", func() {
+		_, err := NewResource[*api.Prefix](dpdkClient).Get(ctx, &api.Prefix{})
+		Expect(err).To(MatchError("get is not supported for *api.Prefix"))
+
+		_, err = NewResource[api.Object](dpdkClient).Create(ctx, &api.Vni{})
+		Expect(err).To(MatchError("kind Vni is not a resource"))
+	})
+
+	It("should delete objects ignoring the given errors", func() {
+		ifaces := NewResource[*api.Interface](dpdkClient)
+		iface := &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: "genvm1"}}
+		Expect(ifaces.Delete(ctx, iface)).To(Succeed())
+		Expect(ifaces.Delete(ctx, iface, errors.Ignore(errors.NOT_FOUND))).To(Succeed())
+	})
+})
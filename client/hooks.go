@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// Hook is called around every RPC of a client, e.g. for audit trails, custom metrics or
+// policy enforcement. op is the RPC name, e.g. "CreateInterface", and req and resp are the
+// request and response protos. Note that dpservice status errors are reported within resp.
+type Hook interface {
+	// BeforeCall is called before the RPC is sent. Returning an error aborts the call with it.
+	BeforeCall(ctx context.Context, op string, req interface{}) error
+	// AfterCall is called after the RPC completed with the transport error of the call, if any.
+	AfterCall(ctx context.Context, op string, req, resp interface{}, err error)
+}
+
+// HookFuncs implements Hook with optional functions.
+type HookFuncs struct {
+	Before func(ctx context.Context, op string, req interface{}) error
+	After  func(ctx context.Context, op string, req, resp interface{}, err error)
+}
+
+func (h HookFuncs) BeforeCall(ctx context.Context, op string, req interface{}) error {
+	if h.Before == nil {
+		return nil
+	}
+	return h.Before(ctx, op, req)
+}
+
+func (h HookFuncs) AfterCall(ctx context.Context, op string, req, resp interface{}, err error) {
+	if h.After != nil {
+		h.After(ctx, op, req, resp, err)
+	}
+}
+
+// WithHooks registers hooks called around every call of the client. Before hooks are called
+// in order, after hooks in reverse order.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+func hooksInterceptor(hooks []Hook) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		op := method[strings.LastIndex(method, "/")+1:]
+		for i, hook := range hooks {
+			if err := hook.BeforeCall(ctx, op, req); err != nil {
+				// only hooks that saw the call are told about its end
+				for j := i - 1; j >= 0; j-- {
+					hooks[j].AfterCall(ctx, op, req, nil, err)
+				}
+				return err
+			}
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		resp := reply
+		if err != nil {
+			resp = nil
+		}
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].AfterCall(ctx, op, req, resp, err)
+		}
+		return err
+	}
+}
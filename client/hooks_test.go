@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("hooks", Label("hooks"), func() {
+	var calls []string
+	hook := func(name string, deny bool) Hook {
+		return HookFuncs{
+			Before: func(ctx context.Context, op string, req interface{}) error {
+				calls = append(calls, "before "+name+" "+op)
+				if deny {
+					return fmt.Errorf("denied")
+				}
+				return nil
+			},
+			After: func(ctx context.Context, op string, req, resp interface{}, err error) {
+				calls = append(calls, fmt.Sprintf("after %s %s %v", name, op, err))
+			},
+		}
+	}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls = append(calls, "invoke")
+		return nil
+	}
+
+	BeforeEach(func() {
+		calls = nil
+	})
+
+	It("should call hooks around the call", func() {
+		interceptor := hooksInterceptor([]Hook{hook("first", false), hook("second", false)})
+		Expect(interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/CreateInterface", nil, nil, nil, invoker)).To(Succeed())
+		Expect(calls).To(Equal([]string{
+			"before first CreateInterface",
+			"before second CreateInterface",
+			"invoke",
+			"after second CreateInterface <nil>",
+			"after first CreateInterface <nil>",
+		}))
+	})
+
+	It("should abort calls denied by a hook", func() {
+		interceptor := hooksInterceptor([]Hook{hook("first", false), hook("second", true)})
+		Expect(interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/ResetVni", nil, nil, nil, invoker)).To(MatchError("denied"))
+		Expect(calls).To(Equal([]string{
+			"before first ResetVni",
+			"before second ResetVni",
+			"after first ResetVni denied",
+		}))
+	})
+})
+
+var _ = Describe("underlay route callbacks", Label("underlay"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.204.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:204::1")
+	vip := netip.MustParseAddr("20.204.0.1")
+
+	var routes []netip.Addr
+	var owners []api.Object
+	var c Client
+	BeforeAll(func() {
+		c = NewClientWithOptions(grpcConn, WithUnderlayRouteCallback(func(_ context.Context, underlayRoute netip.Addr, owner api.Object) {
+			routes = append(routes, underlayRoute)
+			owners = append(owners, owner)
+		}))
+	})
+
+	It("should report the underlay routes of created objects", func() {
+		iface, err := c.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "ulvm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap14"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		created, err := c.CreateVirtualIP(ctx, &api.VirtualIP{
+			VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "ulvm1"},
+			Spec:          api.VirtualIPSpec{IP: &vip},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(routes).To(Equal([]netip.Addr{*iface.Spec.UnderlayRoute, *created.Spec.UnderlayRoute}))
+		Expect(owners).To(HaveLen(2))
+		Expect(owners[0]).To(BeAssignableToTypeOf(&api.Interface{}))
+		Expect(owners[0].GetID()).To(Equal("ulvm1"))
+		Expect(owners[1]).To(BeAssignableToTypeOf(&api.VirtualIP{}))
+	})
+
+	It("should not report create calls failing with an ignored error", func() {
+		_, err := c.CreateVirtualIP(ctx, &api.VirtualIP{
+			VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "ulvm1"},
+			Spec:          api.VirtualIPSpec{IP: &vip},
+		}, errors.Ignore(errors.SNAT_EXISTS))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(2))
+	})
+
+	It("should clean up", func() {
+		_, err := c.DeleteVirtualIP(ctx, "ulvm1")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.DeleteInterface(ctx, "ulvm1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"os"
+)
+
+// ClientIdentity identifies the application using the client towards dpservice.
+type ClientIdentity struct {
+	// Name is the application name, e.g. "metalnet".
+	Name string
+	// Version is the build version of the application.
+	Version string
+	// Host identifies the host the application runs on.
+	Host string
+}
+
+// HostClientIdentity returns an identity for the given application, using the hostname as Host.
+func HostClientIdentity(name, version string) ClientIdentity {
+	host, _ := os.Hostname()
+	return ClientIdentity{Name: name, Version: version, Host: host}
+}
+
+// WithClientIdentity registers the identity GetVersion reports to dpservice whenever the
+// passed api.Version leaves the client name or version empty.
+func WithClientIdentity(identity ClientIdentity) Option {
+	return func(o *options) {
+		o.identity = &identity
+	}
+}
+
+// clientName returns the name reported to dpservice. The protocol has no field for the
+// host, so it is appended to the name to show up in dpservice logs.
+func (i *ClientIdentity) clientName() string {
+	if i.Host == "" {
+		return i.Name
+	}
+	return i.Name + "@" + i.Host
+}
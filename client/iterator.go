@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// Iterator yields converted items one at a time:
+//
+//	it := client.RoutesIterator(ctx, c, vni)
+//	for it.Next() {
+//		route := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   func() (int, error)
+	convert func(i int) (*T, error)
+
+	fetched bool
+	count   int
+	index   int
+	item    *T
+	err     error
+}
+
+// newIterator creates an iterator calling fetch on the first call to Next. fetch returns
+// the number of items, which are converted lazily by convert.
+func newIterator[T any](ctx context.Context, fetch func() (int, error), convert func(i int) (*T, error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, convert: convert}
+}
+
+// Next advances to the next item and reports whether there is one.
+// It returns false once all items were yielded or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.fetched {
+		it.fetched = true
+		it.count, it.err = it.fetch()
+		if it.err != nil {
+			return false
+		}
+	}
+	for it.index < it.count {
+		item, err := it.convert(it.index)
+		it.index++
+		if err == nil {
+			it.item = item
+			return true
+		}
+		if it.err = conversionError(it.ctx, err); it.err != nil {
+			return false
+		}
+	}
+	it.item = nil
+	return false
+}
+
+// Item returns the current item. It must only be called after Next returned true.
+func (it *Iterator[T]) Item() *T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// sliceIterator creates an iterator over the items list returns, for clients without a RawClient.
+func sliceIterator[T any](ctx context.Context, list func() ([]T, error)) *Iterator[T] {
+	var items []T
+	return newIterator(ctx, func() (int, error) {
+		var err error
+		items, err = list()
+		return len(items), err
+	}, func(i int) (*T, error) {
+		return &items[i], nil
+	})
+}
+
+// RoutesIterator returns an iterator over the routes of the VNI. For clients implementing
+// RawClient, the routes are converted lazily, other clients, e.g. decorated ones, list them
+// through c.
+func RoutesIterator(ctx context.Context, c Client, vni uint32, ignoredErrors ...[]uint32) *Iterator[api.Route] {
+	raw, ok := c.(RawClient)
+	if !ok {
+		return sliceIterator(ctx, func() ([]api.Route, error) {
+			list, err := c.ListRoutes(ctx, vni, ignoredErrors...)
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
+	}
+	var res *dpdkproto.ListRoutesResponse
+	return newIterator(ctx, func() (int, error) {
+		var err error
+		res, err = raw.Raw().ListRoutes(ctx, &dpdkproto.ListRoutesRequest{
+			Vni: vni,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if res.GetStatus().GetCode() != 0 {
+			return 0, errors.GetError(res.Status, ignoredErrors)
+		}
+		return len(res.GetRoutes()), nil
+	}, func(i int) (*api.Route, error) {
+		route, err := api.ProtoRouteToRoute(vni, res.GetRoutes()[i])
+		res.Routes[i] = nil
+		return route, err
+	})
+}
+
+// InterfacesIterator returns an iterator over the interfaces, see RoutesIterator.
+func InterfacesIterator(ctx context.Context, c Client, ignoredErrors ...[]uint32) *Iterator[api.Interface] {
+	raw, ok := c.(RawClient)
+	if !ok {
+		return sliceIterator(ctx, func() ([]api.Interface, error) {
+			list, err := c.ListInterfaces(ctx, ignoredErrors...)
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
+	}
+	var res *dpdkproto.ListInterfacesResponse
+	return newIterator(ctx, func() (int, error) {
+		var err error
+		res, err = raw.Raw().ListInterfaces(ctx, &dpdkproto.ListInterfacesRequest{})
+		if err != nil {
+			return 0, err
+		}
+		if res.GetStatus().GetCode() != 0 {
+			return 0, errors.GetError(res.Status, ignoredErrors)
+		}
+		return len(res.GetInterfaces()), nil
+	}, func(i int) (*api.Interface, error) {
+		iface, err := api.ProtoInterfaceToInterface(res.GetInterfaces()[i])
+		res.Interfaces[i] = nil
+		return iface, err
+	})
+}
+
+// FirewallRulesIterator returns an iterator over the firewall rules of the interface, see
+// RoutesIterator.
+func FirewallRulesIterator(ctx context.Context, c Client, interfaceID string, ignoredErrors ...[]uint32) *Iterator[api.FirewallRule] {
+	raw, ok := c.(RawClient)
+	if !ok {
+		return sliceIterator(ctx, func() ([]api.FirewallRule, error) {
+			list, err := c.ListFirewallRules(ctx, interfaceID, ignoredErrors...)
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		})
+	}
+	var res *dpdkproto.ListFirewallRulesResponse
+	return newIterator(ctx, func() (int, error) {
+		var err error
+		res, err = raw.Raw().ListFirewallRules(ctx, &dpdkproto.ListFirewallRulesRequest{
+			InterfaceId: []byte(interfaceID),
+		})
+		if err != nil {
+			return 0, err
+		}
+		if res.GetStatus().GetCode() != 0 {
+			return 0, errors.GetError(res.Status, ignoredErrors)
+		}
+		return len(res.GetRules()), nil
+	}, func(i int) (*api.FirewallRule, error) {
+		fwRule, err := api.ProtoFwRuleToFwRule(res.GetRules()[i], interfaceID)
+		res.Rules[i] = nil
+		return fwRule, err
+	})
+}
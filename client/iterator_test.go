@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("iterator", Label("iterator"), func() {
+	It("should yield converted items lazily", func() {
+		items := []int{1, 2, 3}
+		converted := 0
+		it := newIterator(context.TODO(), func() (int, error) {
+			return len(items), nil
+		}, func(i int) (*string, error) {
+			converted++
+			s := fmt.Sprint(items[i])
+			return &s, nil
+		})
+
+		Expect(it.Next()).To(BeTrue())
+		Expect(*it.Item()).To(Equal("1"))
+		Expect(converted).To(Equal(1))
+
+		Expect(it.Next()).To(BeTrue())
+		Expect(it.Next()).To(BeTrue())
+		Expect(it.Next()).To(BeFalse())
+		Expect(it.Err()).NotTo(HaveOccurred())
+	})
+
+	It("should stop on fetch errors", func() {
+		it := newIterator(context.TODO(), func() (int, error) {
+			return 0, fmt.Errorf("boom")
+		}, func(i int) (*string, error) {
+			return nil, nil
+		})
+		Expect(it.Next()).To(BeFalse())
+		Expect(it.Err()).To(MatchError("boom"))
+	})
+
+	It("should skip malformed items with lenient conversion", func() {
+		var skipped []error
+		ctx := WithCallOptions(context.TODO(), WithLenientConversion(func(err error) {
+			skipped = append(skipped, err)
+		}))
+		it := newIterator(ctx, func() (int, error) {
+			return 3, nil
+		}, func(i int) (*string, error) {
+			if i == 1 {
+				return nil, fmt.Errorf("malformed")
+			}
+			s := fmt.Sprint(i)
+			return &s, nil
+		})
+
+		var items []string
+		for it.Next() {
+			items = append(items, *it.Item())
+		}
+		Expect(it.Err()).NotTo(HaveOccurred())
+		Expect(items).To(Equal([]string{"0", "2"}))
+		Expect(skipped).To(ConsistOf(MatchError("malformed")))
+	})
+
+	It("should report skipped items as warnings with partial results", func() {
+		var warnings []error
+		ctx := WithCallOptions(context.TODO(), WithPartialResults(&warnings))
+		it := newIterator(ctx, func() (int, error) {
+			return 3, nil
+		}, func(i int) (*string, error) {
+			if i != 1 {
+				return nil, fmt.Errorf("malformed %d", i)
+			}
+			s := fmt.Sprint(i)
+			return &s, nil
+		})
+
+		var items []string
+		for it.Next() {
+			items = append(items, *it.Item())
+		}
+		Expect(it.Err()).NotTo(HaveOccurred())
+		Expect(items).To(Equal([]string{"1"}))
+		Expect(warnings).To(ConsistOf(MatchError("malformed 0"), MatchError("malformed 2")))
+	})
+
+	It("should iterate interfaces of a live dpservice", func() {
+		list, err := dpdkClient.ListInterfaces(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, c := range []Client{dpdkClient, Chain(dpdkClient, func(c Client) Client { return struct{ Client }{c} })} {
+			it := InterfacesIterator(context.TODO(), c)
+			count := 0
+			for it.Next() {
+				count++
+			}
+			Expect(it.Err()).NotTo(HaveOccurred())
+			Expect(count).To(Equal(len(list.Items)))
+		}
+	})
+})
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// LoadBalancerTargetChanges summarizes the changes SetLoadBalancerTargets applied.
+type LoadBalancerTargetChanges struct {
+	// Added are the targets that were created.
+	Added []netip.Addr `json:"added,omitempty"`
+	// Removed are the targets that were deleted.
+	Removed []netip.Addr `json:"removed,omitempty"`
+	// Unchanged is the number of targets that were already present.
+	Unchanged int `json:"unchanged"`
+}
+
+// SetLoadBalancerTargets makes targets the targets of the load balancer. It lists the current
+// targets, creates the missing ones first and then deletes the ones no longer wanted, so the
+// load balancer never loses capacity during the update. Targets created or deleted concurrently
+// by others are not an error. Failed operations are returned as an errors.Aggregate keyed by
+// target IP, together with the changes that were applied.
+func SetLoadBalancerTargets(ctx context.Context, c Client, loadBalancerID string, targets []netip.Addr) (*LoadBalancerTargetChanges, error) {
+	current, err := c.ListLoadBalancerTargets(ctx, loadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[netip.Addr]struct{}, len(current.Items))
+	for _, target := range current.Items {
+		if target.Spec.TargetIP != nil {
+			existing[*target.Spec.TargetIP] = struct{}{}
+		}
+	}
+	desired := make(map[netip.Addr]struct{}, len(targets))
+
+	changes := &LoadBalancerTargetChanges{}
+	agg := &errors.Aggregate{}
+	for _, target := range targets {
+		if _, ok := desired[target]; ok {
+			continue
+		}
+		desired[target] = struct{}{}
+		if _, ok := existing[target]; ok {
+			changes.Unchanged++
+			continue
+		}
+		target := target
+		_, err := c.CreateLoadBalancerTarget(ctx, &api.LoadBalancerTarget{
+			TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: loadBalancerID},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &target},
+		}, errors.Ignore(errors.ALREADY_EXISTS))
+		if err != nil {
+			agg.Add(target.String(), err)
+			continue
+		}
+		changes.Added = append(changes.Added, target)
+	}
+
+	for _, target := range current.Items {
+		if target.Spec.TargetIP == nil {
+			continue
+		}
+		if _, ok := desired[*target.Spec.TargetIP]; ok {
+			continue
+		}
+		if _, err := c.DeleteLoadBalancerTarget(ctx, loadBalancerID, target.Spec.TargetIP, errors.Ignore(errors.NOT_FOUND)); err != nil {
+			agg.Add(target.Spec.TargetIP.String(), err)
+			continue
+		}
+		changes.Removed = append(changes.Removed, *target.Spec.TargetIP)
+	}
+
+	return changes, agg.ErrorOrNil()
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redacted replaces the logged values of redacted fields and metadata.
+const Redacted = "[REDACTED]"
+
+// LoggingOptions configures the request and response logging of WithLogger.
+type LoggingOptions struct {
+	// RedactedFields are the proto fields whose values are not logged, either as path from the
+	// request or response, e.g. "pxe_config.boot_filename", or as field name matching at any
+	// depth, e.g. "next_server".
+	RedactedFields []string
+	// RedactedMetadata are the gRPC metadata keys whose values are not logged.
+	RedactedMetadata []string
+	// MaxPayloadSize truncates the logged requests and responses to this many bytes.
+	// Zero means no limit.
+	MaxPayloadSize int
+}
+
+// WithLogger logs every call of the client with its request, response and metadata at
+// verbosity 1, and calls failing with a gRPC error at verbosity 0. Proto bytes fields, which
+// dpservice uses for IDs and addresses, are logged as strings.
+func WithLogger(logger logr.Logger, opts LoggingOptions) Option {
+	return func(o *options) {
+		o.logger = &callLogger{logger: logger, opts: opts}
+	}
+}
+
+type callLogger struct {
+	logger logr.Logger
+	opts   LoggingOptions
+}
+
+func (l *callLogger) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		keysAndValues := []interface{}{
+			"method", method[strings.LastIndex(method, "/")+1:],
+			"duration", time.Since(start),
+			"request", l.payload(req),
+		}
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			keysAndValues = append(keysAndValues, "metadata", l.metadata(md))
+		}
+		if err != nil {
+			l.logger.Error(err, "dpservice call failed", keysAndValues...)
+			return err
+		}
+		if res, ok := reply.(interface{ GetStatus() *dpdkproto.Status }); ok {
+			keysAndValues = append(keysAndValues, "status", res.GetStatus().GetCode())
+		}
+		keysAndValues = append(keysAndValues, "response", l.payload(reply))
+		l.logger.V(1).Info("dpservice call", keysAndValues...)
+		return nil
+	}
+}
+
+func (l *callLogger) logProtocolSkew(skew ProtocolSkew) {
+	l.logger.Info("dpservice protocol differs from the client protocol",
+		"clientProtocol", skew.ClientProtocol, "serviceProtocol", skew.ServiceProtocol)
+}
+
+// payload renders msg as JSON, redacted and truncated according to the options.
+func (l *callLogger) payload(msg interface{}) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Sprint(msg)
+	}
+	data, err := json.Marshal(l.render(m.ProtoReflect(), ""))
+	if err != nil {
+		return fmt.Sprintf("<error rendering payload: %v>", err)
+	}
+	if l.opts.MaxPayloadSize > 0 && len(data) > l.opts.MaxPayloadSize {
+		return fmt.Sprintf("%s...(%d bytes truncated)", data[:l.opts.MaxPayloadSize], len(data)-l.opts.MaxPayloadSize)
+	}
+	return string(data)
+}
+
+func (l *callLogger) redacted(name, path string) bool {
+	return slices.Contains(l.opts.RedactedFields, name) || slices.Contains(l.opts.RedactedFields, path)
+}
+
+func (l *callLogger) render(m protoreflect.Message, prefix string) map[string]interface{} {
+	out := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		path := prefix + name
+		switch {
+		case l.redacted(name, path):
+			out[name] = Redacted
+		case fd.IsList():
+			list := v.List()
+			items := make([]interface{}, list.Len())
+			for i := range items {
+				items[i] = l.renderValue(fd, list.Get(i), path)
+			}
+			out[name] = items
+		case fd.IsMap():
+			entries := make(map[string]interface{})
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				entries[k.String()] = l.renderValue(fd.MapValue(), v, path)
+				return true
+			})
+			out[name] = entries
+		default:
+			out[name] = l.renderValue(fd, v, path)
+		}
+		return true
+	})
+	return out
+}
+
+func (l *callLogger) renderValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return l.render(v.Message(), path+".")
+	case protoreflect.BytesKind:
+		return string(v.Bytes())
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
+
+func (l *callLogger) metadata(md metadata.MD) map[string][]string {
+	out := make(map[string][]string, len(md))
+	for key, values := range md {
+		if slices.Contains(l.opts.RedactedMetadata, key) {
+			values = []string{Redacted}
+		}
+		out[key] = values
+	}
+	return out
+}
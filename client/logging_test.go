@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("call logging", Label("logging"), func() {
+	var lines []string
+
+	invoke := func(opts LoggingOptions, ctx context.Context, req, reply interface{}, err error) {
+		lines = nil
+		logger := funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{Verbosity: 1})
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return err
+		}
+		l := &callLogger{logger: logger, opts: opts}
+		_ = l.interceptor()(ctx, "/dpdkironcore.v1.DPDKironcore/CreateInterface", req, reply, nil, invoker)
+	}
+
+	req := &dpdkproto.CreateInterfaceRequest{
+		InterfaceId:   []byte("vm1"),
+		InterfaceType: dpdkproto.InterfaceType_BAREMETAL,
+		PxeConfig:     &dpdkproto.PxeConfig{NextServer: "10.0.0.1", BootFilename: "/srv/boot/ipxe.efi"},
+	}
+
+	It("should log requests and responses with bytes as strings", func() {
+		invoke(LoggingOptions{}, context.TODO(), req, &dpdkproto.CreateInterfaceResponse{
+			Status:        &dpdkproto.Status{},
+			UnderlayRoute: []byte("fc00::1"),
+		}, nil)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"method"="CreateInterface"`))
+		Expect(lines[0]).To(ContainSubstring(`\"interface_id\":\"vm1\"`))
+		Expect(lines[0]).To(ContainSubstring(`\"interface_type\":\"BAREMETAL\"`))
+		Expect(lines[0]).To(ContainSubstring(`\"underlay_route\":\"fc00::1\"`))
+		Expect(lines[0]).To(ContainSubstring(`"status"=0`))
+	})
+
+	It("should redact fields by path and name", func() {
+		invoke(LoggingOptions{RedactedFields: []string{"pxe_config.boot_filename", "next_server"}}, context.TODO(), req, &dpdkproto.CreateInterfaceResponse{}, nil)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).NotTo(ContainSubstring("ipxe.efi"))
+		Expect(lines[0]).NotTo(ContainSubstring("10.0.0.1"))
+		Expect(lines[0]).To(ContainSubstring(`\"boot_filename\":\"[REDACTED]\"`))
+		Expect(lines[0]).To(ContainSubstring(`\"next_server\":\"[REDACTED]\"`))
+	})
+
+	It("should redact metadata values", func() {
+		ctx := WithCallOptions(context.TODO(), WithTenantID("tenant-a"), WithRequestID("req-1"))
+		invoke(LoggingOptions{RedactedMetadata: []string{TenantIDMetadataKey}}, ctx, req, &dpdkproto.CreateInterfaceResponse{}, nil)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).NotTo(ContainSubstring("tenant-a"))
+		Expect(lines[0]).To(ContainSubstring("req-1"))
+	})
+
+	It("should truncate large payloads", func() {
+		invoke(LoggingOptions{MaxPayloadSize: 10}, context.TODO(), req, &dpdkproto.CreateInterfaceResponse{}, nil)
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(MatchRegexp(`"request"="\{\\"[^"]{0,10}\.\.\.\(\d+ bytes truncated\)"`))
+	})
+
+	It("should log transport errors", func() {
+		invoke(LoggingOptions{}, context.TODO(), req, &dpdkproto.CreateInterfaceResponse{}, status.Error(codes.Unavailable, "down"))
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"msg"="dpservice call failed"`))
+		Expect(lines[0]).NotTo(ContainSubstring(`"response"`))
+	})
+})
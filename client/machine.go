@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/ironcore-dev/dpservice-go/tx"
+)
+
+// ApplyMachine creates the interface of the machine and then the objects configured on it.
+// Objects that already exist are kept as they are, dpservice cannot update them. If an object
+// cannot be created, the objects created by this call are deleted again in reverse order
+// and the error is returned, joined with the errors of the rollback.
+func ApplyMachine(ctx context.Context, c Client, machine *api.Machine) error {
+	return tx.Do(ctx, func(r *tx.Recorder) error {
+		for _, obj := range machine.Objects() {
+			ok, err := createMachineObject(ctx, c, obj)
+			if err != nil {
+				return fmt.Errorf("error creating %s %s: %w", obj.GetKind(), obj.GetID(), err)
+			}
+			if ok {
+				obj := obj
+				r.Record(obj.GetKind()+" "+obj.GetID(), func(ctx context.Context) error {
+					return deleteMachineObject(ctx, c, obj)
+				})
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMachine deletes the objects of the machine in reverse creation order, the interface
+// last. Objects that do not exist are skipped. Failed deletions are returned as an
+// errors.Aggregate keyed by "<kind>/<ID>".
+func DeleteMachine(ctx context.Context, c Client, machine *api.Machine) error {
+	objs := machine.Objects()
+	agg := &errors.Aggregate{}
+	for i := len(objs) - 1; i >= 0; i-- {
+		agg.Add(objs[i].GetKind()+"/"+objs[i].GetID(), deleteMachineObject(ctx, c, objs[i]))
+	}
+	return agg.ErrorOrNil()
+}
+
+// createMachineObject creates obj and reports whether it was created, false if it already existed.
+func createMachineObject(ctx context.Context, c Client, obj api.Object) (bool, error) {
+	var err error
+	var existed uint32
+	switch o := obj.(type) {
+	case *api.Interface:
+		_, err = c.CreateInterface(ctx, o)
+		existed = errors.ALREADY_EXISTS
+	case *api.VirtualIP:
+		_, err = c.CreateVirtualIP(ctx, o)
+		existed = errors.SNAT_EXISTS
+	case *api.Nat:
+		_, err = c.CreateNat(ctx, o)
+		existed = errors.SNAT_EXISTS
+	case *api.Prefix:
+		_, err = c.CreatePrefix(ctx, o)
+		existed = errors.ROUTE_EXISTS
+	case *api.LoadBalancerPrefix:
+		_, err = c.CreateLoadBalancerPrefix(ctx, o)
+		existed = errors.ALREADY_EXISTS
+	case *api.FirewallRule:
+		_, err = c.CreateFirewallRule(ctx, o)
+		existed = errors.ALREADY_EXISTS
+	case *api.LoadBalancerTarget:
+		_, err = c.CreateLoadBalancerTarget(ctx, o)
+		existed = errors.ALREADY_EXISTS
+	default:
+		return false, fmt.Errorf("unsupported machine object %T", obj)
+	}
+	if errors.IsStatusErrorCode(err, existed) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func deleteMachineObject(ctx context.Context, c Client, obj api.Object) error {
+	var err error
+	switch o := obj.(type) {
+	case *api.Interface:
+		_, err = c.DeleteInterface(ctx, o.ID, notFound)
+	case *api.VirtualIP:
+		_, err = c.DeleteVirtualIP(ctx, o.InterfaceID, notFound)
+	case *api.Nat:
+		_, err = c.DeleteNat(ctx, o.InterfaceID, notFound)
+	case *api.Prefix:
+		_, err = c.DeletePrefix(ctx, o.InterfaceID, &o.Spec.Prefix, notFound)
+	case *api.LoadBalancerPrefix:
+		_, err = c.DeleteLoadBalancerPrefix(ctx, o.InterfaceID, &o.Spec.Prefix, notFound)
+	case *api.FirewallRule:
+		_, err = c.DeleteFirewallRule(ctx, o.InterfaceID, o.Spec.RuleID, notFound)
+	case *api.LoadBalancerTarget:
+		_, err = c.DeleteLoadBalancerTarget(ctx, o.LoadbalancerID, o.Spec.TargetIP, notFound)
+	default:
+		err = fmt.Errorf("unsupported machine object %T", obj)
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("machine", Label("machine"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.200.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:200::1")
+	vip := netip.MustParseAddr("20.200.0.1")
+	prefix := netip.MustParsePrefix("10.200.1.0/24")
+	any := netip.MustParsePrefix("0.0.0.0/0")
+
+	machine := &api.Machine{
+		Interface: api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "machine1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap9"},
+		},
+		VirtualIP: &api.VirtualIP{Spec: api.VirtualIPSpec{IP: &vip}},
+		Prefixes:  []api.Prefix{{Spec: api.PrefixSpec{Prefix: prefix}}},
+		FirewallRules: []api.FirewallRule{{Spec: api.FirewallRuleSpec{
+			RuleID: "allow-all", TrafficDirection: "ingress", FirewallAction: "accept", SourcePrefix: &any, DestinationPrefix: &any,
+		}}},
+	}
+
+	It("should create all objects of the machine", func() {
+		Expect(ApplyMachine(ctx, dpdkClient, machine)).To(Succeed())
+
+		_, err := dpdkClient.GetVirtualIP(ctx, "machine1")
+		Expect(err).ToNot(HaveOccurred())
+		prefixes, err := dpdkClient.ListPrefixes(ctx, "machine1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(prefixes.Items)).To(Equal(1))
+		_, err = dpdkClient.GetFirewallRule(ctx, "machine1", "allow-all")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should keep existing objects when applied again", func() {
+		Expect(ApplyMachine(ctx, dpdkClient, machine)).To(Succeed())
+	})
+
+	It("should roll back the created objects on failure", func() {
+		broken := *machine
+		broken.Interface.ID = "machine2"
+		broken.Interface.Spec.Device = "net_tap10"
+		broken.FirewallRules = []api.FirewallRule{{Spec: api.FirewallRuleSpec{RuleID: "broken"}}}
+
+		Expect(ApplyMachine(ctx, dpdkClient, &broken)).To(MatchError(ContainSubstring("error creating FirewallRule machine2/broken")))
+		_, err := dpdkClient.GetInterface(ctx, "machine2")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+	})
+
+	It("should delete all objects of the machine", func() {
+		Expect(DeleteMachine(ctx, dpdkClient, machine)).To(Succeed())
+		_, err := dpdkClient.GetInterface(ctx, "machine1")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+
+		Expect(DeleteMachine(ctx, dpdkClient, machine)).To(Succeed())
+	})
+
+	It("should skip targets of deleted load balancers", func() {
+		target := netip.MustParseAddr("2001:db8:200::2")
+		gone := *machine
+		gone.LoadBalancerTargets = []api.LoadBalancerTarget{{
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "machinelb-gone"},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &target},
+		}}
+
+		Expect(DeleteMachine(ctx, dpdkClient, &gone)).To(Succeed())
+	})
+})
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+// Middleware decorates a Client, e.g. with caching, metrics or rate limiting. A decorator
+// typically embeds the Client it wraps and overrides only the methods it cares about:
+//
+//	type countingClient struct {
+//		client.Client
+//		creates int
+//	}
+//
+//	func (c *countingClient) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+//		c.creates++
+//		return c.Client.CreateInterface(ctx, iface, ignoredErrors...)
+//	}
+type Middleware func(Client) Client
+
+// Chain decorates c with the given middlewares. The first middleware is the outermost one.
+func Chain(c Client, middlewares ...Middleware) Client {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		c = middlewares[i](c)
+	}
+	return c
+}
+
+// ChainMiddlewares combines middlewares into a single one. The first middleware is the outermost one.
+func ChainMiddlewares(middlewares ...Middleware) Middleware {
+	return func(c Client) Client {
+		return Chain(c, middlewares...)
+	}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+type recordingClient struct {
+	Client
+	name  string
+	calls *[]string
+}
+
+func (c *recordingClient) CheckInitialized(ctx context.Context, ignoredErrors ...[]uint32) (*api.Initialized, error) {
+	*c.calls = append(*c.calls, c.name)
+	return c.Client.CheckInitialized(ctx, ignoredErrors...)
+}
+
+var _ = Describe("middleware", Label("middleware"), func() {
+	It("should apply middlewares outermost first", func() {
+		var calls []string
+		recording := func(name string) Middleware {
+			return func(c Client) Client {
+				return &recordingClient{Client: c, name: name, calls: &calls}
+			}
+		}
+
+		c := Chain(&initClient{initialized: true}, recording("first"), ChainMiddlewares(recording("second"), recording("third")))
+		_, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal([]string{"first", "second", "third"}))
+	})
+})
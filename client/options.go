@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+)
+
+// Option configures a client created by NewClientWithOptions or Dial.
+type Option func(*options)
+
+type options struct {
+	unaryInterceptors []grpc.UnaryClientInterceptor
+	callPolicy        callPolicy
+	methodPolicies    map[string]callPolicy
+	operationTimeouts map[OperationType]time.Duration
+	retryBudget       *RetryBudget
+	circuitBreaker    *CircuitBreaker
+	singleflight      bool
+	compressor        string
+	identity          *ClientIdentity
+	restartTracker    *RestartTracker
+	hooks             []Hook
+	spanAnnotator     SpanAnnotator
+	logger            *callLogger
+	skewCallbacks     []ProtocolSkewCallback
+
+	underlayRouteCallbacks []UnderlayRouteCallback
+}
+
+// WithUnaryInterceptors adds interceptors wrapping every call of the client.
+// The first interceptor is the outermost one.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// NewClientWithOptions creates a Client on top of a gRPC connection to dpservice.
+func NewClientWithOptions(cc grpc.ClientConnInterface, opts ...Option) Client {
+	return newClient(cc, newOptions(opts))
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func newClient(cc grpc.ClientConnInterface, o *options) Client {
+	stats := newStatsRecorder()
+	interceptors := o.unaryInterceptors
+	if o.spanAnnotator != nil {
+		interceptors = append(interceptors, spanInterceptor(o.spanAnnotator))
+	}
+	if len(o.hooks) > 0 {
+		interceptors = append(interceptors, hooksInterceptor(o.hooks))
+	}
+	if o.logger != nil {
+		interceptors = append(interceptors, o.logger.interceptor())
+	}
+	interceptors = append(interceptors, stats.interceptor())
+	// Always track the dpservice UUID, so it can be reported by ReadDebugInfo.
+	restartTracker := o.restartTracker
+	if restartTracker == nil {
+		restartTracker = NewRestartTracker()
+	}
+	interceptors = append(interceptors, restartTracker.Interceptor())
+	if o.singleflight {
+		interceptors = append(interceptors, (&singleflightGroup{}).interceptor())
+	}
+	if o.circuitBreaker != nil {
+		interceptors = append(interceptors, o.circuitBreaker.Interceptor())
+	}
+	interceptors = append(interceptors, policyInterceptor(callPolicies{
+		defaults:   o.callPolicy,
+		operations: o.operationTimeouts,
+		methods:    o.methodPolicies,
+		budget:     o.retryBudget,
+	}))
+	if o.compressor != "" {
+		interceptors = append(interceptors, compressionInterceptor(o.compressor))
+	}
+
+	skewCallbacks := o.skewCallbacks
+	if o.logger != nil {
+		skewCallbacks = append(skewCallbacks, o.logger.logProtocolSkew)
+	}
+
+	cc = &interceptedConn{
+		ClientConnInterface: cc,
+		interceptors:        interceptors,
+	}
+	return &client{
+		DPDKironcoreClient: dpdkproto.NewDPDKironcoreClient(cc),
+		identity:           o.identity,
+		stats:              stats,
+		restartTracker:     restartTracker,
+		skew:               &skewTracker{callbacks: skewCallbacks},
+
+		underlayRouteCallbacks: o.underlayRouteCallbacks,
+	}
+}
+
+// interceptedConn runs unary interceptors around every unary call of the wrapped connection.
+type interceptedConn struct {
+	grpc.ClientConnInterface
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	grpcConn, _ := c.ClientConnInterface.(*grpc.ClientConn)
+	return c.invoker(0)(ctx, method, args, reply, grpcConn, opts...)
+}
+
+// invoker returns an invoker running the interceptors starting at index i.
+func (c *interceptedConn) invoker(i int) grpc.UnaryInvoker {
+	if i == len(c.interceptors) {
+		return func(ctx context.Context, method string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return c.ClientConnInterface.Invoke(ctx, method, req, reply, opts...)
+		}
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.interceptors[i](ctx, method, req, reply, cc, c.invoker(i+1), opts...)
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("client options", Label("options"), func() {
+	It("should run unary interceptors in order", func() {
+		var calls []string
+		interceptor := func(name string) grpc.UnaryClientInterceptor {
+			return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				calls = append(calls, name+" "+method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			}
+		}
+
+		c := NewClientWithOptions(grpcConn, WithUnaryInterceptors(interceptor("first"), interceptor("second")))
+		_, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(calls).To(Equal([]string{
+			"first /dpdkironcore.v1.DPDKironcore/CheckInitialized",
+			"second /dpdkironcore.v1.DPDKironcore/CheckInitialized",
+		}))
+	})
+})
+
+var _ = Describe("compression", Label("compression"), func() {
+	It("should call dpservice with gzip compression", func() {
+		c := NewClientWithOptions(grpcConn, WithCompression(GzipCompressor))
+		_, err := c.ListInterfaces(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("client identity", Label("identity"), func() {
+	It("should report the registered identity in GetVersion", func() {
+		c := NewClientWithOptions(grpcConn, WithClientIdentity(ClientIdentity{Name: "agent", Version: "v1.2.3", Host: "node1"}))
+		version, err := c.GetVersion(context.TODO(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version.ClientName).To(Equal("agent@node1"))
+		Expect(version.ClientVersion).To(Equal("v1.2.3"))
+		Expect(version.Spec.ServiceVersion).NotTo(BeEmpty())
+	})
+})
+
+var _ = Describe("protocol skew", Label("skew"), func() {
+	// rewritingProtocol makes dpservice report the given protocol in GetVersion.
+	rewritingProtocol := func(protocol *string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if res, ok := reply.(*dpdkproto.GetVersionResponse); ok && err == nil {
+				res.ServiceProtocol = *protocol
+			}
+			return err
+		}
+	}
+
+	It("should record the skew revealed by GetVersion", func() {
+		protocol := strings.TrimSpace(dpdkproto.GeneratedFrom)
+		var skews []ProtocolSkew
+		c := NewClientWithOptions(grpcConn,
+			WithUnaryInterceptors(rewritingProtocol(&protocol)),
+			WithProtocolSkewCallback(func(skew ProtocolSkew) { skews = append(skews, skew) }),
+		)
+		skewClient := c.(ProtocolSkewClient)
+		Expect(skewClient.ProtocolSkew()).To(BeNil())
+
+		version, err := c.GetVersion(context.TODO(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skewClient.ProtocolSkew()).To(BeNil())
+		Expect(VersionProtocolSkew(version)).To(BeNil())
+
+		protocol = "v0.0.1"
+		for i := 0; i < 2; i++ {
+			version, err = c.GetVersion(context.TODO(), nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		skew := ProtocolSkew{ClientProtocol: strings.TrimSpace(dpdkproto.GeneratedFrom), ServiceProtocol: "v0.0.1"}
+		Expect(skewClient.ProtocolSkew()).To(Equal(&skew))
+		Expect(VersionProtocolSkew(version)).To(Equal(&skew))
+		Expect(skews).To(Equal([]ProtocolSkew{skew}))
+		Expect(ReadDebugInfo(c).ProtocolSkew).To(Equal(&skew))
+
+		protocol = strings.TrimSpace(dpdkproto.GeneratedFrom)
+		_, err = c.GetVersion(context.TODO(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skewClient.ProtocolSkew()).To(BeNil())
+	})
+})
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/tx"
+)
+
+// The Patch* helpers centralize the read-modify-write pattern: they get the live object, let
+// mutate change a copy of its spec and apply the result if it differs by api.SpecEqual.
+// dpservice cannot update objects, so changed objects are deleted and recreated. Note that
+// recreating an interface also deletes the objects configured on it, and recreating a load
+// balancer its targets. The patched object is returned, the live one if nothing changed.
+// Fields dpservice never returns cannot be patched, as the live value is unknown: mutating the
+// PXE config, NAT or virtual IP of an interface, the VNI of a NAT or the expiry of a firewall
+// rule fails with an error. If the patched object cannot be created, the live object is
+// created again, without the objects dpservice deleted along with it.
+
+// patchRollbackTimeout bounds recreating the live object after a failed patch.
+var patchRollbackTimeout = time.Minute
+
+// PatchInterface patches the spec of the interface id.
+func PatchInterface(ctx context.Context, c Client, id string, mutate func(spec *api.InterfaceSpec)) (*api.Interface, error) {
+	return patch(ctx, c, &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, func(iface *api.Interface) {
+		mutate(&iface.Spec)
+	})
+}
+
+// PatchVirtualIP patches the spec of the virtual IP of the interface.
+func PatchVirtualIP(ctx context.Context, c Client, interfaceID string, mutate func(spec *api.VirtualIPSpec)) (*api.VirtualIP, error) {
+	return patch(ctx, c, &api.VirtualIP{VirtualIPMeta: api.VirtualIPMeta{InterfaceID: interfaceID}}, func(vip *api.VirtualIP) {
+		mutate(&vip.Spec)
+	})
+}
+
+// PatchNat patches the spec of the NAT of the interface.
+func PatchNat(ctx context.Context, c Client, interfaceID string, mutate func(spec *api.NatSpec)) (*api.Nat, error) {
+	return patch(ctx, c, &api.Nat{NatMeta: api.NatMeta{InterfaceID: interfaceID}}, func(nat *api.Nat) {
+		mutate(&nat.Spec)
+	})
+}
+
+// PatchLoadBalancer patches the spec of the load balancer id.
+func PatchLoadBalancer(ctx context.Context, c Client, id string, mutate func(spec *api.LoadBalancerSpec)) (*api.LoadBalancer, error) {
+	return patch(ctx, c, &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: id}}, func(lb *api.LoadBalancer) {
+		mutate(&lb.Spec)
+	})
+}
+
+// PatchFirewallRule patches the spec of the firewall rule of the interface. The rule ID
+// cannot be changed.
+func PatchFirewallRule(ctx context.Context, c Client, interfaceID, ruleID string, mutate func(spec *api.FirewallRuleSpec)) (*api.FirewallRule, error) {
+	rule := &api.FirewallRule{
+		FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: interfaceID},
+		Spec:             api.FirewallRuleSpec{RuleID: ruleID},
+	}
+	return patch(ctx, c, rule, func(rule *api.FirewallRule) {
+		mutate(&rule.Spec)
+		rule.Spec.RuleID = ruleID
+	})
+}
+
+func patch[T api.Object](ctx context.Context, c Client, obj T, mutate func(T)) (T, error) {
+	objs := NewResource[T](c)
+	live, err := objs.Get(ctx, obj)
+	if err != nil {
+		return live, err
+	}
+	patched, err := deepCopy(live)
+	if err != nil {
+		return live, fmt.Errorf("error copying %s %s: %w", live.GetKind(), live.GetID(), err)
+	}
+	mutate(patched)
+	if field, ok := unpatchableField(live, patched); ok {
+		return live, fmt.Errorf("cannot patch %s of %s %s: it is not returned by dpservice", field, live.GetKind(), live.GetID())
+	}
+	if api.SpecEqual(live, patched) {
+		return live, nil
+	}
+
+	if err := objs.Delete(ctx, live); err != nil {
+		return live, fmt.Errorf("error deleting %s %s to recreate it: %w", live.GetKind(), live.GetID(), err)
+	}
+	r := &tx.Recorder{}
+	r.Record(live.GetKind()+" "+live.GetID(), func(ctx context.Context) error {
+		_, err := objs.Create(ctx, live)
+		return err
+	})
+	created, err := objs.Create(ctx, patched)
+	if err != nil {
+		// restore the live object even if the create failed because ctx is done
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), patchRollbackTimeout)
+		defer cancel()
+		err = fmt.Errorf("error recreating %s %s: %w", live.GetKind(), live.GetID(), err)
+		return live, errors.Join(err, r.Rollback(rollbackCtx))
+	}
+	return created, nil
+}
+
+// unpatchableField returns the name of a spec field api.SpecEqual ignores, as dpservice never
+// returns it, that differs between live and patched.
+func unpatchableField(live, patched api.Object) (string, bool) {
+	switch live := live.(type) {
+	case *api.Interface:
+		patched := patched.(*api.Interface)
+		switch {
+		case !reflect.DeepEqual(live.Spec.PXE, patched.Spec.PXE):
+			return "pxe", true
+		case !reflect.DeepEqual(live.Spec.Nat, patched.Spec.Nat):
+			return "nat", true
+		case !reflect.DeepEqual(live.Spec.VIP, patched.Spec.VIP):
+			return "vip", true
+		}
+	case *api.Nat:
+		if live.Spec.Vni != patched.(*api.Nat).Spec.Vni {
+			return "vni", true
+		}
+	case *api.FirewallRule:
+		if !reflect.DeepEqual(live.Spec.ExpiresAt, patched.(*api.FirewallRule).Spec.ExpiresAt) {
+			return "expires_at", true
+		}
+	}
+	return "", false
+}
+
+// deepCopy copies obj through its JSON encoding, so mutating the copy cannot change obj.
+func deepCopy[T api.Object](obj T) (T, error) {
+	var zero T
+	kind, err := api.DefaultScheme.KindOf(obj)
+	if err != nil {
+		return zero, err
+	}
+	copied, err := api.DefaultScheme.New(kind)
+	if err != nil {
+		return zero, err
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return zero, err
+	}
+	if err := json.Unmarshal(data, copied); err != nil {
+		return zero, err
+	}
+	return copied.(T), nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("patch", Label("patch"), func() {
+	ctx := context.TODO()
+
+	It("should apply only changed specs", func() {
+		ipv4 := netip.MustParseAddr("10.207.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8:207::1")
+		created, err := dpdkClient.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "patchvm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap17"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			_, err := dpdkClient.DeleteInterface(ctx, "patchvm1", errors.Ignore(errors.NOT_FOUND))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("keeping an unchanged interface")
+		iface, err := PatchInterface(ctx, dpdkClient, "patchvm1", func(spec *api.InterfaceSpec) {
+			*spec.IPv4 = ipv4
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iface.Spec.UnderlayRoute).To(Equal(created.Spec.UnderlayRoute))
+
+		By("rejecting changes of fields dpservice does not return")
+		_, err = PatchInterface(ctx, dpdkClient, "patchvm1", func(spec *api.InterfaceSpec) {
+			spec.PXE = &api.PXE{Server: "10.207.0.254", FileName: "boot.ipxe"}
+		})
+		Expect(err).To(MatchError(ContainSubstring("cannot patch pxe of Interface patchvm1")))
+
+		By("recreating a changed interface")
+		patchedIPv4 := netip.MustParseAddr("10.207.0.2")
+		iface, err = PatchInterface(ctx, dpdkClient, "patchvm1", func(spec *api.InterfaceSpec) {
+			*spec.IPv4 = patchedIPv4
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*iface.Spec.IPv4).To(Equal(patchedIPv4))
+
+		live, err := dpdkClient.GetInterface(ctx, "patchvm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*live.Spec.IPv4).To(Equal(patchedIPv4))
+	})
+
+	It("should restore the live object if the patched one cannot be created", func() {
+		ipv4 := netip.MustParseAddr("10.207.0.3")
+		ipv6 := netip.MustParseAddr("2001:db8:207::3")
+		_, err := dpdkClient.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "patchvm2"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap18"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			_, err := dpdkClient.DeleteInterface(ctx, "patchvm2", errors.Ignore(errors.NOT_FOUND))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		c := &failingCreateClient{Client: dpdkClient}
+		_, err = PatchInterface(ctx, c, "patchvm2", func(spec *api.InterfaceSpec) {
+			*spec.IPv4 = netip.MustParseAddr("10.207.0.4")
+		})
+		Expect(err).To(MatchError(ContainSubstring("error recreating Interface patchvm2: create failed")))
+
+		live, err := dpdkClient.GetInterface(ctx, "patchvm2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*live.Spec.IPv4).To(Equal(ipv4))
+	})
+
+	It("should fail for missing objects", func() {
+		_, err := PatchLoadBalancer(ctx, dpdkClient, "patchlb1", func(spec *api.LoadBalancerSpec) {})
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND, errors.NO_LB)).To(BeTrue())
+	})
+})
+
+// failingCreateClient fails the first interface creation, passing later ones through.
+type failingCreateClient struct {
+	Client
+	failed bool
+}
+
+func (c *failingCreateClient) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	if !c.failed {
+		c.failed = true
+		return nil, stderrors.New("create failed")
+	}
+	return c.Client.CreateInterface(ctx, iface, ignoredErrors...)
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy describes how failed gRPC calls are retried. Only transport level failures
+// are retried, dpservice status errors are returned to the caller as they are.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+	// BackoffMultiplier is applied to the delay after each retry. Values below 1 are treated as 1.
+	BackoffMultiplier float64
+	// RetryableCodes are the gRPC codes worth retrying. Defaults to codes.Unavailable.
+	RetryableCodes []codes.Code
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	if len(p.RetryableCodes) == 0 {
+		return code == codes.Unavailable
+	}
+	return slices.Contains(p.RetryableCodes, code)
+}
+
+func (p *RetryPolicy) do(ctx context.Context, budget *RetryBudget, call func(ctx context.Context) error) error {
+	budget.deposit()
+	backoff := p.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := call(ctx)
+		if err == nil || attempt >= p.MaxAttempts || !p.retryable(err) || !budget.withdraw() {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		if p.BackoffMultiplier > 1 {
+			backoff = time.Duration(float64(backoff) * p.BackoffMultiplier)
+		}
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// WithDefaultTimeout bounds every call of the client, including retries, to d unless
+// overridden per call with WithDeadline.
+func WithDefaultTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.callPolicy.timeout = d
+	}
+}
+
+// WithDefaultRetryPolicy retries every call of the client according to p unless
+// overridden per call with WithRetryPolicy.
+func WithDefaultRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.callPolicy.retryPolicy = &p
+	}
+}
+
+// WithMethodTimeout overrides the default timeout for the given RPC, e.g. "ResetVni".
+// Method names are the RPC names of the DPDKironcore service.
+func WithMethodTimeout(method string, d time.Duration) Option {
+	return func(o *options) {
+		p := o.methodPolicies[method]
+		p.timeout = d
+		o.setMethodPolicy(method, p)
+	}
+}
+
+// WithMethodRetryPolicy overrides the default retry policy for the given RPC, e.g. "CreateRoute".
+// Method names are the RPC names of the DPDKironcore service.
+func WithMethodRetryPolicy(method string, policy RetryPolicy) Option {
+	return func(o *options) {
+		p := o.methodPolicies[method]
+		p.retryPolicy = &policy
+		o.setMethodPolicy(method, p)
+	}
+}
+
+// OperationType classifies the RPCs of dpservice for WithOperationTimeout.
+type OperationType int
+
+const (
+	// ReadOperation are Get, List and status RPCs.
+	ReadOperation OperationType = iota
+	// MutationOperation are all RPCs changing state, e.g. Create and Delete RPCs.
+	MutationOperation
+	// HeavyOperation are RPCs doing a lot of work in dpservice, i.e. ResetVni, and calls made
+	// with a context marked by WithOperationType, e.g. those of snapshot.Take.
+	HeavyOperation
+)
+
+// operationTypeOf classifies an RPC by its name.
+func operationTypeOf(method string) OperationType {
+	switch {
+	case method == "ResetVni":
+		return HeavyOperation
+	case strings.HasPrefix(method, "Get"), strings.HasPrefix(method, "List"),
+		method == "CheckInitialized", method == "CaptureStatus":
+		return ReadOperation
+	default:
+		return MutationOperation
+	}
+}
+
+// WithOperationTimeout bounds the calls of the given operation type to d if the caller's
+// context has no deadline. It overrides the default timeout, but not method specific timeouts
+// or WithDeadline.
+func WithOperationTimeout(op OperationType, d time.Duration) Option {
+	return func(o *options) {
+		if o.operationTimeouts == nil {
+			o.operationTimeouts = make(map[OperationType]time.Duration)
+		}
+		o.operationTimeouts[op] = d
+	}
+}
+
+func (o *options) setMethodPolicy(method string, p callPolicy) {
+	if o.methodPolicies == nil {
+		o.methodPolicies = make(map[string]callPolicy)
+	}
+	o.methodPolicies[method] = p
+}
+
+// WithRetryBudget limits the retries of all calls of the client by budget.
+func WithRetryBudget(budget *RetryBudget) Option {
+	return func(o *options) {
+		o.retryBudget = budget
+	}
+}
+
+// RetryBudget limits retries to a fraction of all calls, so retries cannot multiply the
+// load on a struggling dpservice. Every call earns ratio tokens, every retry costs one token,
+// and the budget holds at most maxTokens, which also allows short bursts of retries.
+type RetryBudget struct {
+	ratio     float64
+	maxTokens float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// NewRetryBudget creates a budget allowing retries for the given fraction of calls, e.g. 0.1 for 10%.
+func NewRetryBudget(ratio float64, maxTokens int) *RetryBudget {
+	return &RetryBudget{
+		ratio:     ratio,
+		maxTokens: float64(maxTokens),
+		tokens:    float64(maxTokens),
+	}
+}
+
+func (b *RetryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.ratio, b.maxTokens)
+}
+
+func (b *RetryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// WithDeadline bounds a call, including retries, to d. It overrides the client's default timeout.
+// Only clients created by NewClientWithOptions or Dial honor it.
+func WithDeadline(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.policy.timeout = d
+	}
+}
+
+// WithOperationType makes calls count as op for WithOperationTimeout, e.g. to give all calls of
+// a snapshot the timeout of heavy operations.
+func WithOperationType(op OperationType) CallOption {
+	return func(o *callOptions) {
+		o.operationType = &op
+	}
+}
+
+// WithRetryPolicy retries a call according to p. It overrides the client's default retry policy.
+// Only clients created by NewClientWithOptions or Dial honor it.
+func WithRetryPolicy(p RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.policy.retryPolicy = &p
+	}
+}
+
+// callPolicy holds the timeout and retry settings of a call. Zero fields are unset.
+type callPolicy struct {
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+}
+
+func (p callPolicy) isZero() bool {
+	return p.timeout == 0 && p.retryPolicy == nil
+}
+
+// merge returns p with all fields set in override replaced.
+func (p callPolicy) merge(override callPolicy) callPolicy {
+	if override.timeout != 0 {
+		p.timeout = override.timeout
+	}
+	if override.retryPolicy != nil {
+		p.retryPolicy = override.retryPolicy
+	}
+	return p
+}
+
+type callPolicyKey struct{}
+
+func callPolicyFromContext(ctx context.Context) callPolicy {
+	p, _ := ctx.Value(callPolicyKey{}).(callPolicy)
+	return p
+}
+
+type operationTypeKey struct{}
+
+// callPolicies are the timeout and retry settings of a client.
+type callPolicies struct {
+	defaults   callPolicy
+	operations map[OperationType]time.Duration
+	methods    map[string]callPolicy
+	budget     *RetryBudget
+}
+
+// defaultsFor returns the default policy of method, including its operation timeout if ctx
+// has no deadline.
+func (p callPolicies) defaultsFor(ctx context.Context, method string) callPolicy {
+	defaults := p.defaults
+	if _, ok := ctx.Deadline(); ok {
+		return defaults
+	}
+	op, ok := ctx.Value(operationTypeKey{}).(OperationType)
+	if !ok {
+		op = operationTypeOf(method)
+	}
+	if d := p.operations[op]; d > 0 {
+		defaults.timeout = d
+	}
+	return defaults
+}
+
+// policyInterceptor applies the call policy stored in the context on top of the method
+// specific, operation and default policies.
+func policyInterceptor(policies callPolicies) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		name := method[strings.LastIndex(method, "/")+1:]
+		p := policies.defaultsFor(ctx, name).
+			merge(policies.methods[name]).
+			merge(callPolicyFromContext(ctx))
+		if p.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+		}
+		if p.retryPolicy == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return p.retryPolicy.do(ctx, policies.budget, func(ctx context.Context) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+	}
+}
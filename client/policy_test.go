@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("call policy", Label("policy"), func() {
+	failing := func(attempts *int, failures int, code codes.Code) grpc.UnaryInvoker {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			*attempts++
+			if *attempts <= failures {
+				return status.Error(code, "failed")
+			}
+			return nil
+		}
+	}
+
+	It("should retry retryable errors", func() {
+		attempts := 0
+		interceptor := policyInterceptor(callPolicies{defaults: callPolicy{retryPolicy: &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}})
+		Expect(interceptor(context.TODO(), "method", nil, nil, nil, failing(&attempts, 2, codes.Unavailable))).To(Succeed())
+		Expect(attempts).To(Equal(3))
+	})
+
+	It("should not retry other errors", func() {
+		attempts := 0
+		interceptor := policyInterceptor(callPolicies{defaults: callPolicy{retryPolicy: &RetryPolicy{MaxAttempts: 3}}})
+		Expect(interceptor(context.TODO(), "method", nil, nil, nil, failing(&attempts, 2, codes.InvalidArgument))).NotTo(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
+
+	It("should let call options override the defaults", func() {
+		var deadline time.Time
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			deadline, _ = ctx.Deadline()
+			return nil
+		}
+
+		interceptor := policyInterceptor(callPolicies{defaults: callPolicy{timeout: time.Second}})
+		ctx := WithCallOptions(context.TODO(), WithDeadline(time.Hour))
+		Expect(interceptor(ctx, "method", nil, nil, nil, invoker)).To(Succeed())
+		Expect(time.Until(deadline)).To(BeNumerically(">", time.Minute))
+	})
+
+	It("should apply method specific policies", func() {
+		attempts := 0
+		interceptor := policyInterceptor(callPolicies{
+			defaults: callPolicy{retryPolicy: &RetryPolicy{MaxAttempts: 1}},
+			methods:  map[string]callPolicy{"CreateRoute": {retryPolicy: &RetryPolicy{MaxAttempts: 3}}},
+		})
+		Expect(interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/CreateRoute", nil, nil, nil, failing(&attempts, 5, codes.Unavailable))).NotTo(Succeed())
+		Expect(attempts).To(Equal(3))
+
+		attempts = 0
+		Expect(interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/DeleteRoute", nil, nil, nil, failing(&attempts, 5, codes.Unavailable))).NotTo(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
+
+	It("should apply operation timeouts if the context has no deadline", func() {
+		var deadline time.Time
+		var hasDeadline bool
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			deadline, hasDeadline = ctx.Deadline()
+			return nil
+		}
+
+		interceptor := policyInterceptor(callPolicies{
+			defaults: callPolicy{timeout: time.Second},
+			operations: map[OperationType]time.Duration{
+				ReadOperation:  time.Minute,
+				HeavyOperation: time.Hour,
+			},
+			methods: map[string]callPolicy{"GetVni": {timeout: 2 * time.Second}},
+		})
+		call := func(ctx context.Context, method string) time.Duration {
+			Expect(interceptor(ctx, "/dpdkironcore.v1.DPDKironcore/"+method, nil, nil, nil, invoker)).To(Succeed())
+			Expect(hasDeadline).To(BeTrue())
+			return time.Until(deadline)
+		}
+
+		Expect(call(context.TODO(), "ListInterfaces")).To(BeNumerically("~", time.Minute, time.Second))
+		Expect(call(context.TODO(), "ResetVni")).To(BeNumerically("~", time.Hour, time.Second))
+		Expect(call(context.TODO(), "CreateInterface")).To(BeNumerically("<=", time.Second))
+		Expect(call(context.TODO(), "GetVni")).To(BeNumerically("~", 2*time.Second, time.Second))
+
+		heavy := WithCallOptions(context.TODO(), WithOperationType(HeavyOperation))
+		Expect(call(heavy, "ListInterfaces")).To(BeNumerically("~", time.Hour, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Minute)
+		defer cancel()
+		Expect(call(ctx, "ResetVni")).To(BeNumerically("<=", time.Second))
+	})
+
+	It("should stop retrying when the retry budget is exhausted", func() {
+		attempts := 0
+		interceptor := policyInterceptor(callPolicies{
+			defaults: callPolicy{retryPolicy: &RetryPolicy{MaxAttempts: 10}},
+			budget:   NewRetryBudget(0.1, 2),
+		})
+		Expect(interceptor(context.TODO(), "method", nil, nil, nil, failing(&attempts, 100, codes.Unavailable))).NotTo(Succeed())
+		Expect(attempts).To(Equal(3))
+	})
+})
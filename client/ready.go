@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+// The checks of Ready, in the order they are run.
+const (
+	ReadinessCheckConnectivity = "connectivity"
+	ReadinessCheckInitialized  = "initialized"
+	ReadinessCheckProtocol     = "protocol"
+)
+
+// ReadinessCheck is the result of a single check of Ready.
+type ReadinessCheck struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	// Reason tells why the check failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Readiness is the result of Ready.
+type Readiness struct {
+	Ready  bool             `json:"ready"`
+	Checks []ReadinessCheck `json:"checks"`
+}
+
+// Reasons returns the reasons of the failed checks, prefixed with the check name.
+func (r Readiness) Reasons() []string {
+	var reasons []string
+	for _, check := range r.Checks {
+		if !check.Ready {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", check.Name, check.Reason))
+		}
+	}
+	return reasons
+}
+
+// stateSource is implemented by clients knowing the state of their connection.
+type stateSource interface {
+	State() connectivity.State
+}
+
+// Ready reports whether dpservice is ready to be used through c, e.g. to gate scheduling
+// onto a node: the connection must not be failing, dpservice must be initialized and speak
+// the protocol the client was generated from. All checks are run, so the result lists every
+// reason at once. The connectivity check passes for clients not created by Dial.
+func Ready(ctx context.Context, c Client) Readiness {
+	readiness := Readiness{
+		Ready: true,
+		Checks: []ReadinessCheck{
+			readinessCheck(ReadinessCheckConnectivity, checkConnectivity(c)),
+			readinessCheck(ReadinessCheckInitialized, checkInitialized(ctx, c)),
+			readinessCheck(ReadinessCheckProtocol, checkProtocol(ctx, c)),
+		},
+	}
+	for _, check := range readiness.Checks {
+		readiness.Ready = readiness.Ready && check.Ready
+	}
+	return readiness
+}
+
+func readinessCheck(name string, err error) ReadinessCheck {
+	if err != nil {
+		return ReadinessCheck{Name: name, Reason: err.Error()}
+	}
+	return ReadinessCheck{Name: name, Ready: true}
+}
+
+// checkConnectivity fails while the connection is failing. Idle connections are fine, they
+// connect on the next call.
+func checkConnectivity(c Client) error {
+	source, ok := c.(stateSource)
+	if !ok {
+		return nil
+	}
+	switch state := source.State(); state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return fmt.Errorf("connection is %s", state)
+	}
+	return nil
+}
+
+func checkInitialized(ctx context.Context, c Client) error {
+	initialized, err := c.CheckInitialized(ctx)
+	if err != nil {
+		return err
+	}
+	if initialized.Spec.UUID == "" {
+		return fmt.Errorf("dpservice is not initialized")
+	}
+	return nil
+}
+
+func checkProtocol(ctx context.Context, c Client) error {
+	version, err := c.GetVersion(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if skew := VersionProtocolSkew(version); skew != nil {
+		return fmt.Errorf("client protocol %s differs from dpservice protocol %s", skew.ClientProtocol, skew.ServiceProtocol)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("readiness", Label("ready"), func() {
+	It("should be ready if all checks pass", func() {
+		readiness := Ready(context.TODO(), dpdkClient)
+		Expect(readiness.Ready).To(BeTrue())
+		Expect(readiness.Checks).To(Equal([]ReadinessCheck{
+			{Name: ReadinessCheckConnectivity, Ready: true},
+			{Name: ReadinessCheckInitialized, Ready: true},
+			{Name: ReadinessCheckProtocol, Ready: true},
+		}))
+		Expect(readiness.Reasons()).To(BeEmpty())
+	})
+
+	It("should report the reasons of failed checks", func() {
+		c := NewClientWithOptions(grpcConn, WithUnaryInterceptors(
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				err := invoker(ctx, method, req, reply, cc, opts...)
+				if res, ok := reply.(*dpdkproto.GetVersionResponse); ok && err == nil {
+					res.ServiceProtocol = "v0.0.1"
+				}
+				return err
+			}))
+
+		readiness := Ready(context.TODO(), c)
+		Expect(readiness.Ready).To(BeFalse())
+		Expect(readiness.Reasons()).To(ConsistOf(MatchRegexp(`^protocol: client protocol .* differs from dpservice protocol v0\.0\.1$`)))
+	})
+})
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	rpbalpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// ServerFileDescriptorSet asks the gRPC server reflection service of the dpservice behind conn
+// for the descriptors of the file defining the DPDKironcore service and the files it imports,
+// dependencies first. Compare them to dpdkproto.FileDescriptorSet to detect schema drift.
+// Servers only offering the v1alpha reflection service are supported as well.
+func ServerFileDescriptorSet(ctx context.Context, conn grpc.ClientConnInterface) (*descriptorpb.FileDescriptorSet, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	files, err := reflectFiles(ctx, newReflectionStream(ctx, conn))
+	if status.Code(err) == codes.Unimplemented {
+		files, err = reflectFiles(ctx, newAlphaReflectionStream(ctx, conn))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reflecting dpservice descriptors: %w", err)
+	}
+	return files, nil
+}
+
+// reflectionStream exchanges v1 reflection messages with a server.
+type reflectionStream interface {
+	Send(req *rpb.ServerReflectionRequest) error
+	Recv() (*rpb.ServerReflectionResponse, error)
+}
+
+type failedReflectionStream struct {
+	err error
+}
+
+func (s failedReflectionStream) Send(*rpb.ServerReflectionRequest) error { return s.err }
+
+func (s failedReflectionStream) Recv() (*rpb.ServerReflectionResponse, error) { return nil, s.err }
+
+func newReflectionStream(ctx context.Context, conn grpc.ClientConnInterface) reflectionStream {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return failedReflectionStream{err}
+	}
+	return stream
+}
+
+// alphaReflectionStream speaks v1alpha, whose messages are wire compatible with v1.
+type alphaReflectionStream struct {
+	stream rpbalpha.ServerReflection_ServerReflectionInfoClient
+}
+
+func newAlphaReflectionStream(ctx context.Context, conn grpc.ClientConnInterface) reflectionStream {
+	stream, err := rpbalpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return failedReflectionStream{err}
+	}
+	return alphaReflectionStream{stream}
+}
+
+func (s alphaReflectionStream) Send(req *rpb.ServerReflectionRequest) error {
+	alphaReq := &rpbalpha.ServerReflectionRequest{}
+	if err := convertMessage(req, alphaReq); err != nil {
+		return err
+	}
+	return s.stream.Send(alphaReq)
+}
+
+func (s alphaReflectionStream) Recv() (*rpb.ServerReflectionResponse, error) {
+	alphaRes, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	res := &rpb.ServerReflectionResponse{}
+	if err := convertMessage(alphaRes, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func convertMessage(from, to proto.Message) error {
+	data, err := proto.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, to)
+}
+
+// reflectFiles requests the file of the DPDKironcore service and then every import the
+// server did not send along.
+func reflectFiles(ctx context.Context, stream reflectionStream) (*descriptorpb.FileDescriptorSet, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	var root string
+	req := &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{
+		FileContainingSymbol: dpdkproto.DPDKironcore_ServiceDesc.ServiceName,
+	}}
+	for req != nil {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if errRes := res.GetErrorResponse(); errRes != nil {
+			return nil, status.Error(codes.Code(errRes.GetErrorCode()), errRes.GetErrorMessage())
+		}
+		for i, data := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(data, file); err != nil {
+				return nil, fmt.Errorf("error decoding file descriptor: %w", err)
+			}
+			files[file.GetName()] = file
+			if root == "" && i == 0 {
+				root = file.GetName()
+			}
+		}
+		if root == "" {
+			return nil, fmt.Errorf("server sent no file descriptors")
+		}
+
+		req = nil
+		for _, file := range files {
+			for _, dep := range file.GetDependency() {
+				if _, ok := files[dep]; !ok {
+					req = &rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep}}
+					break
+				}
+			}
+			if req != nil {
+				break
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]bool)
+	var add func(name string)
+	add = func(name string) {
+		if added[name] {
+			return
+		}
+		added[name] = true
+		for _, dep := range files[name].GetDependency() {
+			add(dep)
+		}
+		set.File = append(set.File, files[name])
+	}
+	add(root)
+	return set, nil
+}
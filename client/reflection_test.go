@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	rpbalpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var _ = Describe("reflection", Label("reflection"), func() {
+	ctx := context.TODO()
+
+	dial := func(addr string) *grpc.ClientConn {
+		conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+		return conn
+	}
+
+	It("should return the descriptors the client was generated from", func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		files, err := ServerFileDescriptorSet(ctx, dial(sim.Addr()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(files, dpdkproto.FileDescriptorSet())).To(BeTrue())
+	})
+
+	It("should fall back to v1alpha reflection", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		server := grpc.NewServer()
+		simulator.NewServer(netip.Prefix{}).Register(server)
+		rpbalpha.RegisterServerReflectionServer(server, reflection.NewServer(reflection.ServerOptions{Services: server}))
+		go func() { _ = server.Serve(listener) }()
+		DeferCleanup(server.Stop)
+
+		files, err := ServerFileDescriptorSet(ctx, dial(listener.Addr().String()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files.File[len(files.File)-1].GetName()).To(Equal(dpdkproto.File_proto_dpdk_proto.Path()))
+	})
+
+	It("should fail without reflection", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		server := grpc.NewServer()
+		go func() { _ = server.Serve(listener) }()
+		DeferCleanup(server.Stop)
+
+		_, err = ServerFileDescriptorSet(ctx, dial(listener.Addr().String()))
+		Expect(err).To(MatchError(ContainSubstring("error reflecting dpservice descriptors")))
+	})
+})
@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// The resource clients expose the operations of a single kind, so code working with one kind
+// can depend on (and mock) a small interface instead of Client. They are views on a Client,
+// any middlewares decorating it apply:
+//
+//	ifaces := client.Interfaces(c)
+//	iface, err := ifaces.Get(ctx, "vm1")
+
+// LoadBalancerClient manages load balancers.
+type LoadBalancerClient interface {
+	Get(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error)
+	Create(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) (*api.LoadBalancer, error)
+	Delete(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error)
+}
+
+// LoadBalancerPrefixClient manages the load balancer prefixes of interfaces.
+type LoadBalancerPrefixClient interface {
+	List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error)
+	Create(ctx context.Context, prefix *api.LoadBalancerPrefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error)
+	Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error)
+}
+
+// LoadBalancerTargetClient manages the targets of load balancers.
+type LoadBalancerTargetClient interface {
+	List(ctx context.Context, loadBalancerID string, ignoredErrors ...[]uint32) (*api.LoadBalancerTargetList, error)
+	Create(ctx context.Context, target *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error)
+	Delete(ctx context.Context, loadBalancerID string, targetIP *netip.Addr, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error)
+}
+
+// InterfaceClient manages interfaces.
+type InterfaceClient interface {
+	Get(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error)
+	List(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error)
+	Create(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error)
+	Delete(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error)
+}
+
+// VirtualIPClient manages the virtual IPs of interfaces.
+type VirtualIPClient interface {
+	Get(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error)
+	Create(ctx context.Context, virtualIP *api.VirtualIP, ignoredErrors ...[]uint32) (*api.VirtualIP, error)
+	Delete(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error)
+}
+
+// PrefixClient manages the prefixes of interfaces.
+type PrefixClient interface {
+	List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error)
+	Create(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error)
+	Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error)
+}
+
+// RouteClient manages the routes of VNIs.
+type RouteClient interface {
+	List(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error)
+	Create(ctx context.Context, route *api.Route, ignoredErrors ...[]uint32) (*api.Route, error)
+	Delete(ctx context.Context, vni uint32, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Route, error)
+}
+
+// NatClient manages the NATs of interfaces. List lists the local NATs of a NAT IP.
+type NatClient interface {
+	Get(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error)
+	List(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error)
+	Create(ctx context.Context, nat *api.Nat, ignoredErrors ...[]uint32) (*api.Nat, error)
+	Delete(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error)
+}
+
+// NeighborNatClient manages neighbor NATs.
+type NeighborNatClient interface {
+	List(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error)
+	Create(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error)
+	Delete(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error)
+}
+
+// FirewallRuleClient manages the firewall rules of interfaces.
+type FirewallRuleClient interface {
+	Get(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error)
+	List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error)
+	Create(ctx context.Context, rule *api.FirewallRule, ignoredErrors ...[]uint32) (*api.FirewallRule, error)
+	Delete(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error)
+}
+
+// LoadBalancers returns the LoadBalancerClient of c.
+func LoadBalancers(c Client) LoadBalancerClient {
+	return loadBalancers{c}
+}
+
+// LoadBalancerPrefixes returns the LoadBalancerPrefixClient of c.
+func LoadBalancerPrefixes(c Client) LoadBalancerPrefixClient {
+	return loadBalancerPrefixes{c}
+}
+
+// LoadBalancerTargets returns the LoadBalancerTargetClient of c.
+func LoadBalancerTargets(c Client) LoadBalancerTargetClient {
+	return loadBalancerTargets{c}
+}
+
+// Interfaces returns the InterfaceClient of c.
+func Interfaces(c Client) InterfaceClient {
+	return interfaces{c}
+}
+
+// VirtualIPs returns the VirtualIPClient of c.
+func VirtualIPs(c Client) VirtualIPClient {
+	return virtualIPs{c}
+}
+
+// Prefixes returns the PrefixClient of c.
+func Prefixes(c Client) PrefixClient {
+	return prefixes{c}
+}
+
+// Routes returns the RouteClient of c.
+func Routes(c Client) RouteClient {
+	return routes{c}
+}
+
+// Nats returns the NatClient of c.
+func Nats(c Client) NatClient {
+	return nats{c}
+}
+
+// NeighborNats returns the NeighborNatClient of c.
+func NeighborNats(c Client) NeighborNatClient {
+	return neighborNats{c}
+}
+
+// FirewallRules returns the FirewallRuleClient of c.
+func FirewallRules(c Client) FirewallRuleClient {
+	return firewallRules{c}
+}
+
+type loadBalancers struct{ c Client }
+
+func (r loadBalancers) Get(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return r.c.GetLoadBalancer(ctx, id, ignoredErrors...)
+}
+
+func (r loadBalancers) Create(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return r.c.CreateLoadBalancer(ctx, lb, ignoredErrors...)
+}
+
+func (r loadBalancers) Delete(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	return r.c.DeleteLoadBalancer(ctx, id, ignoredErrors...)
+}
+
+type loadBalancerPrefixes struct{ c Client }
+
+func (r loadBalancerPrefixes) List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return r.c.ListLoadBalancerPrefixes(ctx, interfaceID, ignoredErrors...)
+}
+
+func (r loadBalancerPrefixes) Create(ctx context.Context, prefix *api.LoadBalancerPrefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	return r.c.CreateLoadBalancerPrefix(ctx, prefix, ignoredErrors...)
+}
+
+func (r loadBalancerPrefixes) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.LoadBalancerPrefix, error) {
+	return r.c.DeleteLoadBalancerPrefix(ctx, interfaceID, prefix, ignoredErrors...)
+}
+
+type loadBalancerTargets struct{ c Client }
+
+func (r loadBalancerTargets) List(ctx context.Context, loadBalancerID string, ignoredErrors ...[]uint32) (*api.LoadBalancerTargetList, error) {
+	return r.c.ListLoadBalancerTargets(ctx, loadBalancerID, ignoredErrors...)
+}
+
+func (r loadBalancerTargets) Create(ctx context.Context, target *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	return r.c.CreateLoadBalancerTarget(ctx, target, ignoredErrors...)
+}
+
+func (r loadBalancerTargets) Delete(ctx context.Context, loadBalancerID string, targetIP *netip.Addr, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	return r.c.DeleteLoadBalancerTarget(ctx, loadBalancerID, targetIP, ignoredErrors...)
+}
+
+type interfaces struct{ c Client }
+
+func (r interfaces) Get(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return r.c.GetInterface(ctx, id, ignoredErrors...)
+}
+
+func (r interfaces) List(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	return r.c.ListInterfaces(ctx, ignoredErrors...)
+}
+
+func (r interfaces) Create(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return r.c.CreateInterface(ctx, iface, ignoredErrors...)
+}
+
+func (r interfaces) Delete(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	return r.c.DeleteInterface(ctx, id, ignoredErrors...)
+}
+
+type virtualIPs struct{ c Client }
+
+func (r virtualIPs) Get(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return r.c.GetVirtualIP(ctx, interfaceID, ignoredErrors...)
+}
+
+func (r virtualIPs) Create(ctx context.Context, virtualIP *api.VirtualIP, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return r.c.CreateVirtualIP(ctx, virtualIP, ignoredErrors...)
+}
+
+func (r virtualIPs) Delete(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return r.c.DeleteVirtualIP(ctx, interfaceID, ignoredErrors...)
+}
+
+type prefixes struct{ c Client }
+
+func (r prefixes) List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return r.c.ListPrefixes(ctx, interfaceID, ignoredErrors...)
+}
+
+func (r prefixes) Create(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	return r.c.CreatePrefix(ctx, prefix, ignoredErrors...)
+}
+
+func (r prefixes) Delete(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	return r.c.DeletePrefix(ctx, interfaceID, prefix, ignoredErrors...)
+}
+
+type routes struct{ c Client }
+
+func (r routes) List(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	return r.c.ListRoutes(ctx, vni, ignoredErrors...)
+}
+
+func (r routes) Create(ctx context.Context, route *api.Route, ignoredErrors ...[]uint32) (*api.Route, error) {
+	return r.c.CreateRoute(ctx, route, ignoredErrors...)
+}
+
+func (r routes) Delete(ctx context.Context, vni uint32, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Route, error) {
+	return r.c.DeleteRoute(ctx, vni, prefix, ignoredErrors...)
+}
+
+type nats struct{ c Client }
+
+func (r nats) Get(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return r.c.GetNat(ctx, interfaceID, ignoredErrors...)
+}
+
+func (r nats) List(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	return r.c.ListLocalNats(ctx, natIP, ignoredErrors...)
+}
+
+func (r nats) Create(ctx context.Context, nat *api.Nat, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return r.c.CreateNat(ctx, nat, ignoredErrors...)
+}
+
+func (r nats) Delete(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return r.c.DeleteNat(ctx, interfaceID, ignoredErrors...)
+}
+
+type neighborNats struct{ c Client }
+
+func (r neighborNats) List(ctx context.Context, natIP *netip.Addr, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	return r.c.ListNeighborNats(ctx, natIP, ignoredErrors...)
+}
+
+func (r neighborNats) Create(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	return r.c.CreateNeighborNat(ctx, nat, ignoredErrors...)
+}
+
+func (r neighborNats) Delete(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) (*api.NeighborNat, error) {
+	return r.c.DeleteNeighborNat(ctx, nat, ignoredErrors...)
+}
+
+type firewallRules struct{ c Client }
+
+func (r firewallRules) Get(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return r.c.GetFirewallRule(ctx, interfaceID, ruleID, ignoredErrors...)
+}
+
+func (r firewallRules) List(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	return r.c.ListFirewallRules(ctx, interfaceID, ignoredErrors...)
+}
+
+func (r firewallRules) Create(ctx context.Context, rule *api.FirewallRule, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return r.c.CreateFirewallRule(ctx, rule, ignoredErrors...)
+}
+
+func (r firewallRules) Delete(ctx context.Context, interfaceID string, ruleID string, ignoredErrors ...[]uint32) (*api.FirewallRule, error) {
+	return r.c.DeleteFirewallRule(ctx, interfaceID, ruleID, ignoredErrors...)
+}
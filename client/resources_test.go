@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+type countingInterfaceCreates struct {
+	Client
+	creates int
+}
+
+func (c *countingInterfaceCreates) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	c.creates++
+	return c.Client.CreateInterface(ctx, iface, ignoredErrors...)
+}
+
+var _ = Describe("resource clients", Label("resources"), Ordered, func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.202.0.1")
+	ipv6 := netip.MustParseAddr("2001:db8:202::1")
+	prefix := netip.MustParsePrefix("10.202.1.0/24")
+
+	It("should manage interfaces through the decorated client", func() {
+		counting := &countingInterfaceCreates{Client: dpdkClient}
+		ifaces := Interfaces(counting)
+
+		_, err := ifaces.Create(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "resvm1"},
+			Spec:          api.InterfaceSpec{VNI: positiveTestVNI, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap12"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(counting.creates).To(Equal(1))
+
+		iface, err := ifaces.Get(ctx, "resvm1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(iface.Spec.VNI).To(Equal(uint32(positiveTestVNI)))
+
+		list, err := ifaces.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Items).To(ContainElement(HaveField("ID", "resvm1")))
+	})
+
+	It("should manage prefixes", func() {
+		prefixes := Prefixes(dpdkClient)
+		_, err := prefixes.Create(ctx, &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: "resvm1"},
+			Spec:       api.PrefixSpec{Prefix: prefix},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		list, err := prefixes.List(ctx, "resvm1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+
+		_, err = prefixes.Delete(ctx, "resvm1", &prefix)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should delete the interface", func() {
+		ifaces := Interfaces(dpdkClient)
+		_, err := ifaces.Delete(ctx, "resvm1")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = ifaces.Get(ctx, "resvm1", errors.Ignore(errors.NOT_FOUND))
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+)
+
+// ErrRestarted is the cause of contexts returned by RestartTracker.Guard once a dpservice
+// restart was detected.
+var ErrRestarted = errors.New("dpservice restarted")
+
+// RestartCallback is called with the previous and the new initialization UUID of dpservice.
+type RestartCallback func(oldUUID, newUUID string)
+
+// RestartTracker tracks the initialization UUID reported by CheckInitialized and Initialize
+// and detects dpservice restarts by its change. Restarts are only noticed on these calls,
+// so consumers should check periodically, e.g. with EnsureInitialized.
+type RestartTracker struct {
+	mu        sync.Mutex
+	uuid      string
+	callbacks []RestartCallback
+	guards    map[*guard]struct{}
+}
+
+type guard struct {
+	cancel context.CancelCauseFunc
+}
+
+// NewRestartTracker creates a tracker calling onRestart whenever a restart is detected.
+func NewRestartTracker(onRestart ...RestartCallback) *RestartTracker {
+	return &RestartTracker{
+		callbacks: onRestart,
+		guards:    make(map[*guard]struct{}),
+	}
+}
+
+// WithRestartTracker lets t observe every call of the client.
+func WithRestartTracker(t *RestartTracker) Option {
+	return func(o *options) {
+		o.restartTracker = t
+	}
+}
+
+// UUID returns the last seen initialization UUID.
+func (t *RestartTracker) UUID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.uuid
+}
+
+// Guard returns a copy of ctx that is canceled with cause ErrRestarted when a restart is
+// detected, failing operations that must not continue against a fresh dpservice.
+// The returned cancel function must be called to release the guard.
+func (t *RestartTracker) Guard(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &guard{cancel: cancel}
+
+	t.mu.Lock()
+	t.guards[g] = struct{}{}
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.guards, g)
+		t.mu.Unlock()
+		cancel(context.Canceled)
+	}
+}
+
+// Observe records uuid as the current initialization UUID, firing the callbacks and
+// canceling all guarded contexts if it differs from the previous one.
+func (t *RestartTracker) Observe(uuid string) {
+	if uuid == "" {
+		return
+	}
+
+	t.mu.Lock()
+	oldUUID := t.uuid
+	t.uuid = uuid
+	if oldUUID == "" || oldUUID == uuid {
+		t.mu.Unlock()
+		return
+	}
+	for g := range t.guards {
+		g.cancel(ErrRestarted)
+		delete(t.guards, g)
+	}
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(oldUUID, uuid)
+	}
+}
+
+// Interceptor returns a unary interceptor observing the UUIDs returned by dpservice.
+func (t *RestartTracker) Interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		switch res := reply.(type) {
+		case *dpdkproto.CheckInitializedResponse:
+			if res.GetStatus().GetCode() == 0 {
+				t.Observe(res.GetUuid())
+			}
+		case *dpdkproto.InitializeResponse:
+			if res.GetStatus().GetCode() == 0 {
+				t.Observe(res.GetUuid())
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("restart tracker", Label("restart"), func() {
+	It("should detect a changed initialization UUID", func() {
+		var restarts [][2]string
+		t := NewRestartTracker(func(oldUUID, newUUID string) {
+			restarts = append(restarts, [2]string{oldUUID, newUUID})
+		})
+		ctx, cancel := t.Guard(context.TODO())
+		defer cancel()
+
+		t.Observe("first")
+		t.Observe("first")
+		Expect(restarts).To(BeEmpty())
+		Expect(ctx.Err()).NotTo(HaveOccurred())
+
+		t.Observe("second")
+		Expect(restarts).To(Equal([][2]string{{"first", "second"}}))
+		Expect(t.UUID()).To(Equal("second"))
+		Expect(context.Cause(ctx)).To(MatchError(ErrRestarted))
+	})
+
+	It("should track the UUID of a live dpservice", func() {
+		t := NewRestartTracker()
+		c := NewClientWithOptions(grpcConn, WithRestartTracker(t))
+		initialized, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.UUID()).To(Equal(initialized.Spec.UUID))
+	})
+})
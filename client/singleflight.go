@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithSingleflight collapses concurrent read calls (Get*, List*, CheckInitialized) with identical
+// arguments into a single RPC whose result is shared by all callers. An error of the shared call,
+// including one caused by the first caller's context, is returned to every caller.
+func WithSingleflight() Option {
+	return func(o *options) {
+		o.singleflight = true
+	}
+}
+
+type flight struct {
+	done  chan struct{}
+	reply proto.Message
+	err   error
+}
+
+type singleflightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+func isReadMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List") || name == "CheckInitialized"
+}
+
+func (g *singleflightGroup) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		reqMsg, ok := req.(proto.Message)
+		replyMsg, ok2 := reply.(proto.Message)
+		if !ok || !ok2 || !isReadMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(reqMsg)
+		if err != nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		key := method + "\x00" + string(data)
+
+		g.mu.Lock()
+		if f, ok := g.flights[key]; ok {
+			g.mu.Unlock()
+			select {
+			case <-f.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if f.err != nil {
+				return f.err
+			}
+			proto.Reset(replyMsg)
+			proto.Merge(replyMsg, f.reply)
+			return nil
+		}
+		f := &flight{done: make(chan struct{})}
+		if g.flights == nil {
+			g.flights = make(map[string]*flight)
+		}
+		g.flights[key] = f
+		g.mu.Unlock()
+
+		f.err = invoker(ctx, method, req, reply, cc, opts...)
+		if f.err == nil {
+			f.reply = proto.Clone(replyMsg)
+		}
+
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+
+		return f.err
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("singleflight", Label("singleflight"), func() {
+	It("should share the result of concurrent identical calls", func() {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls.Add(1)
+			<-release
+			reply.(*dpdkproto.GetInterfaceResponse).Interface = &dpdkproto.Interface{Id: []byte("vm1")}
+			return nil
+		}
+
+		interceptor := (&singleflightGroup{}).interceptor()
+		req := &dpdkproto.GetInterfaceRequest{InterfaceId: []byte("vm1")}
+		replies := make([]*dpdkproto.GetInterfaceResponse, 5)
+
+		var wg sync.WaitGroup
+		for i := range replies {
+			replies[i] = &dpdkproto.GetInterfaceResponse{}
+			wg.Add(1)
+			go func(reply *dpdkproto.GetInterfaceResponse) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				Expect(interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/GetInterface", req, reply, nil, invoker)).To(Succeed())
+			}(replies[i])
+		}
+		Eventually(calls.Load).Should(BeEquivalentTo(1))
+		close(release)
+		wg.Wait()
+
+		Expect(calls.Load()).To(BeEquivalentTo(1))
+		for _, reply := range replies {
+			Expect(string(reply.GetInterface().GetId())).To(Equal("vm1"))
+		}
+	})
+
+	It("should not collapse mutating calls", func() {
+		Expect(isReadMethod("/dpdkironcore.v1.DPDKironcore/CreateInterface")).To(BeFalse())
+		Expect(isReadMethod("/dpdkironcore.v1.DPDKironcore/ListRoutes")).To(BeTrue())
+	})
+})
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// ProtocolSkew describes a difference between the protocol the client was generated from
+// and the protocol dpservice reported in GetVersion.
+type ProtocolSkew struct {
+	ClientProtocol  string `json:"clientProtocol"`
+	ServiceProtocol string `json:"serviceProtocol"`
+}
+
+// ProtocolSkewCallback is called whenever GetVersion reveals a new protocol skew.
+type ProtocolSkewCallback func(skew ProtocolSkew)
+
+// WithProtocolSkewCallback registers callbacks called whenever GetVersion reveals a new
+// protocol skew, e.g. to log it or to emit a metric. Clients created with WithLogger also
+// log skews.
+func WithProtocolSkewCallback(callbacks ...ProtocolSkewCallback) Option {
+	return func(o *options) {
+		o.skewCallbacks = append(o.skewCallbacks, callbacks...)
+	}
+}
+
+// skewTracker records the protocol skew last revealed by GetVersion.
+type skewTracker struct {
+	mu        sync.Mutex
+	skew      *ProtocolSkew
+	callbacks []ProtocolSkewCallback
+}
+
+// observe records the protocols of a GetVersion call, firing the callbacks if they differ
+// and the skew is new. Empty service protocols, e.g. of older dpservice versions, are ignored.
+func (t *skewTracker) observe(clientProtocol, serviceProtocol string) {
+	if t == nil || serviceProtocol == "" {
+		return
+	}
+
+	t.mu.Lock()
+	if clientProtocol == serviceProtocol {
+		t.skew = nil
+		t.mu.Unlock()
+		return
+	}
+	skew := ProtocolSkew{ClientProtocol: clientProtocol, ServiceProtocol: serviceProtocol}
+	if t.skew != nil && *t.skew == skew {
+		t.mu.Unlock()
+		return
+	}
+	t.skew = &skew
+	callbacks := t.callbacks
+	t.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(skew)
+	}
+}
+
+func (t *skewTracker) get() *ProtocolSkew {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.skew == nil {
+		return nil
+	}
+	skew := *t.skew
+	return &skew
+}
+
+// VersionProtocolSkew returns the difference between the protocol of the client and of
+// dpservice reported by a GetVersion call, or nil if there is none. Empty service protocols,
+// e.g. of older dpservice versions, are no skew.
+func VersionProtocolSkew(version *api.Version) *ProtocolSkew {
+	clientProtocol := version.ClientProtocol
+	if clientProtocol == "" {
+		clientProtocol = strings.TrimSpace(dpdkproto.GeneratedFrom)
+	}
+	serviceProtocol := strings.TrimSpace(version.Spec.ServiceProtocol)
+	if serviceProtocol == "" || serviceProtocol == clientProtocol {
+		return nil
+	}
+	return &ProtocolSkew{ClientProtocol: clientProtocol, ServiceProtocol: serviceProtocol}
+}
+
+// ProtocolSkewClient is implemented by the clients created by this package. ProtocolSkew
+// returns the difference between the protocol of the client and of dpservice revealed by the
+// last GetVersion call, or nil if there is none or GetVersion was not called.
+type ProtocolSkewClient interface {
+	ProtocolSkew() *ProtocolSkew
+}
+
+func (c *client) ProtocolSkew() *ProtocolSkew {
+	return c.skew.get()
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LatencyBuckets are the upper bounds of the latency histograms recorded by the client.
+var LatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Stats is a snapshot of the calls made by a client since its creation or the last reset.
+type Stats struct {
+	// Since is the start of the recording.
+	Since time.Time
+	// Methods holds the statistics per RPC name, e.g. "CreateInterface".
+	Methods map[string]MethodStats
+}
+
+// MethodStats are the call statistics of a single RPC. A call including its retries counts once.
+type MethodStats struct {
+	Calls uint64
+	// TransportErrors counts the calls failed with a gRPC error by code.
+	TransportErrors map[codes.Code]uint64
+	// StatusErrors counts the calls answered with a dpservice error by status code.
+	StatusErrors map[uint32]uint64
+	// Latency is the histogram of call durations.
+	Latency Histogram
+}
+
+// Histogram counts durations in the buckets of LatencyBuckets. Counts has one more entry than
+// Buckets for durations exceeding the last bucket.
+type Histogram struct {
+	Buckets []time.Duration
+	Counts  []uint64
+	Sum     time.Duration
+}
+
+// Mean returns the average duration.
+func (h Histogram) Mean() time.Duration {
+	var count uint64
+	for _, c := range h.Counts {
+		count += c
+	}
+	if count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(count)
+}
+
+func (h *Histogram) observe(d time.Duration) {
+	h.Counts[sort.Search(len(h.Buckets), func(i int) bool { return d <= h.Buckets[i] })]++
+	h.Sum += d
+}
+
+// Errors returns the number of failed calls.
+func (s MethodStats) Errors() uint64 {
+	var errs uint64
+	for _, n := range s.TransportErrors {
+		errs += n
+	}
+	for _, n := range s.StatusErrors {
+		errs += n
+	}
+	return errs
+}
+
+// StatusErrorsByName counts the calls answered with a dpservice error by status name, e.g.
+// "NOT_FOUND", so expected lookup misses can be told apart from real failures.
+func (s MethodStats) StatusErrorsByName() map[string]uint64 {
+	byName := make(map[string]uint64, len(s.StatusErrors))
+	for code, n := range s.StatusErrors {
+		byName[errors.StatusName(code)] += n
+	}
+	return byName
+}
+
+func (s MethodStats) clone() MethodStats {
+	c := s
+	c.TransportErrors = make(map[codes.Code]uint64, len(s.TransportErrors))
+	for code, n := range s.TransportErrors {
+		c.TransportErrors[code] = n
+	}
+	c.StatusErrors = make(map[uint32]uint64, len(s.StatusErrors))
+	for code, n := range s.StatusErrors {
+		c.StatusErrors[code] = n
+	}
+	c.Latency.Counts = append([]uint64(nil), s.Latency.Counts...)
+	return c
+}
+
+// statsRecorder records the statistics of a client.
+type statsRecorder struct {
+	mu      sync.Mutex
+	since   time.Time
+	methods map[string]*MethodStats
+}
+
+func newStatsRecorder() *statsRecorder {
+	r := &statsRecorder{}
+	r.reset()
+	return r
+}
+
+func (r *statsRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.since = time.Now()
+	r.methods = make(map[string]*MethodStats)
+}
+
+func (r *statsRecorder) snapshot() Stats {
+	if r == nil {
+		return Stats{Methods: map[string]MethodStats{}}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := Stats{Since: r.since, Methods: make(map[string]MethodStats, len(r.methods))}
+	for method, s := range r.methods {
+		stats.Methods[method] = s.clone()
+	}
+	return stats
+}
+
+func (r *statsRecorder) record(method string, d time.Duration, err error, reply interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.methods[method]
+	if !ok {
+		s = &MethodStats{
+			TransportErrors: make(map[codes.Code]uint64),
+			StatusErrors:    make(map[uint32]uint64),
+			Latency: Histogram{
+				Buckets: LatencyBuckets,
+				Counts:  make([]uint64, len(LatencyBuckets)+1),
+			},
+		}
+		r.methods[method] = s
+	}
+
+	s.Calls++
+	s.Latency.observe(d)
+	if err != nil {
+		s.TransportErrors[status.Code(err)]++
+		return
+	}
+	if res, ok := reply.(interface{ GetStatus() *dpdkproto.Status }); ok && res.GetStatus().GetCode() != 0 {
+		s.StatusErrors[res.GetStatus().GetCode()]++
+	}
+}
+
+func (r *statsRecorder) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		r.record(method[strings.LastIndex(method, "/")+1:], time.Since(start), err, reply)
+		return err
+	}
+}
+
+// StatsClient is implemented by the clients created by this package, only those created by
+// NewClientWithOptions or Dial record statistics though. Stats returns the call statistics of
+// the client and ResetStats clears them.
+type StatsClient interface {
+	Stats() Stats
+	ResetStats()
+}
+
+func (c *client) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+func (c *client) ResetStats() {
+	if c.stats != nil {
+		c.stats.reset()
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("client stats", Label("stats"), func() {
+	invoke := func(r *statsRecorder, method string, reply interface{}, err error) {
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			time.Sleep(2 * time.Millisecond)
+			return err
+		}
+		_ = r.interceptor()(context.TODO(), "/dpdkironcore.v1.DPDKironcore/"+method, nil, reply, nil, invoker)
+	}
+
+	It("should count calls, errors and latencies per method", func() {
+		r := newStatsRecorder()
+		invoke(r, "CreateRoute", &dpdkproto.CreateRouteResponse{Status: &dpdkproto.Status{}}, nil)
+		invoke(r, "CreateRoute", &dpdkproto.CreateRouteResponse{Status: &dpdkproto.Status{Code: errors.ROUTE_EXISTS}}, nil)
+		invoke(r, "CreateRoute", &dpdkproto.CreateRouteResponse{}, status.Error(codes.Unavailable, "down"))
+
+		stats := r.snapshot()
+		route := stats.Methods["CreateRoute"]
+		Expect(route.Calls).To(BeEquivalentTo(3))
+		Expect(route.Errors()).To(BeEquivalentTo(2))
+		Expect(route.StatusErrors).To(Equal(map[uint32]uint64{errors.ROUTE_EXISTS: 1}))
+		Expect(route.StatusErrorsByName()).To(Equal(map[string]uint64{"ROUTE_EXISTS": 1}))
+		Expect(route.TransportErrors).To(Equal(map[codes.Code]uint64{codes.Unavailable: 1}))
+		Expect(route.Latency.Counts[0]).To(BeZero())
+		Expect(route.Latency.Counts[1]).To(BeEquivalentTo(3))
+		Expect(route.Latency.Mean()).To(BeNumerically(">=", 2*time.Millisecond))
+	})
+
+	It("should reset the statistics", func() {
+		c := &client{stats: newStatsRecorder()}
+		invoke(c.stats, "ListRoutes", &dpdkproto.ListRoutesResponse{}, nil)
+		snapshot := c.Stats()
+		Expect(snapshot.Methods).To(HaveKey("ListRoutes"))
+
+		c.ResetStats()
+		Expect(c.Stats().Methods).To(BeEmpty())
+		Expect(snapshot.Methods).To(HaveKey("ListRoutes"))
+	})
+
+	It("should record calls of clients created with options", func() {
+		c := NewClientWithOptions(grpcConn)
+		_, err := c.CheckInitialized(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.(StatsClient).Stats().Methods["CheckInitialized"].Calls).To(BeEquivalentTo(1))
+	})
+})
@@ -24,6 +24,7 @@ var (
 	ctxCancel       context.CancelFunc
 	ctxGrpc         context.Context
 	dpserviceAddr   string = "127.0.0.1:1337"
+	grpcConn        *grpc.ClientConn
 	dpdkProtoClient dpdkproto.DPDKironcoreClient
 	dpdkClient      Client
 )
@@ -45,6 +46,7 @@ var _ = BeforeSuite(func() {
 
 	conn, err := grpc.DialContext(ctxGrpc, dpserviceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
 	Expect(err).NotTo(HaveOccurred())
+	grpcConn = conn
 
 	dpdkProtoClient = dpdkproto.NewDPDKironcoreClient(conn)
 	dpdkClient = NewClient(dpdkProtoClient)
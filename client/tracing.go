@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"strconv"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+)
+
+// Keys of the span attributes describing the outcome of a call.
+const (
+	SpanAttributeStatusCode    = "dpservice.status.code"
+	SpanAttributeStatusMessage = "dpservice.status.message"
+	SpanAttributeUnderlayRoute = "dpservice.underlay_route"
+	SpanAttributeVFName        = "dpservice.vf.name"
+	SpanAttributePCIName       = "dpservice.interface.pci_name"
+)
+
+// SpanAttribute is a key/value pair describing what dpservice answered to a call.
+type SpanAttribute struct {
+	Key   string
+	Value string
+}
+
+// SpanAnnotator attaches attributes to the span active in ctx. It adapts the client to the
+// tracing library in use, e.g. for OpenTelemetry:
+//
+//	func(ctx context.Context, attrs []client.SpanAttribute) {
+//		span := trace.SpanFromContext(ctx)
+//		for _, attr := range attrs {
+//			span.SetAttributes(attribute.String(attr.Key, attr.Value))
+//		}
+//	}
+type SpanAnnotator func(ctx context.Context, attrs []SpanAttribute)
+
+// WithSpanAnnotator annotates the span of every call with the dpservice status code and the
+// underlay routes and virtual functions dpservice allocated, so traces alone are enough to
+// reconstruct the state of the dataplane. The span must be started by an interceptor passed
+// with WithUnaryInterceptors or by the caller.
+func WithSpanAnnotator(annotator SpanAnnotator) Option {
+	return func(o *options) {
+		o.spanAnnotator = annotator
+	}
+}
+
+func spanInterceptor(annotator SpanAnnotator) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			if attrs := spanAttributes(reply); len(attrs) > 0 {
+				annotator(ctx, attrs)
+			}
+		}
+		return err
+	}
+}
+
+// spanAttributes returns the attributes describing reply.
+func spanAttributes(reply interface{}) []SpanAttribute {
+	var attrs []SpanAttribute
+	if res, ok := reply.(interface{ GetStatus() *dpdkproto.Status }); ok && res.GetStatus() != nil {
+		attrs = append(attrs, SpanAttribute{Key: SpanAttributeStatusCode, Value: strconv.FormatUint(uint64(res.GetStatus().GetCode()), 10)})
+		if msg := res.GetStatus().GetMessage(); msg != "" {
+			attrs = append(attrs, SpanAttribute{Key: SpanAttributeStatusMessage, Value: msg})
+		}
+	}
+	if res, ok := reply.(interface{ GetUnderlayRoute() []byte }); ok && len(res.GetUnderlayRoute()) > 0 {
+		attrs = append(attrs, SpanAttribute{Key: SpanAttributeUnderlayRoute, Value: string(res.GetUnderlayRoute())})
+	}
+	if res, ok := reply.(*dpdkproto.CreateInterfaceResponse); ok && res.GetVf().GetName() != "" {
+		attrs = append(attrs, SpanAttribute{Key: SpanAttributeVFName, Value: res.GetVf().GetName()})
+	}
+	if res, ok := reply.(interface{ GetInterface() *dpdkproto.Interface }); ok && res.GetInterface() != nil {
+		iface := res.GetInterface()
+		if len(iface.GetUnderlayRoute()) > 0 {
+			attrs = append(attrs, SpanAttribute{Key: SpanAttributeUnderlayRoute, Value: string(iface.GetUnderlayRoute())})
+		}
+		if iface.GetPciName() != "" {
+			attrs = append(attrs, SpanAttribute{Key: SpanAttributePCIName, Value: iface.GetPciName()})
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("span annotations", Label("tracing"), func() {
+	annotate := func(reply interface{}, err error) []SpanAttribute {
+		var attrs []SpanAttribute
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return err
+		}
+		interceptor := spanInterceptor(func(_ context.Context, a []SpanAttribute) {
+			attrs = append(attrs, a...)
+		})
+		_ = interceptor(context.TODO(), "/dpdkironcore.v1.DPDKironcore/CreateInterface", nil, reply, nil, invoker)
+		return attrs
+	}
+
+	It("should annotate the allocated underlay route and virtual function", func() {
+		Expect(annotate(&dpdkproto.CreateInterfaceResponse{
+			Status:        &dpdkproto.Status{},
+			UnderlayRoute: []byte("fc00::1"),
+			Vf:            &dpdkproto.VirtualFunction{Name: "net_tap5"},
+		}, nil)).To(Equal([]SpanAttribute{
+			{Key: SpanAttributeStatusCode, Value: "0"},
+			{Key: SpanAttributeUnderlayRoute, Value: "fc00::1"},
+			{Key: SpanAttributeVFName, Value: "net_tap5"},
+		}))
+	})
+
+	It("should annotate the PCI name of returned interfaces", func() {
+		Expect(annotate(&dpdkproto.GetInterfaceResponse{
+			Status:    &dpdkproto.Status{},
+			Interface: &dpdkproto.Interface{UnderlayRoute: []byte("fc00::2"), PciName: "0000:3b:00.2"},
+		}, nil)).To(ContainElements(
+			SpanAttribute{Key: SpanAttributeUnderlayRoute, Value: "fc00::2"},
+			SpanAttribute{Key: SpanAttributePCIName, Value: "0000:3b:00.2"},
+		))
+	})
+
+	It("should annotate dpservice status errors", func() {
+		Expect(annotate(&dpdkproto.CreateRouteResponse{
+			Status: &dpdkproto.Status{Code: errors.ROUTE_EXISTS, Message: "ROUTE_EXISTS"},
+		}, nil)).To(Equal([]SpanAttribute{
+			{Key: SpanAttributeStatusCode, Value: "301"},
+			{Key: SpanAttributeStatusMessage, Value: "ROUTE_EXISTS"},
+		}))
+	})
+
+	It("should not annotate transport errors", func() {
+		Expect(annotate(&dpdkproto.CreateRouteResponse{}, status.Error(codes.Unavailable, "down"))).To(BeEmpty())
+	})
+})
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// UnderlayRouteCallback is called with the underlay route dpservice allocated for owner,
+// the object returned by the create call, e.g. an *api.Interface or *api.LoadBalancer.
+type UnderlayRouteCallback func(ctx context.Context, underlayRoute netip.Addr, owner api.Object)
+
+// WithUnderlayRouteCallback registers callbacks called whenever a create call of the client
+// returns a new underlay route, i.e. for interfaces, virtual IPs, NATs, prefixes, load
+// balancer prefixes and load balancers. Route-announcement daemons can subscribe to them
+// instead of inspecting the return values at every call site. Calls failing with an
+// ignored error, e.g. ALREADY_EXISTS, allocate no route and are not reported.
+func WithUnderlayRouteCallback(callbacks ...UnderlayRouteCallback) Option {
+	return func(o *options) {
+		o.underlayRouteCallbacks = append(o.underlayRouteCallbacks, callbacks...)
+	}
+}
+
+func (c *clientV2) notifyUnderlayRoute(ctx context.Context, underlayRoute netip.Addr, owner api.Object) {
+	for _, callback := range c.underlayRouteCallbacks {
+		callback(ctx, underlayRoute, owner)
+	}
+}
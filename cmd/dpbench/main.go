@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command dpbench measures the create, list and delete throughput and latency of dpservice.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ironcore-dev/dpservice-go/bench"
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+var logger = log.New(os.Stderr, "dpbench: ", log.LstdFlags)
+
+func main() {
+	var (
+		address string
+		cfg     bench.Config
+		vni     uint
+	)
+	flag.StringVar(&address, "address", "127.0.0.1:1337", "Address of dpservice, also unix:// and vsock:// targets are supported.")
+	flag.IntVar(&cfg.Requests, "requests", 1000, "Number of requests per operation.")
+	flag.IntVar(&cfg.Concurrency, "concurrency", 8, "Number of requests in flight.")
+	flag.UintVar(&vni, "vni", 4242, "VNI to create the benchmark routes and a placeholder loadbalancer in, it should not be used otherwise.")
+	flag.Parse()
+	cfg.VNI = uint32(vni)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := client.Dial(ctx, address)
+	if err != nil {
+		logger.Fatalf("error connecting to dpservice: %v", err)
+	}
+	defer c.Close()
+
+	results, err := bench.Routes(ctx, c, cfg)
+	for _, res := range results {
+		fmt.Println(res)
+	}
+	if err != nil {
+		logger.Fatalf("error running benchmark: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command dpcap streams the packets dpservice captures on an interface as pcap to stdout or
+// a FIFO. It implements the Wireshark extcap interface, so copied or linked into the
+// extcap directory of Wireshark it shows the interfaces of dpservice as capture interfaces.
+//
+//	dpcap --address 127.0.0.1:1337 --sink-node-ip fc00::1 --extcap-interface dpservice-vf-vm1 --capture | tcpdump -r -
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/netip"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/capture"
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+const (
+	version         = "1.0"
+	interfacePrefix = "dpservice-"
+	// listTimeout bounds listing the interfaces of dpservice for Wireshark.
+	listTimeout = 2 * time.Second
+)
+
+var logger = log.New(os.Stderr, "dpcap: ", log.LstdFlags)
+
+func main() {
+	var (
+		listInterfaces bool
+		listDLTs       bool
+		listConfig     bool
+		doCapture      bool
+		iface          string
+		fifo           string
+
+		address       string
+		sinkNodeIP    string
+		udpSrcPort    uint
+		udpDstPort    uint
+		listenAddress string
+	)
+	flag.BoolVar(&listInterfaces, "extcap-interfaces", false, "List the capture interfaces (extcap).")
+	flag.BoolVar(&listDLTs, "extcap-dlts", false, "List the link types of the interface (extcap).")
+	flag.BoolVar(&listConfig, "extcap-config", false, "List the configuration arguments (extcap).")
+	flag.BoolVar(&doCapture, "capture", false, "Capture packets of the interface.")
+	flag.StringVar(&iface, "extcap-interface", "", "Interface to capture, dpservice-vf-<interface ID> or dpservice-pf-<PF index>.")
+	flag.StringVar(&fifo, "fifo", "-", "File or FIFO to write the pcap stream to, - for stdout.")
+	flag.String("extcap-version", "", "Version of Wireshark (extcap), ignored.")
+	flag.String("extcap-capture-filter", "", "Capture filter (extcap), ignored as dpservice captures whole interfaces.")
+
+	flag.StringVar(&address, "address", "127.0.0.1:1337", "Address of dpservice, also unix:// and vsock:// targets are supported.")
+	flag.StringVar(&sinkNodeIP, "sink-node-ip", "", "Underlay IPv6 address of this node dpservice sends captured packets to.")
+	flag.UintVar(&udpSrcPort, "udp-src-port", 3000, "UDP source port of the captured packets.")
+	flag.UintVar(&udpDstPort, "udp-dst-port", 3000, "UDP destination port of the captured packets.")
+	flag.StringVar(&listenAddress, "listen-address", "", "UDP address to receive captured packets on, defaults to all addresses with the destination port.")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch {
+	case listInterfaces:
+		printInterfaces(ctx, address)
+	case listDLTs:
+		fmt.Println("dlt {number=1}{name=EN10MB}{display=Ethernet}")
+	case listConfig:
+		printConfig()
+	case doCapture:
+		captureInterface, err := parseInterface(iface)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		sink, err := netip.ParseAddr(sinkNodeIP)
+		if err != nil {
+			logger.Fatalf("invalid sink node ip: %v", err)
+		}
+		if err := run(ctx, address, fifo, capture.Options{
+			SinkNodeIP:    sink,
+			UdpSrcPort:    uint32(udpSrcPort),
+			UdpDstPort:    uint32(udpDstPort),
+			Interfaces:    []api.CaptureInterface{captureInterface},
+			ListenAddress: listenAddress,
+		}); err != nil {
+			logger.Fatal(err)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func run(ctx context.Context, address, fifo string, opts capture.Options) error {
+	var w io.Writer = os.Stdout
+	if fifo != "-" {
+		f, err := os.OpenFile(fifo, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("error opening fifo: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	c, err := client.Dial(ctx, address)
+	if err != nil {
+		return fmt.Errorf("error connecting to dpservice: %w", err)
+	}
+	defer c.Close()
+
+	return capture.Stream(ctx, c, opts, w)
+}
+
+// parseInterface parses the extcap interface names listed by printInterfaces.
+func parseInterface(name string) (api.CaptureInterface, error) {
+	kind, info, ok := strings.Cut(strings.TrimPrefix(name, interfacePrefix), "-")
+	if !strings.HasPrefix(name, interfacePrefix) || !ok || (kind != "vf" && kind != "pf") || info == "" {
+		return api.CaptureInterface{}, fmt.Errorf("invalid interface %q, expected %svf-<interface ID> or %spf-<PF index>", name, interfacePrefix, interfacePrefix)
+	}
+	return api.CaptureInterface{InterfaceType: kind, InterfaceInfo: info}, nil
+}
+
+// printInterfaces lists the PFs and the interfaces of dpservice. Wireshark lists interfaces
+// without configuration, so the default or passed address is used and only the PFs are
+// listed if dpservice is not reachable.
+func printInterfaces(ctx context.Context, address string) {
+	fmt.Printf("extcap {version=%s}{help=https://github.com/ironcore-dev/dpservice-go}\n", version)
+	for i := 0; i < 2; i++ {
+		fmt.Printf("interface {value=%spf-%d}{display=dpservice PF %d}\n", interfacePrefix, i, i)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+	c, err := client.Dial(ctx, address)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+	ifaces, err := c.ListInterfaces(ctx)
+	if err != nil {
+		return
+	}
+	for _, iface := range ifaces.Items {
+		fmt.Printf("interface {value=%svf-%s}{display=dpservice %s (VNI %d)}\n", interfacePrefix, iface.ID, iface.ID, iface.Spec.VNI)
+	}
+}
+
+func printConfig() {
+	fmt.Println("arg {number=0}{call=--address}{display=dpservice address}{type=string}{default=127.0.0.1:1337}{tooltip=Address of dpservice}")
+	fmt.Println("arg {number=1}{call=--sink-node-ip}{display=Sink node IP}{type=string}{required=true}{tooltip=Underlay IPv6 address of this node}")
+	fmt.Println("arg {number=2}{call=--udp-src-port}{display=UDP source port}{type=integer}{range=1,65535}{default=3000}")
+	fmt.Println("arg {number=3}{call=--udp-dst-port}{display=UDP destination port}{type=integer}{range=1,65535}{default=3000}")
+}
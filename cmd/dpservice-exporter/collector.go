@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "dpservice"
+
+var (
+	upDesc = prometheus.NewDesc(namespace+"_up",
+		"Whether the last gathering of dpservice state succeeded.", nil, nil)
+	infoDesc = prometheus.NewDesc(namespace+"_info",
+		"Version information of dpservice.", []string{"service_version", "service_protocol"}, nil)
+	protocolSkewDesc = prometheus.NewDesc(namespace+"_protocol_skew",
+		"Whether the protocol of dpservice differs from the protocol the exporter was built with.",
+		[]string{"client_protocol", "service_protocol"}, nil)
+	gatherDurationDesc = prometheus.NewDesc(namespace+"_gather_duration_seconds",
+		"Duration of the last gathering of dpservice state.", nil, nil)
+	interfacesDesc = prometheus.NewDesc(namespace+"_interfaces",
+		"Number of interfaces per VNI.", []string{"vni"}, nil)
+	meteringRateDesc = prometheus.NewDesc(namespace+"_interface_metering_rate",
+		"Configured metering rate of an interface.", []string{"interface_id", "type"}, nil)
+	natPortsDesc = prometheus.NewDesc(namespace+"_nat_allocated_ports",
+		"Number of ports allocated per NAT IP.", []string{"nat_ip", "nat_type"}, nil)
+	natUtilizationDesc = prometheus.NewDesc(namespace+"_nat_port_utilization",
+		"Ratio of the NAT port range allocated to local and neighbor NATs per NAT IP.", []string{"nat_ip"}, nil)
+	firewallRulesDesc = prometheus.NewDesc(namespace+"_firewall_rules",
+		"Number of firewall rules per interface.", []string{"interface_id"}, nil)
+	lbTargetsDesc = prometheus.NewDesc(namespace+"_loadbalancer_targets",
+		"Number of targets per load balancer.", []string{"loadbalancer_id"}, nil)
+	routesDesc = prometheus.NewDesc(namespace+"_routes",
+		"Number of routes per VNI.", []string{"vni"}, nil)
+	clientCallsDesc = prometheus.NewDesc(namespace+"_client_calls_total",
+		"Number of calls the exporter made to dpservice per RPC.", []string{"method"}, nil)
+	clientErrorsDesc = prometheus.NewDesc(namespace+"_client_errors_total",
+		"Number of failed calls per RPC, gRPC code and dpservice status. Calls answered with a dpservice error have grpc_code OK.",
+		[]string{"method", "grpc_code", "status"}, nil)
+)
+
+var notFound = errors.Ignore(errors.NOT_FOUND, errors.SNAT_NO_DATA)
+
+// collector periodically gathers dpservice state and exposes the last result.
+type collector struct {
+	client        client.Client
+	loadBalancers []string
+	// natPorts is the number of ports NAT port ranges are allocated from per NAT IP.
+	natPorts int
+
+	mu      sync.Mutex
+	metrics []prometheus.Metric
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		upDesc, infoDesc, protocolSkewDesc, gatherDurationDesc, interfacesDesc, meteringRateDesc, natPortsDesc, natUtilizationDesc, firewallRulesDesc, lbTargetsDesc, routesDesc,
+		clientCallsDesc, clientErrorsDesc,
+	} {
+		ch <- desc
+	}
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	metrics := c.metrics
+	c.mu.Unlock()
+
+	for _, metric := range metrics {
+		ch <- metric
+	}
+	c.collectClientStats(ch)
+}
+
+// collectClientStats exposes the call statistics of the client, so NOT_FOUND noise can be
+// told apart from real failures like ROUTE_INSERT.
+func (c *collector) collectClientStats(ch chan<- prometheus.Metric) {
+	statsClient, ok := c.client.(client.StatsClient)
+	if !ok {
+		return
+	}
+	for method, stats := range statsClient.Stats().Methods {
+		ch <- prometheus.MustNewConstMetric(clientCallsDesc, prometheus.CounterValue, float64(stats.Calls), method)
+		for code, n := range stats.TransportErrors {
+			ch <- prometheus.MustNewConstMetric(clientErrorsDesc, prometheus.CounterValue, float64(n), method, code.String(), "")
+		}
+		for name, n := range stats.StatusErrorsByName() {
+			ch <- prometheus.MustNewConstMetric(clientErrorsDesc, prometheus.CounterValue, float64(n), method, codes.OK.String(), name)
+		}
+	}
+}
+
+// run gathers every interval until ctx is done.
+func (c *collector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.update(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *collector) update(ctx context.Context) {
+	start := time.Now()
+	metrics, err := c.gather(ctx)
+	up := 1.0
+	if err != nil {
+		logger.Printf("error gathering dpservice state: %v", err)
+		metrics, up = nil, 0
+	}
+	metrics = append(metrics,
+		prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up),
+		prometheus.MustNewConstMetric(gatherDurationDesc, prometheus.GaugeValue, time.Since(start).Seconds()),
+	)
+
+	c.mu.Lock()
+	c.metrics = metrics
+	c.mu.Unlock()
+}
+
+func (c *collector) gather(ctx context.Context) ([]prometheus.Metric, error) {
+	var metrics []prometheus.Metric
+	gauge := func(desc *prometheus.Desc, value float64, labels ...string) {
+		metrics = append(metrics, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...))
+	}
+
+	version, err := c.client.GetVersion(ctx, &api.Version{})
+	if err != nil {
+		return nil, err
+	}
+	gauge(infoDesc, 1, version.Spec.ServiceVersion, version.Spec.ServiceProtocol)
+	if skew := client.VersionProtocolSkew(version); skew != nil {
+		gauge(protocolSkewDesc, 1, skew.ClientProtocol, skew.ServiceProtocol)
+	} else {
+		gauge(protocolSkewDesc, 0, version.ClientProtocol, version.Spec.ServiceProtocol)
+	}
+
+	ifaces, err := c.client.ListInterfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	interfacesPerVNI := make(map[uint32]int)
+	natIPs := make(map[netip.Addr]struct{})
+	for _, iface := range ifaces.Items {
+		interfacesPerVNI[iface.Spec.VNI]++
+		if m := iface.Spec.Metering; m != nil {
+			gauge(meteringRateDesc, float64(m.TotalRate), iface.ID, "total")
+			gauge(meteringRateDesc, float64(m.PublicRate), iface.ID, "public")
+		}
+
+		rules, err := c.client.ListFirewallRules(ctx, iface.ID, notFound)
+		if err != nil {
+			return nil, err
+		}
+		gauge(firewallRulesDesc, float64(len(rules.Items)), iface.ID)
+
+		nat, err := c.client.GetNat(ctx, iface.ID, notFound)
+		if err != nil {
+			return nil, err
+		}
+		if nat.Status.Code == 0 && nat.Spec.NatIP != nil {
+			natIPs[*nat.Spec.NatIP] = struct{}{}
+		}
+	}
+
+	vnis := make([]uint32, 0, len(interfacesPerVNI))
+	for vni, count := range interfacesPerVNI {
+		gauge(interfacesDesc, float64(count), strconv.FormatUint(uint64(vni), 10))
+		vnis = append(vnis, vni)
+	}
+	sort.Slice(vnis, func(i, j int) bool { return vnis[i] < vnis[j] })
+
+	for natIP := range natIPs {
+		natIP := natIP
+		allocated := 0
+		for _, natType := range []api.NatType{api.NatTypeLocal, api.NatTypeNeighbor} {
+			nats, err := c.client.ListNats(ctx, &natIP, natType.String())
+			if err != nil {
+				return nil, err
+			}
+			ports := 0
+			for _, nat := range nats.Items {
+				ports += int(nat.Spec.MaxPort) - int(nat.Spec.MinPort)
+			}
+			gauge(natPortsDesc, float64(ports), natIP.String(), natType.String())
+			allocated += ports
+		}
+		if c.natPorts > 0 {
+			gauge(natUtilizationDesc, float64(allocated)/float64(c.natPorts), natIP.String())
+		}
+	}
+
+	for _, id := range c.loadBalancers {
+		targets, err := c.client.ListLoadBalancerTargets(ctx, id, notFound)
+		if err != nil {
+			return nil, err
+		}
+		gauge(lbTargetsDesc, float64(len(targets.Items)), id)
+	}
+
+	for _, vni := range vnis {
+		routes, err := c.client.ListRoutes(ctx, vni)
+		if err != nil {
+			return nil, err
+		}
+		gauge(routesDesc, float64(len(routes.Items)), strconv.FormatUint(uint64(vni), 10))
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+// findMetric returns the metric of desc with the given label name and value pairs, nil if
+// there is none.
+func findMetric(metrics []prometheus.Metric, desc *prometheus.Desc, labels ...string) *dto.Metric {
+	for _, metric := range metrics {
+		if metric.Desc() != desc {
+			continue
+		}
+		m := &dto.Metric{}
+		Expect(metric.Write(m)).To(Succeed())
+		values := map[string]string{}
+		for _, label := range m.GetLabel() {
+			values[label.GetName()] = label.GetValue()
+		}
+		matches := true
+		for i := 0; i+1 < len(labels); i += 2 {
+			matches = matches && values[labels[i]] == labels[i+1]
+		}
+		if matches {
+			return m
+		}
+	}
+	return nil
+}
+
+// metricValue returns the value of the gauge or counter of desc with the given labels.
+func metricValue(metrics []prometheus.Metric, desc *prometheus.Desc, labels ...string) float64 {
+	m := findMetric(metrics, desc, labels...)
+	ExpectWithOffset(1, m).NotTo(BeNil(), "no metric %s %v", desc, labels)
+	if m.GetCounter() != nil {
+		return m.GetCounter().GetValue()
+	}
+	return m.GetGauge().GetValue()
+}
+
+// rewritingProtocol makes dpservice report the given protocol in GetVersion.
+func rewritingProtocol(protocol string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if res, ok := reply.(*dpdkproto.GetVersionResponse); ok && err == nil {
+			res.ServiceProtocol = protocol
+		}
+		return err
+	}
+}
+
+var _ = Describe("collector", func() {
+	ctx := context.TODO()
+	natIP := netip.MustParseAddr("20.0.0.1")
+
+	dial := func(opts ...client.DialerOption) *collector {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		c, err := client.Dial(ctx, sim.Addr(), opts...)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+		return &collector{client: c, natPorts: 4000}
+	}
+
+	gather := func(col *collector) []prometheus.Metric {
+		col.update(ctx)
+		ch := make(chan prometheus.Metric, 100)
+		col.Collect(ch)
+		close(ch)
+		var metrics []prometheus.Metric
+		for metric := range ch {
+			metrics = append(metrics, metric)
+		}
+		Expect(metricValue(metrics, upDesc)).To(BeEquivalentTo(1))
+		return metrics
+	}
+
+	createInterface := func(c client.Client, id string, ipv4 string) {
+		ip, ipv6 := netip.MustParseAddr(ipv4), netip.MustParseAddr("2001:db8::"+id[len(id)-1:])
+		_, err := c.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: id},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ip, IPv6: &ipv6, Device: "net_tap" + id[len(id)-1:]},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should report the NAT port utilization", func() {
+		col := dial()
+		createInterface(col.client, "vm1", "10.0.0.1")
+		_, err := col.client.CreateNat(ctx, &api.Nat{
+			NatMeta: api.NatMeta{InterfaceID: "vm1"},
+			Spec:    api.NatSpec{NatIP: &natIP, MinPort: 1000, MaxPort: 2000},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		underlayRoute := netip.MustParseAddr("fc00::1")
+		_, err = col.client.CreateNeighborNat(ctx, &api.NeighborNat{
+			NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+			Spec:            api.NeighborNatSpec{Vni: 200, MinPort: 2000, MaxPort: 2500, UnderlayRoute: &underlayRoute},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		metrics := gather(col)
+		Expect(metricValue(metrics, natPortsDesc, "nat_ip", "20.0.0.1", "nat_type", api.NatTypeLocal.String())).To(BeEquivalentTo(1000))
+		Expect(metricValue(metrics, natPortsDesc, "nat_ip", "20.0.0.1", "nat_type", api.NatTypeNeighbor.String())).To(BeEquivalentTo(500))
+		Expect(metricValue(metrics, natUtilizationDesc, "nat_ip", "20.0.0.1")).To(BeEquivalentTo(0.375))
+		Expect(metricValue(metrics, interfacesDesc, "vni", "100")).To(BeEquivalentTo(1))
+	})
+
+	It("should count client errors per dpservice status", func() {
+		col := dial()
+		createInterface(col.client, "vm1", "10.0.0.1")
+		createInterface(col.client, "vm2", "10.0.0.2")
+
+		metrics := gather(col)
+		Expect(metricValue(metrics, clientCallsDesc, "method", "GetNat")).To(BeEquivalentTo(2))
+		Expect(metricValue(metrics, clientErrorsDesc, "method", "GetNat", "grpc_code", "OK", "status", "SNAT_NO_DATA")).To(BeEquivalentTo(2))
+		Expect(findMetric(metrics, clientErrorsDesc, "method", "ListInterfaces")).To(BeNil())
+	})
+
+	It("should report the protocol skew", func() {
+		metrics := gather(dial())
+		Expect(metricValue(metrics, protocolSkewDesc)).To(BeEquivalentTo(0))
+
+		metrics = gather(dial(client.WithUnaryInterceptors(rewritingProtocol("v0.0.1"))))
+		Expect(metricValue(metrics, protocolSkewDesc, "service_protocol", "v0.0.1")).To(BeEquivalentTo(1))
+	})
+})
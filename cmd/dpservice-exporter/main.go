@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command dpservice-exporter periodically gathers the state of a dpservice instance
+// and exposes it as Prometheus metrics.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/natpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var logger = log.New(os.Stderr, "dpservice-exporter: ", log.LstdFlags)
+
+func main() {
+	var (
+		address       string
+		listenAddress string
+		interval      time.Duration
+		loadBalancers string
+		natMinPort    uint
+		natMaxPort    uint
+	)
+	flag.StringVar(&address, "address", "127.0.0.1:1337", "Address of dpservice, also unix:// and vsock:// targets are supported.")
+	flag.StringVar(&listenAddress, "listen-address", ":9064", "Address to expose metrics on.")
+	flag.DurationVar(&interval, "interval", 30*time.Second, "Interval between gatherings of dpservice state.")
+	flag.StringVar(&loadBalancers, "loadbalancers", "", "Comma separated IDs of load balancers to report target counts for.")
+	flag.UintVar(&natMinPort, "nat-min-port", natpool.DefaultMinPort, "First port NAT port ranges are allocated from, for the NAT port utilization.")
+	flag.UintVar(&natMaxPort, "nat-max-port", natpool.DefaultMaxPort, "End of the ports NAT port ranges are allocated from, exclusive.")
+	flag.Parse()
+	if natMinPort >= natMaxPort {
+		logger.Fatalf("invalid NAT port range %d-%d", natMinPort, natMaxPort)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := client.Dial(ctx, address, client.WithDefaultTimeout(interval))
+	if err != nil {
+		logger.Fatalf("error connecting to dpservice: %v", err)
+	}
+	defer c.Close()
+
+	col := &collector{client: c, natPorts: int(natMaxPort - natMinPort)}
+	if loadBalancers != "" {
+		col.loadBalancers = strings.Split(loadBalancers, ",")
+	}
+	go col.run(ctx, interval)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(col, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+	server := &http.Server{Addr: listenAddress, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Printf("serving metrics on %s", listenAddress)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Fatalf("error serving metrics: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemError is the failure of a batch operation on a single resource.
+type ItemError struct {
+	ID  string
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.ID, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Aggregate collects the per-item failures of a batch operation, keyed by the ID of
+// the affected resource. It supports errors.Is and errors.As on the collected errors.
+type Aggregate struct {
+	items []*ItemError
+}
+
+// Add records err for the resource with the given ID. Nil errors are ignored.
+func (a *Aggregate) Add(id string, err error) {
+	if err == nil {
+		return
+	}
+	a.items = append(a.items, &ItemError{ID: id, Err: err})
+}
+
+// Len returns the number of collected errors.
+func (a *Aggregate) Len() int {
+	return len(a.items)
+}
+
+// Items returns the collected errors in the order they were added.
+func (a *Aggregate) Items() []*ItemError {
+	return a.items
+}
+
+// IDs returns the IDs of all failed resources in the order they were added.
+func (a *Aggregate) IDs() []string {
+	ids := make([]string, len(a.items))
+	for i, item := range a.items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// Get returns the first error recorded for the resource with the given ID, or nil.
+func (a *Aggregate) Get(id string) error {
+	for _, item := range a.items {
+		if item.ID == id {
+			return item.Err
+		}
+	}
+	return nil
+}
+
+// ErrorOrNil returns the aggregate if it holds any error and nil otherwise.
+func (a *Aggregate) ErrorOrNil() error {
+	if a == nil || len(a.items) == 0 {
+		return nil
+	}
+	return a
+}
+
+func (a *Aggregate) Error() string {
+	if len(a.items) == 1 {
+		return a.items[0].Error()
+	}
+	msgs := make([]string, len(a.items))
+	for i, item := range a.items {
+		msgs[i] = item.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(a.items), strings.Join(msgs, "; "))
+}
+
+func (a *Aggregate) Unwrap() []error {
+	errs := make([]error, len(a.items))
+	for i, item := range a.items {
+		errs[i] = item
+	}
+	return errs
+}
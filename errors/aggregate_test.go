@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregate", func() {
+	It("should be nil without errors", func() {
+		agg := &Aggregate{}
+		agg.Add("route1", nil)
+		Expect(agg.ErrorOrNil()).To(BeNil())
+	})
+
+	It("should collect errors by resource ID", func() {
+		agg := &Aggregate{}
+		agg.Add("route1", NewStatusError(ROUTE_EXISTS, "exists"))
+		agg.Add("route2", errors.New("boom"))
+
+		err := agg.ErrorOrNil()
+		Expect(err).To(HaveOccurred())
+		Expect(agg.Len()).To(Equal(2))
+		Expect(agg.IDs()).To(Equal([]string{"route1", "route2"}))
+		Expect(agg.Get("route2")).To(MatchError("boom"))
+		Expect(agg.Get("route3")).To(BeNil())
+		Expect(err.Error()).To(Equal("2 errors occurred: route1: [error code 301] exists; route2: boom"))
+	})
+
+	It("should support errors.As on collected errors", func() {
+		agg := &Aggregate{}
+		agg.Add("route1", NewStatusError(ROUTE_EXISTS, "exists"))
+		Expect(IsStatusErrorCode(agg, ROUTE_EXISTS)).To(BeTrue())
+	})
+})
@@ -6,6 +6,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
 )
@@ -60,6 +61,55 @@ const (
 	StatusErrorString = "rpc error"
 )
 
+var statusNames = map[uint32]string{
+	BAD_REQUEST:     "BAD_REQUEST",
+	NOT_FOUND:       "NOT_FOUND",
+	ALREADY_EXISTS:  "ALREADY_EXISTS",
+	WRONG_TYPE:      "WRONG_TYPE",
+	BAD_IPVER:       "BAD_IPVER",
+	NO_VM:           "NO_VM",
+	NO_VNI:          "NO_VNI",
+	ITERATOR:        "ITERATOR",
+	OUT_OF_MEMORY:   "OUT_OF_MEMORY",
+	LIMIT_REACHED:   "LIMIT_REACHED",
+	ALREADY_ACTIVE:  "ALREADY_ACTIVE",
+	NOT_ACTIVE:      "NOT_ACTIVE",
+	ROLLBACK:        "ROLLBACK",
+	RTE_RULE_ADD:    "RTE_RULE_ADD",
+	RTE_RULE_DEL:    "RTE_RULE_DEL",
+	ROUTE_EXISTS:    "ROUTE_EXISTS",
+	ROUTE_NOT_FOUND: "ROUTE_NOT_FOUND",
+	ROUTE_INSERT:    "ROUTE_INSERT",
+	ROUTE_BAD_PORT:  "ROUTE_BAD_PORT",
+	ROUTE_RESET:     "ROUTE_RESET",
+	DNAT_NO_DATA:    "DNAT_NO_DATA",
+	DNAT_CREATE:     "DNAT_CREATE",
+	DNAT_EXISTS:     "DNAT_EXISTS",
+	SNAT_NO_DATA:    "SNAT_NO_DATA",
+	SNAT_CREATE:     "SNAT_CREATE",
+	SNAT_EXISTS:     "SNAT_EXISTS",
+	VNI_INIT4:       "VNI_INIT4",
+	VNI_INIT6:       "VNI_INIT6",
+	VNI_FREE4:       "VNI_FREE4",
+	VNI_FREE6:       "VNI_FREE6",
+	PORT_START:      "PORT_START",
+	PORT_STOP:       "PORT_STOP",
+	VNF_INSERT:      "VNF_INSERT",
+	VM_HANDLE:       "VM_HANDLE",
+	NO_BACKIP:       "NO_BACKIP",
+	NO_LB:           "NO_LB",
+	NO_DROP_SUPPORT: "NO_DROP_SUPPORT",
+}
+
+// StatusName returns the name of a dpservice status code, e.g. "NOT_FOUND", or the code as
+// decimal string for unknown codes.
+func StatusName(code uint32) string {
+	if name, ok := statusNames[code]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(code), 10)
+}
+
 type StatusError struct {
 	errorCode uint32
 	message   string
@@ -102,6 +152,12 @@ func GetError(status *dpdkproto.Status, ignoredErrors [][]uint32) error {
 	return NewStatusError(status.Code, status.Message)
 }
 
+// IsStatusError reports whether err is a dpservice status error, rather than a transport failure.
+func IsStatusError(err error) bool {
+	statusError := &StatusError{}
+	return errors.As(err, &statusError)
+}
+
 func IsStatusErrorCode(err error, errorCodes ...uint32) bool {
 	statusError := &StatusError{}
 	if !errors.As(err, &statusError) {
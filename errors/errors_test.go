@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatusName", func() {
+	It("should name known status codes", func() {
+		Expect(StatusName(NOT_FOUND)).To(Equal("NOT_FOUND"))
+		Expect(StatusName(ROUTE_INSERT)).To(Equal("ROUTE_INSERT"))
+	})
+
+	It("should fall back to the code for unknown status codes", func() {
+		Expect(StatusName(999)).To(Equal("999"))
+	})
+})
+
+var _ = Describe("IsStatusError", func() {
+	It("should tell status errors from other errors", func() {
+		Expect(IsStatusError(fmt.Errorf("wrapped: %w", NewStatusError(NOT_FOUND, "NOT_FOUND")))).To(BeTrue())
+		Expect(IsStatusError(fmt.Errorf("connection refused"))).To(BeFalse())
+		Expect(IsStatusError(nil)).To(BeFalse())
+	})
+})
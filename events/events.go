@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events reports notable dataplane outcomes as Kubernetes Events, so operators see
+// them in kubectl describe. It does not depend on client-go: events are emitted through a
+// Recorder, which a record.EventRecorder bound to the object they concern, e.g. the Node,
+// satisfies with a RecorderFunc:
+//
+//	rec := events.RecorderFunc(func(eventtype, reason, messageFmt string, args ...interface{}) {
+//		recorder.Eventf(node, eventtype, reason, messageFmt, args...)
+//	})
+//	c, err := client.Dial(ctx, target,
+//		client.WithHooks(events.Hook(rec)),
+//		client.WithRestartTracker(client.NewRestartTracker(events.OnRestart(rec))),
+//	)
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/natpool"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// Event types, matching the ones of the Kubernetes core API.
+const (
+	EventTypeNormal  = "Normal"
+	EventTypeWarning = "Warning"
+)
+
+// Reasons of the recorded events.
+const (
+	ReasonUnderlayRouteAllocated = "UnderlayRouteAllocated"
+	ReasonNatPortsExhausted      = "NatPortsExhausted"
+	ReasonDpserviceRestarted     = "DpserviceRestarted"
+)
+
+// Recorder records an event. Its signature matches record.EventRecorder.Eventf of client-go
+// without the object.
+type Recorder interface {
+	Eventf(eventtype, reason, messageFmt string, args ...interface{})
+}
+
+// RecorderFunc implements Recorder with a function.
+type RecorderFunc func(eventtype, reason, messageFmt string, args ...interface{})
+
+func (f RecorderFunc) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	f(eventtype, reason, messageFmt, args...)
+}
+
+// Hook returns a client hook recording an event whenever dpservice allocates the underlay
+// route of a new interface.
+func Hook(r Recorder) client.Hook {
+	return client.HookFuncs{
+		After: func(_ context.Context, _ string, req, resp interface{}, err error) {
+			if err != nil {
+				return
+			}
+			res, ok := resp.(*dpdkproto.CreateInterfaceResponse)
+			if !ok || res.GetStatus().GetCode() != 0 {
+				return
+			}
+			underlayRoute, err := api.ParseResponseAddr("underlay route", res.GetUnderlayRoute())
+			if err != nil {
+				return
+			}
+			interfaceID := string(req.(*dpdkproto.CreateInterfaceRequest).GetInterfaceId())
+			r.Eventf(EventTypeNormal, ReasonUnderlayRouteAllocated, "Interface %s was allocated underlay route %s", interfaceID, underlayRoute)
+		},
+	}
+}
+
+// OnRestart returns a restart callback recording an event whenever a RestartTracker detects a
+// dpservice restart, which loses all objects configured in dpservice.
+func OnRestart(r Recorder) client.RestartCallback {
+	return func(oldUUID, newUUID string) {
+		r.Eventf(EventTypeWarning, ReasonDpserviceRestarted, "dpservice restarted, initialization UUID changed from %s to %s", oldUUID, newUUID)
+	}
+}
+
+// RecordNatError records an event if err reports that no NAT port range was left for the
+// interface, e.g. when returned by natpool.Pool.Assign. Other errors are not recorded.
+func RecordNatError(r Recorder, interfaceID string, err error) {
+	if errors.Is(err, natpool.ErrExhausted) {
+		r.Eventf(EventTypeWarning, ReasonNatPortsExhausted, "No NAT port range left for interface %s: %v", interfaceID, err)
+	}
+}
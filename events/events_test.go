@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/natpool"
+)
+
+type event struct {
+	eventtype, reason, message string
+}
+
+type fakeRecorder struct {
+	events []event
+}
+
+func (r *fakeRecorder) Eventf(eventtype, reason, messageFmt string, args ...interface{}) {
+	r.events = append(r.events, event{eventtype, reason, fmt.Sprintf(messageFmt, args...)})
+}
+
+var _ = Describe("events", func() {
+	ctx := context.TODO()
+
+	It("should record allocated underlay routes and restarts", func() {
+		rec := &fakeRecorder{}
+		c, err := client.Dial(ctx, sim.Addr(),
+			client.WithHooks(Hook(rec)),
+			client.WithRestartTracker(client.NewRestartTracker(OnRestart(rec))),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		iface, err := c.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap2"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rec.events).To(Equal([]event{{
+			EventTypeNormal, ReasonUnderlayRouteAllocated,
+			fmt.Sprintf("Interface vm1 was allocated underlay route %s", iface.Spec.UnderlayRoute),
+		}}))
+
+		rec.events = nil
+		sim.Restart()
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rec.events).To(HaveLen(1))
+		Expect(rec.events[0].eventtype).To(Equal(EventTypeWarning))
+		Expect(rec.events[0].reason).To(Equal(ReasonDpserviceRestarted))
+	})
+
+	It("should record exhausted NAT pools only", func() {
+		rec := &fakeRecorder{}
+		RecordNatError(rec, "vm1", fmt.Errorf("error assigning nat: %w", natpool.ErrExhausted))
+		RecordNatError(rec, "vm2", fmt.Errorf("some other error"))
+		RecordNatError(rec, "vm3", nil)
+		Expect(rec.events).To(Equal([]event{{
+			EventTypeWarning, ReasonNatPortsExhausted,
+			"No NAT port range left for interface vm1: error assigning nat: nat pool exhausted",
+		}}))
+	})
+})
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fwjanitor deletes firewall rules once their ExpiresAt has passed, e.g. temporary
+// break-glass rules. dpservice has no rule expiry, so the janitor records the expiry of the
+// rules it creates in a Store and deletes expired rules periodically.
+//
+//	j := fwjanitor.New(c, fwjanitor.NewMemoryStore())
+//	go j.Run(ctx, time.Minute)
+//	expiresAt := time.Now().Add(time.Hour)
+//	rule.Spec.ExpiresAt = &expiresAt
+//	_, err := j.CreateFirewallRule(ctx, rule)
+package fwjanitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// Expiry is the expiry of a firewall rule recorded by the janitor.
+type Expiry struct {
+	InterfaceID string    `json:"interface_id"`
+	RuleID      string    `json:"rule_id"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Store persists the expiries of firewall rules, so they survive restarts of the janitor.
+type Store interface {
+	// Put records expiry, replacing a previous expiry of the same rule.
+	Put(ctx context.Context, expiry Expiry) error
+	// Delete forgets the expiry of a rule. Deleting an unknown rule is not an error.
+	Delete(ctx context.Context, interfaceID, ruleID string) error
+	// List returns all recorded expiries.
+	List(ctx context.Context) ([]Expiry, error)
+}
+
+// Janitor creates firewall rules with an expiry and deletes them once they expired.
+type Janitor struct {
+	client client.Client
+	store  Store
+
+	now func() time.Time
+}
+
+// New creates a janitor managing rules with c and recording their expiries in store.
+func New(c client.Client, store Store) *Janitor {
+	return &Janitor{client: c, store: store, now: time.Now}
+}
+
+// CreateFirewallRule creates rule and records its expiry if ExpiresAt is set. The expiry is
+// recorded before the rule is created, so a rule never outlives a failed call.
+func (j *Janitor) CreateFirewallRule(ctx context.Context, rule *api.FirewallRule) (*api.FirewallRule, error) {
+	if rule.Spec.ExpiresAt != nil {
+		if err := j.store.Put(ctx, Expiry{
+			InterfaceID: rule.InterfaceID,
+			RuleID:      rule.Spec.RuleID,
+			ExpiresAt:   *rule.Spec.ExpiresAt,
+		}); err != nil {
+			return nil, fmt.Errorf("error recording expiry of firewall rule %s: %w", rule.GetID(), err)
+		}
+	}
+	res, err := j.client.CreateFirewallRule(ctx, rule)
+	if err != nil {
+		return res, err
+	}
+	res.Spec.ExpiresAt = rule.Spec.ExpiresAt
+	return res, nil
+}
+
+// DeleteFirewallRule deletes the rule and forgets its expiry.
+func (j *Janitor) DeleteFirewallRule(ctx context.Context, interfaceID, ruleID string) error {
+	if _, err := j.client.DeleteFirewallRule(ctx, interfaceID, ruleID, errors.Ignore(errors.NOT_FOUND, errors.NO_VM)); err != nil {
+		return err
+	}
+	return j.store.Delete(ctx, interfaceID, ruleID)
+}
+
+// Sweep deletes all expired rules and returns the expiries of the deleted rules. Rules or
+// interfaces deleted by others are forgotten as well. Failed deletions are returned as an
+// errors.Aggregate keyed by "<interface ID>/<rule ID>" and retried by the next sweep.
+func (j *Janitor) Sweep(ctx context.Context) ([]Expiry, error) {
+	expiries, err := j.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing firewall rule expiries: %w", err)
+	}
+	now := j.now()
+	var deleted []Expiry
+	agg := &errors.Aggregate{}
+	for _, expiry := range expiries {
+		if now.Before(expiry.ExpiresAt) {
+			continue
+		}
+		if err := j.DeleteFirewallRule(ctx, expiry.InterfaceID, expiry.RuleID); err != nil {
+			agg.Add(expiry.InterfaceID+"/"+expiry.RuleID, err)
+			continue
+		}
+		deleted = append(deleted, expiry)
+	}
+	return deleted, agg.ErrorOrNil()
+}
+
+// Run sweeps every interval until ctx is done. Errors are retried by the next sweep.
+func (j *Janitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = j.Sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MemoryStore is a Store keeping the expiries in memory, for tests or rules that need not
+// survive a restart.
+type MemoryStore struct {
+	mu       sync.Mutex
+	expiries map[[2]string]Expiry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{expiries: make(map[[2]string]Expiry)}
+}
+
+func (s *MemoryStore) Put(_ context.Context, expiry Expiry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expiries[[2]string{expiry.InterfaceID, expiry.RuleID}] = expiry
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, interfaceID, ruleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.expiries, [2]string{interfaceID, ruleID})
+	return nil
+}
+
+func (s *MemoryStore) List(context.Context) ([]Expiry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiries := make([]Expiry, 0, len(s.expiries))
+	for _, expiry := range s.expiries {
+		expiries = append(expiries, expiry)
+	}
+	return expiries, nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package fwjanitor
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("firewall janitor", func() {
+	ctx := context.TODO()
+	now := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+	prefix := netip.MustParsePrefix("0.0.0.0/0")
+
+	var j *Janitor
+
+	rule := func(id string, expiresAt *time.Time) *api.FirewallRule {
+		return &api.FirewallRule{
+			FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"},
+			Spec: api.FirewallRuleSpec{
+				RuleID:            id,
+				TrafficDirection:  "ingress",
+				FirewallAction:    "accept",
+				SourcePrefix:      &prefix,
+				DestinationPrefix: &prefix,
+				ExpiresAt:         expiresAt,
+			},
+		}
+	}
+
+	ruleIDs := func() []string {
+		rules, err := simClient.ListFirewallRules(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		var ids []string
+		for _, rule := range rules.Items {
+			ids = append(ids, rule.Spec.RuleID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		_, err := simClient.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		j = New(simClient, NewMemoryStore())
+		j.now = func() time.Time { return now }
+	})
+
+	It("should delete rules once they expired", func() {
+		soon, later := now.Add(time.Minute), now.Add(time.Hour)
+		res, err := j.CreateFirewallRule(ctx, rule("soon", &soon))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Spec.ExpiresAt).To(Equal(&soon))
+		_, err = j.CreateFirewallRule(ctx, rule("later", &later))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = j.CreateFirewallRule(ctx, rule("permanent", nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(j.Sweep(ctx)).To(BeEmpty())
+		Expect(ruleIDs()).To(ConsistOf("soon", "later", "permanent"))
+
+		now = now.Add(30 * time.Minute)
+		Expect(j.Sweep(ctx)).To(ConsistOf(Expiry{InterfaceID: "vm1", RuleID: "soon", ExpiresAt: soon}))
+		Expect(ruleIDs()).To(ConsistOf("later", "permanent"))
+		Expect(j.Sweep(ctx)).To(BeEmpty())
+	})
+
+	It("should forget rules deleted by others", func() {
+		expiresAt := now.Add(-time.Minute)
+		_, err := j.CreateFirewallRule(ctx, rule("gone", &expiresAt))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = simClient.DeleteInterface(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(j.Sweep(ctx)).To(HaveLen(1))
+		Expect(j.store.List(ctx)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package fwjanitor
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var (
+	sim       *simulator.Simulator
+	simClient *client.ConnectedClient
+)
+
+func TestFWJanitor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Firewall Janitor Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	sim, err = simulator.Start("")
+	Expect(err).NotTo(HaveOccurred())
+
+	simClient, err = client.Dial(context.TODO(), sim.Addr())
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = BeforeEach(func() {
+	sim.Restart()
+	_, err := client.EnsureInitialized(context.TODO(), simClient)
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	if simClient != nil {
+		Expect(simClient.Close()).To(Succeed())
+	}
+	if sim != nil {
+		sim.Stop()
+	}
+})
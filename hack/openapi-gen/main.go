@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// openapi-gen generates the OpenAPI v3 schemas of the api types from their JSON encoding.
+// It is run by go generate in the api package.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"math"
+	"net/netip"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// schema is the subset of the OpenAPI v3 schema object used for the api types.
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *int64             `json:"minimum,omitempty"`
+	Maximum              *int64             `json:"maximum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+var (
+	addrType   = reflect.TypeOf(netip.Addr{})
+	prefixType = reflect.TypeOf(netip.Prefix{})
+	timeType   = reflect.TypeOf(time.Time{})
+	protoType  = reflect.TypeOf((*protoreflect.ProtoMessage)(nil)).Elem()
+)
+
+type generator struct {
+	schemas map[string]*schema
+}
+
+func main() {
+	output := flag.String("o", "zz_generated.openapi.go", "file to write the schemas to")
+	flag.Parse()
+
+	g := &generator{schemas: make(map[string]*schema)}
+	for _, kind := range api.DefaultScheme.Kinds() {
+		obj, err := api.DefaultScheme.New(kind)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.ref(reflect.TypeOf(obj))
+	}
+	for _, obj := range []interface{}{
+		api.InterfaceList{}, api.PrefixList{}, api.RouteList{}, api.NatList{},
+		api.LoadBalancerTargetList{}, api.FirewallRuleList{}, api.Machine{},
+	} {
+		g.ref(reflect.TypeOf(obj))
+	}
+
+	src, err := g.source()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (g *generator) source() ([]byte, error) {
+	names := make([]string, 0, len(g.schemas))
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString(`// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by openapi-gen. DO NOT EDIT.
+
+package api
+
+var openAPISchemas = map[string]string{
+`)
+	for _, name := range names {
+		data, err := json.Marshal(g.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "\t%q: `%s`,\n", name, data)
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// ref returns the schema of t, registering named structs as components referenced by it.
+func (g *generator) ref(t reflect.Type) *schema {
+	for t.Kind() == reflect.Pointer {
+		if t.Implements(protoType) {
+			return g.protoRef(reflect.Zero(t).Interface().(protoreflect.ProtoMessage).ProtoReflect().Descriptor())
+		}
+		t = t.Elem()
+	}
+	switch t {
+	case addrType:
+		return &schema{Type: "string", Format: "ip"}
+	case prefixType:
+		return &schema{Type: "string", Format: "cidr"}
+	case timeType:
+		return &schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := g.schemas[t.Name()]; !ok {
+			s := &schema{Type: "object", Properties: make(map[string]*schema)}
+			g.schemas[t.Name()] = s
+			g.addFields(s, t)
+		}
+		return &schema{Ref: "#/components/schemas/" + t.Name()}
+	case reflect.Slice:
+		return &schema{Type: "array", Items: g.ref(t.Elem())}
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int32:
+		return &schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8:
+		return &schema{Type: "integer", Format: "int32", Minimum: int64Ptr(0), Maximum: int64Ptr(255)}
+	case reflect.Uint16:
+		return &schema{Type: "integer", Format: "int32", Minimum: int64Ptr(0), Maximum: int64Ptr(65535)}
+	case reflect.Uint32:
+		return &schema{Type: "integer", Format: "int64", Minimum: int64Ptr(0), Maximum: int64Ptr(math.MaxUint32)}
+	case reflect.Uint64:
+		return &schema{Type: "integer", Format: "int64", Minimum: int64Ptr(0)}
+	default:
+		log.Fatalf("unsupported type %s", t)
+		return nil
+	}
+}
+
+// addFields adds the properties encoding/json encodes the fields of t with to s.
+func (g *generator) addFields(s *schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			g.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.ref(field.Type)
+	}
+}
+
+// protoRef returns the schema of the protojson encoding of messages of desc with proto names.
+func (g *generator) protoRef(desc protoreflect.MessageDescriptor) *schema {
+	name := string(desc.Name())
+	if _, ok := g.schemas[name]; !ok {
+		s := &schema{Type: "object", Properties: make(map[string]*schema), AdditionalProperties: boolPtr(false)}
+		g.schemas[name] = s
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			s.Properties[string(fields.Get(i).Name())] = g.protoField(fields.Get(i))
+		}
+	}
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *generator) protoField(field protoreflect.FieldDescriptor) *schema {
+	var s *schema
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		s = g.protoRef(field.Message())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		s = &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s = &schema{Type: "integer", Format: "int64", Minimum: int64Ptr(0), Maximum: int64Ptr(math.MaxUint32)}
+	case protoreflect.BoolKind:
+		s = &schema{Type: "boolean"}
+	case protoreflect.StringKind, protoreflect.EnumKind:
+		s = &schema{Type: "string"}
+	default:
+		log.Fatalf("unsupported proto field %s", field.FullName())
+	}
+	if field.IsList() {
+		return &schema{Type: "array", Items: s}
+	}
+	return s
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package httpprobe serves Kubernetes liveness and readiness probes of node agents using
+// dpservice.
+//
+//	mux.Handle("/", httpprobe.Handler(c))
+//
+// /livez only fails once the client is closed, since restarting the agent does not help
+// while dpservice is unavailable. /readyz reports the result of client.Ready.
+package httpprobe
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+// stateSource is implemented by clients knowing the state of their connection, e.g.
+// client.ConnectedClient.
+type stateSource interface {
+	State() connectivity.State
+}
+
+// Handler returns an HTTP handler serving /livez and /readyz for c. Failing probes are
+// answered with 503 Service Unavailable, the body listing the result of every check.
+func Handler(c client.Client) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		check := client.ReadinessCheck{Name: "client", Ready: true}
+		if source, ok := c.(stateSource); ok && source.State() == connectivity.Shutdown {
+			check = client.ReadinessCheck{Name: "client", Reason: "client is closed"}
+		}
+		writeChecks(w, "livez", check.Ready, []client.ReadinessCheck{check})
+	}))
+	mux.Handle("/readyz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readiness := client.Ready(r.Context(), c)
+		writeChecks(w, "readyz", readiness.Ready, readiness.Checks)
+	}))
+	return mux
+}
+
+func writeChecks(w http.ResponseWriter, probe string, ready bool, checks []client.ReadinessCheck) {
+	var out strings.Builder
+	for _, check := range checks {
+		if check.Ready {
+			fmt.Fprintf(&out, "[+]%s ok\n", check.Name)
+		} else {
+			fmt.Fprintf(&out, "[-]%s failed: %s\n", check.Name, check.Reason)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if ready {
+		fmt.Fprintf(&out, "%s check passed\n", probe)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(&out, "%s check failed\n", probe)
+	}
+	_, _ = w.Write([]byte(out.String()))
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpprobe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+var _ = Describe("probe handler", func() {
+	var (
+		c       *client.ConnectedClient
+		handler http.Handler
+	)
+
+	probe := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	BeforeEach(func() {
+		var err error
+		c, err = client.Dial(context.TODO(), sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		handler = Handler(c)
+	})
+
+	It("should be ready once dpservice is initialized", func() {
+		rec := probe("/readyz")
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Body.String()).To(ContainSubstring("[-]initialized failed"))
+		Expect(rec.Body.String()).To(ContainSubstring("[+]protocol ok"))
+
+		_, err := client.EnsureInitialized(context.TODO(), c)
+		Expect(err).NotTo(HaveOccurred())
+		rec = probe("/readyz")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("[+]connectivity ok\n[+]initialized ok\n[+]protocol ok\nreadyz check passed\n"))
+	})
+
+	It("should not be ready if the protocols differ", func() {
+		skewed, err := client.Dial(context.TODO(), sim.Addr(), client.WithUnaryInterceptors(
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				err := invoker(ctx, method, req, reply, cc, opts...)
+				if res, ok := reply.(*dpdkproto.GetVersionResponse); ok && err == nil {
+					res.ServiceProtocol = "v0.0.1"
+				}
+				return err
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(skewed.Close)
+		_, err = client.EnsureInitialized(context.TODO(), skewed)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = Handler(skewed)
+		rec := probe("/readyz")
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Body.String()).To(ContainSubstring("[-]protocol failed: client protocol"))
+		Expect(rec.Body.String()).To(ContainSubstring("differs from dpservice protocol v0.0.1"))
+	})
+
+	It("should be live until the client is closed", func() {
+		rec := probe("/livez")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("[+]client ok\nlivez check passed\n"))
+
+		closed, err := client.Dial(context.TODO(), sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(closed.Close()).To(Succeed())
+		handler = Handler(closed)
+		rec = probe("/livez")
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Body.String()).To(ContainSubstring("[-]client failed: client is closed"))
+		Expect(probe("/readyz").Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpprobe
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var sim *simulator.Simulator
+
+func TestHTTPProbe(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTTP Probe Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	sim, err = simulator.Start("")
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = BeforeEach(func() {
+	sim.Restart()
+})
+
+var _ = AfterSuite(func() {
+	if sim != nil {
+		sim.Stop()
+	}
+})
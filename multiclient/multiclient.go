@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package multiclient fans operations out to the dpservice instances of many nodes, e.g. to
+// create the neighbor NATs of a NAT on all peers of its node.
+//
+//	m := multiclient.New(map[string]client.Client{"node1": c1, "node2": c2, "node3": c3})
+//	results := m.Without("node1").CreateNeighborNat(ctx, neighborNat)
+//	if err := multiclient.Errors(results); err != nil { ... }
+package multiclient
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+type options struct {
+	concurrency int
+}
+
+type Option func(*options)
+
+// WithConcurrency limits fan-out operations to n nodes in flight. Defaults to all nodes.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// Result is the outcome of an operation on a single node.
+type Result[T any] struct {
+	Node  string
+	Value T
+	Err   error
+}
+
+// MultiClient holds the clients of many nodes, keyed by node name.
+type MultiClient struct {
+	clients map[string]client.Client
+	nodes   []string
+	opts    options
+}
+
+// New creates a MultiClient for the clients of the given nodes.
+func New(clients map[string]client.Client, opts ...Option) *MultiClient {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	nodes := make([]string, 0, len(clients))
+	for node := range clients {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return &MultiClient{clients: clients, nodes: nodes, opts: o}
+}
+
+// Nodes returns the names of the nodes, sorted.
+func (m *MultiClient) Nodes() []string {
+	return append([]string(nil), m.nodes...)
+}
+
+// Client returns the client of node, nil for unknown nodes.
+func (m *MultiClient) Client(node string) client.Client {
+	return m.clients[node]
+}
+
+// Without returns a MultiClient for all nodes except the given ones, e.g. the peers of a node.
+func (m *MultiClient) Without(nodes ...string) *MultiClient {
+	clients := make(map[string]client.Client, len(m.clients))
+	for node, c := range m.clients {
+		clients[node] = c
+	}
+	for _, node := range nodes {
+		delete(clients, node)
+	}
+	return New(clients, func(o *options) { *o = m.opts })
+}
+
+// Do calls fn for every node in parallel and returns the results sorted by node.
+func Do[T any](ctx context.Context, m *MultiClient, fn func(ctx context.Context, node string, c client.Client) (T, error)) []Result[T] {
+	concurrency := m.opts.concurrency
+	if concurrency < 1 || concurrency > len(m.nodes) {
+		concurrency = len(m.nodes)
+	}
+	results := make([]Result[T], len(m.nodes))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, node := range m.nodes {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, node string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			value, err := fn(ctx, node, m.clients[node])
+			results[i] = Result[T]{Node: node, Value: value, Err: err}
+		}(i, node)
+	}
+	wg.Wait()
+	return results
+}
+
+// Errors returns the failed results as an errors.Aggregate keyed by node, or nil if all
+// succeeded.
+func Errors[T any](results []Result[T]) error {
+	agg := &errors.Aggregate{}
+	for _, result := range results {
+		agg.Add(result.Node, result.Err)
+	}
+	return agg.ErrorOrNil()
+}
+
+// ListInterfaces lists the interfaces of all nodes.
+func (m *MultiClient) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) []Result[*api.InterfaceList] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.InterfaceList, error) {
+		return c.ListInterfaces(ctx, ignoredErrors...)
+	})
+}
+
+// CreateNeighborNat creates the neighbor NAT on all nodes.
+func (m *MultiClient) CreateNeighborNat(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) []Result[*api.NeighborNat] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.NeighborNat, error) {
+		return c.CreateNeighborNat(ctx, nat, ignoredErrors...)
+	})
+}
+
+// DeleteNeighborNat deletes the neighbor NAT on all nodes.
+func (m *MultiClient) DeleteNeighborNat(ctx context.Context, nat *api.NeighborNat, ignoredErrors ...[]uint32) []Result[*api.NeighborNat] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.NeighborNat, error) {
+		return c.DeleteNeighborNat(ctx, nat, ignoredErrors...)
+	})
+}
+
+// CreateLoadBalancer creates the load balancer on all nodes.
+func (m *MultiClient) CreateLoadBalancer(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) []Result[*api.LoadBalancer] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.LoadBalancer, error) {
+		return c.CreateLoadBalancer(ctx, lb, ignoredErrors...)
+	})
+}
+
+// DeleteLoadBalancer deletes the load balancer on all nodes.
+func (m *MultiClient) DeleteLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) []Result[*api.LoadBalancer] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.LoadBalancer, error) {
+		return c.DeleteLoadBalancer(ctx, id, ignoredErrors...)
+	})
+}
+
+// CreateLoadBalancerTarget creates the load balancer target on all nodes.
+func (m *MultiClient) CreateLoadBalancerTarget(ctx context.Context, target *api.LoadBalancerTarget, ignoredErrors ...[]uint32) []Result[*api.LoadBalancerTarget] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.LoadBalancerTarget, error) {
+		return c.CreateLoadBalancerTarget(ctx, target, ignoredErrors...)
+	})
+}
+
+// DeleteLoadBalancerTarget deletes the load balancer target on all nodes.
+func (m *MultiClient) DeleteLoadBalancerTarget(ctx context.Context, lbID string, targetIP *netip.Addr, ignoredErrors ...[]uint32) []Result[*api.LoadBalancerTarget] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*api.LoadBalancerTarget, error) {
+		return c.DeleteLoadBalancerTarget(ctx, lbID, targetIP, ignoredErrors...)
+	})
+}
+
+// SetLoadBalancerTargets sets the targets of the load balancer on all nodes.
+func (m *MultiClient) SetLoadBalancerTargets(ctx context.Context, lbID string, targets []netip.Addr) []Result[*client.LoadBalancerTargetChanges] {
+	return Do(ctx, m, func(ctx context.Context, _ string, c client.Client) (*client.LoadBalancerTargetChanges, error) {
+		return client.SetLoadBalancerTargets(ctx, c, lbID, targets)
+	})
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package multiclient
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("multi client", func() {
+	ctx := context.TODO()
+	natIP := netip.MustParseAddr("203.0.113.1")
+	underlayRoute := netip.MustParseAddr("fc00::1")
+
+	neighborNat := &api.NeighborNat{
+		NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+		Spec:            api.NeighborNatSpec{Vni: 100, MinPort: 1000, MaxPort: 2000, UnderlayRoute: &underlayRoute},
+	}
+
+	It("should create neighbor nats on all peers", func() {
+		m := New(clients, WithConcurrency(1))
+		Expect(m.Nodes()).To(Equal([]string{"node1", "node2", "node3"}))
+
+		results := m.Without("node1").CreateNeighborNat(ctx, neighborNat)
+		Expect(Errors(results)).To(Succeed())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Node).To(Equal("node2"))
+		Expect(results[1].Node).To(Equal("node3"))
+
+		for node, want := range map[string]int{"node1": 0, "node2": 1, "node3": 1} {
+			nats, err := m.Client(node).ListNeighborNats(ctx, &natIP)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nats.Items).To(HaveLen(want), node)
+		}
+	})
+
+	It("should report the failures per node", func() {
+		m := New(clients)
+		_, err := m.Client("node2").CreateNeighborNat(ctx, neighborNat)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = Errors(m.CreateNeighborNat(ctx, neighborNat))
+		var agg *errors.Aggregate
+		Expect(err).To(BeAssignableToTypeOf(agg))
+		agg = err.(*errors.Aggregate)
+		Expect(agg.IDs()).To(Equal([]string{"node2"}))
+		Expect(errors.IsStatusErrorCode(agg.Get("node2"), errors.ALREADY_EXISTS)).To(BeTrue())
+	})
+
+	It("should run custom operations on all nodes", func() {
+		results := Do(ctx, New(clients), func(ctx context.Context, node string, c client.Client) (string, error) {
+			initialized, err := c.CheckInitialized(ctx)
+			if err != nil {
+				return "", err
+			}
+			return initialized.Spec.UUID, nil
+		})
+		Expect(Errors(results)).To(Succeed())
+		Expect(results[0].Value).NotTo(Equal(results[1].Value))
+	})
+})
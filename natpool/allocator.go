@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package natpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+// ErrConflict is returned by Store.Save when the reservations were changed since they were
+// loaded.
+var ErrConflict = errors.New("nat port reservations changed concurrently")
+
+// DefaultMaxConflictRetries is the number of times an allocation is retried after a conflict
+// unless configured otherwise.
+const DefaultMaxConflictRetries = 5
+
+// Block is a range of ports of a NAT IP reserved for an owner, MaxPort exclusive.
+type Block struct {
+	IP      netip.Addr `json:"ip"`
+	MinPort uint32     `json:"min_port"`
+	MaxPort uint32     `json:"max_port"`
+	Owner   string     `json:"owner"`
+}
+
+// Store persists the port blocks reserved per NAT IP. It is shared by all replicas allocating
+// from the same IPs, e.g. backed by a ConfigMap or an etcd key, and must reject outdated writes
+// so that concurrent replicas never reserve overlapping blocks.
+type Store interface {
+	// Load returns the blocks reserved for ip and the revision they were read at.
+	Load(ctx context.Context, ip netip.Addr) (blocks []Block, revision string, err error)
+	// Save replaces the blocks reserved for ip if they are still at revision, and returns
+	// ErrConflict otherwise.
+	Save(ctx context.Context, ip netip.Addr, blocks []Block, revision string) error
+}
+
+// AllocatorOptions configures an Allocator.
+type AllocatorOptions struct {
+	// MinPort and MaxPort bound the allocatable ports. MaxPort is exclusive.
+	MinPort uint32
+	MaxPort uint32
+	// MaxConflictRetries is the number of times an allocation is retried after the store
+	// reported a conflict.
+	MaxConflictRetries int
+}
+
+// Allocator reserves port blocks of NAT IPs in a Store. Blocks already used by NATs in
+// dpservice are never handed out, even if they are not reserved in the store.
+type Allocator struct {
+	client client.Client
+	store  Store
+	opts   AllocatorOptions
+}
+
+// NewAllocator creates an allocator reserving blocks in store and checking them against the
+// NATs of c.
+func NewAllocator(c client.Client, store Store, opts AllocatorOptions) (*Allocator, error) {
+	if store == nil {
+		return nil, fmt.Errorf("allocator needs a store")
+	}
+	if opts.MinPort == 0 {
+		opts.MinPort = DefaultMinPort
+	}
+	if opts.MaxPort == 0 {
+		opts.MaxPort = DefaultMaxPort
+	}
+	if opts.MaxPort > DefaultMaxPort || opts.MinPort >= opts.MaxPort {
+		return nil, fmt.Errorf("invalid port range %d-%d", opts.MinPort, opts.MaxPort)
+	}
+	if opts.MaxConflictRetries == 0 {
+		opts.MaxConflictRetries = DefaultMaxConflictRetries
+	}
+	return &Allocator{client: c, store: store, opts: opts}, nil
+}
+
+// Allocate reserves a block of size ports of ip for owner. If owner already holds a block of
+// ip, that block is returned. It returns ErrExhausted if no block of size is free.
+func (a *Allocator) Allocate(ctx context.Context, ip netip.Addr, owner string, size uint32) (Block, error) {
+	if size == 0 || size > a.opts.MaxPort-a.opts.MinPort {
+		return Block{}, fmt.Errorf("invalid block size %d", size)
+	}
+	var block Block
+	err := a.update(ctx, ip, func(blocks []Block) ([]Block, error) {
+		for _, b := range blocks {
+			if b.Owner == owner {
+				block = b
+				return nil, nil
+			}
+		}
+		used, err := usedRanges(ctx, a.client, ip)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range blocks {
+			used = append(used, portRange{min: b.MinPort, max: b.MaxPort})
+		}
+		r, ok := freeRange(a.opts.MinPort, a.opts.MaxPort, size, used)
+		if !ok {
+			return nil, ErrExhausted
+		}
+		block = Block{IP: ip, MinPort: r.min, MaxPort: r.max, Owner: owner}
+		return append(blocks, block), nil
+	})
+	return block, err
+}
+
+// Free releases the block of ip reserved for owner. Freeing an owner without block is not
+// an error.
+func (a *Allocator) Free(ctx context.Context, ip netip.Addr, owner string) error {
+	return a.update(ctx, ip, func(blocks []Block) ([]Block, error) {
+		for i, b := range blocks {
+			if b.Owner == owner {
+				return append(blocks[:i:i], blocks[i+1:]...), nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// update applies fn to the blocks of ip and saves the result, retrying on conflicts. fn
+// returning nil blocks skips the save.
+func (a *Allocator) update(ctx context.Context, ip netip.Addr, fn func([]Block) ([]Block, error)) error {
+	for attempt := 0; ; attempt++ {
+		blocks, revision, err := a.store.Load(ctx, ip)
+		if err != nil {
+			return fmt.Errorf("error loading reservations of %s: %w", ip, err)
+		}
+		updated, err := fn(blocks)
+		if err != nil || updated == nil {
+			return err
+		}
+		err = a.store.Save(ctx, ip, updated, revision)
+		if !errors.Is(err, ErrConflict) || attempt >= a.opts.MaxConflictRetries {
+			if err != nil {
+				return fmt.Errorf("error saving reservations of %s: %w", ip, err)
+			}
+			return nil
+		}
+	}
+}
+
+// MemoryStore is a Store keeping the reservations in memory, for a single process or tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	blocks    map[netip.Addr][]Block
+	revisions map[netip.Addr]int
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		blocks:    make(map[netip.Addr][]Block),
+		revisions: make(map[netip.Addr]int),
+	}
+}
+
+func (s *MemoryStore) Load(_ context.Context, ip netip.Addr) ([]Block, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Block(nil), s.blocks[ip]...), strconv.Itoa(s.revisions[ip]), nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, ip netip.Addr, blocks []Block, revision string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if revision != strconv.Itoa(s.revisions[ip]) {
+		return ErrConflict
+	}
+	s.blocks[ip] = append([]Block(nil), blocks...)
+	s.revisions[ip]++
+	return nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package natpool
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// conflictingStore reports a conflict on every save.
+type conflictingStore struct {
+	*MemoryStore
+	saves int
+}
+
+func (s *conflictingStore) Save(context.Context, netip.Addr, []Block, string) error {
+	s.saves++
+	return ErrConflict
+}
+
+var _ = Describe("port block allocator", func() {
+	ctx := context.TODO()
+	natIP := netip.MustParseAddr("203.0.113.1")
+
+	It("should allocate non-overlapping blocks of the requested size", func() {
+		a, err := NewAllocator(simClient, NewMemoryStore(), AllocatorOptions{MinPort: 1000, MaxPort: 2000})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(a.Allocate(ctx, natIP, "a", 100)).To(Equal(Block{IP: natIP, MinPort: 1000, MaxPort: 1100, Owner: "a"}))
+		Expect(a.Allocate(ctx, natIP, "b", 500)).To(Equal(Block{IP: natIP, MinPort: 1500, MaxPort: 2000, Owner: "b"}))
+		Expect(a.Allocate(ctx, natIP, "a", 100)).To(Equal(Block{IP: natIP, MinPort: 1000, MaxPort: 1100, Owner: "a"}))
+
+		_, err = a.Allocate(ctx, natIP, "c", 1000)
+		Expect(err).To(MatchError(ErrExhausted))
+
+		Expect(a.Free(ctx, natIP, "b")).To(Succeed())
+		Expect(a.Free(ctx, natIP, "b")).To(Succeed())
+		Expect(a.Allocate(ctx, natIP, "c", 500)).To(Equal(Block{IP: natIP, MinPort: 1500, MaxPort: 2000, Owner: "c"}))
+	})
+
+	It("should skip port ranges used by nats in dpservice", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		_, err := simClient.CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm0"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap0"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = simClient.CreateNat(ctx, &api.Nat{
+			NatMeta: api.NatMeta{InterfaceID: "vm0"},
+			Spec:    api.NatSpec{NatIP: &natIP, MinPort: 1000, MaxPort: 1100},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		a, err := NewAllocator(simClient, NewMemoryStore(), AllocatorOptions{MinPort: 1000, MaxPort: 2000})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a.Allocate(ctx, natIP, "a", 100)).To(HaveField("MinPort", BeEquivalentTo(1100)))
+	})
+
+	It("should not hand out overlapping blocks to concurrent allocators", func() {
+		store := NewMemoryStore()
+		var (
+			wg     sync.WaitGroup
+			mu     sync.Mutex
+			blocks []Block
+		)
+		for i := 0; i < 4; i++ {
+			a, err := NewAllocator(simClient, store, AllocatorOptions{MinPort: 1000, MaxPort: 2000, MaxConflictRetries: 100})
+			Expect(err).NotTo(HaveOccurred())
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				block, err := a.Allocate(ctx, natIP, fmt.Sprintf("replica%d", i), 250)
+				Expect(err).NotTo(HaveOccurred())
+				mu.Lock()
+				defer mu.Unlock()
+				blocks = append(blocks, block)
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(blocks).To(HaveLen(4))
+		Expect(blocks).To(ConsistOf(
+			HaveField("MinPort", BeEquivalentTo(1000)),
+			HaveField("MinPort", BeEquivalentTo(1250)),
+			HaveField("MinPort", BeEquivalentTo(1500)),
+			HaveField("MinPort", BeEquivalentTo(1750)),
+		))
+	})
+
+	It("should give up after the configured conflict retries", func() {
+		store := &conflictingStore{MemoryStore: NewMemoryStore()}
+		a, err := NewAllocator(simClient, store, AllocatorOptions{MaxConflictRetries: 2})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = a.Allocate(ctx, natIP, "a", 100)
+		Expect(err).To(MatchError(ErrConflict))
+		Expect(store.saves).To(Equal(3))
+	})
+})
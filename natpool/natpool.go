@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package natpool shares a pool of NAT IPs among the interfaces of a VNI. Every interface is
+// assigned a free port range of one of the IPs, and the NAT entries are created in dpservice.
+//
+//	pool, err := natpool.New(c, natpool.Config{VNI: 100, IPs: []netip.Addr{natIP}})
+//	if err != nil { ... }
+//	nat, err := pool.Assign(ctx, "vm1")
+//
+// Allocator reserves port blocks in a Store shared by several replicas instead.
+package natpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	dpdkerrors "github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// ErrExhausted is returned by Assign when no IP of the pool has a free port range left.
+var ErrExhausted = errors.New("nat pool exhausted")
+
+const (
+	// DefaultPortsPerInterface is the size of the port range assigned to an interface unless configured otherwise.
+	DefaultPortsPerInterface = 1024
+	// DefaultMinPort is the first assignable port unless configured otherwise.
+	DefaultMinPort = 1024
+	// DefaultMaxPort is the end of the assignable ports, exclusive, unless configured otherwise.
+	DefaultMaxPort = 65536
+)
+
+// Config configures a Pool.
+type Config struct {
+	// VNI the NAT entries are created in.
+	VNI uint32
+	// IPs are the NAT IPs of the pool. They are used in order.
+	IPs []netip.Addr
+	// PortsPerInterface is the size of the port range assigned to each interface.
+	PortsPerInterface uint32
+	// MinPort and MaxPort bound the assignable ports. MaxPort is exclusive like the max port of dpservice NATs.
+	MinPort uint32
+	MaxPort uint32
+}
+
+// Pool assigns NAT IPs and port ranges to interfaces. The ranges in use are read from dpservice
+// on every assignment, so NATs created by others, including the neighbor NATs of ranges used
+// on other nodes, are respected.
+type Pool struct {
+	client client.Client
+	config Config
+
+	// mu serializes assignments, so concurrent calls do not pick the same range.
+	mu sync.Mutex
+}
+
+// New creates a pool assigning NATs with c.
+func New(c client.Client, config Config) (*Pool, error) {
+	if len(config.IPs) == 0 {
+		return nil, fmt.Errorf("nat pool needs at least one ip")
+	}
+	for _, ip := range config.IPs {
+		if !ip.IsValid() {
+			return nil, fmt.Errorf("invalid nat ip %s", ip)
+		}
+	}
+	if config.PortsPerInterface == 0 {
+		config.PortsPerInterface = DefaultPortsPerInterface
+	}
+	if config.MinPort == 0 {
+		config.MinPort = DefaultMinPort
+	}
+	if config.MaxPort == 0 {
+		config.MaxPort = DefaultMaxPort
+	}
+	if config.MaxPort > DefaultMaxPort || config.MinPort >= config.MaxPort {
+		return nil, fmt.Errorf("invalid port range %d-%d", config.MinPort, config.MaxPort)
+	}
+	if config.PortsPerInterface > config.MaxPort-config.MinPort {
+		return nil, fmt.Errorf("%d ports per interface exceed the port range %d-%d", config.PortsPerInterface, config.MinPort, config.MaxPort)
+	}
+	return &Pool{client: c, config: config}, nil
+}
+
+// portRange is a range of ports, max exclusive.
+type portRange struct {
+	min, max uint32
+}
+
+func (r portRange) overlaps(o portRange) bool {
+	return r.min < o.max && o.min < r.max
+}
+
+// usedRanges returns the port ranges of ip used by local and neighbor NATs.
+func usedRanges(ctx context.Context, c client.Client, ip netip.Addr) ([]portRange, error) {
+	nats, err := c.ListNats(ctx, &ip, "any")
+	if err != nil {
+		return nil, fmt.Errorf("error listing nats of %s: %w", ip, err)
+	}
+	used := make([]portRange, 0, len(nats.Items))
+	for _, nat := range nats.Items {
+		used = append(used, portRange{min: nat.Spec.MinPort, max: nat.Spec.MaxPort})
+	}
+	return used, nil
+}
+
+// freeRange returns the first range of size ports between min and max, aligned to min,
+// that does not overlap any used range.
+func freeRange(min, max, size uint32, used []portRange) (portRange, bool) {
+	for start := min; start+size <= max; start += size {
+		candidate := portRange{min: start, max: start + size}
+		free := true
+		for _, r := range used {
+			if candidate.overlaps(r) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate, true
+		}
+	}
+	return portRange{}, false
+}
+
+// Assign creates a NAT for the interface with the first free port range of the pool's IPs.
+// It returns ErrExhausted if no range is left.
+func (p *Pool) Assign(ctx context.Context, interfaceID string) (*api.Nat, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, ip := range p.config.IPs {
+		used, err := usedRanges(ctx, p.client, ip)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := freeRange(p.config.MinPort, p.config.MaxPort, p.config.PortsPerInterface, used)
+		if !ok {
+			continue
+		}
+		ip := ip
+		return p.client.CreateNat(ctx, &api.Nat{
+			TypeMeta: api.TypeMeta{Kind: api.NatKind},
+			NatMeta:  api.NatMeta{InterfaceID: interfaceID},
+			Spec: api.NatSpec{
+				NatIP:   &ip,
+				MinPort: r.min,
+				MaxPort: r.max,
+				Vni:     p.config.VNI,
+			},
+		})
+	}
+	return nil, ErrExhausted
+}
+
+// Release deletes the NAT of the interface, freeing its port range. Releasing an interface
+// without NAT is not an error.
+func (p *Pool) Release(ctx context.Context, interfaceID string) error {
+	_, err := p.client.DeleteNat(ctx, interfaceID)
+	return dpdkerrors.IgnoreStatusErrorCode(err, dpdkerrors.SNAT_NO_DATA)
+}
+
+// AddNeighbor creates the neighbor NAT for a NAT of the pool assigned on another node, so
+// traffic for its port range is forwarded to the node's underlay route and the range is not
+// assigned again.
+func (p *Pool) AddNeighbor(ctx context.Context, nat *api.Nat, underlayRoute netip.Addr) error {
+	_, err := p.client.CreateNeighborNat(ctx, p.neighborNat(nat, &underlayRoute))
+	return err
+}
+
+// RemoveNeighbor deletes the neighbor NAT created by AddNeighbor.
+func (p *Pool) RemoveNeighbor(ctx context.Context, nat *api.Nat) error {
+	_, err := p.client.DeleteNeighborNat(ctx, p.neighborNat(nat, nil))
+	return dpdkerrors.IgnoreStatusErrorCode(err, dpdkerrors.NOT_FOUND)
+}
+
+func (p *Pool) neighborNat(nat *api.Nat, underlayRoute *netip.Addr) *api.NeighborNat {
+	return &api.NeighborNat{
+		TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+		NeighborNatMeta: api.NeighborNatMeta{NatIP: nat.Spec.NatIP},
+		Spec: api.NeighborNatSpec{
+			Vni:           p.config.VNI,
+			MinPort:       nat.Spec.MinPort,
+			MaxPort:       nat.Spec.MaxPort,
+			UnderlayRoute: underlayRoute,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package natpool
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("nat pool", func() {
+	ctx := context.TODO()
+	natIP1 := netip.MustParseAddr("203.0.113.1")
+	natIP2 := netip.MustParseAddr("203.0.113.2")
+
+	createInterfaces := func(n int) {
+		for i := 0; i < n; i++ {
+			ipv4 := netip.AddrFrom4([4]byte{10, 0, 0, byte(i + 1)})
+			ipv6 := netip.MustParseAddr(fmt.Sprintf("2001:db8::%x", i+1))
+			_, err := simClient.CreateInterface(ctx, &api.Interface{
+				InterfaceMeta: api.InterfaceMeta{ID: fmt.Sprintf("vm%d", i)},
+				Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: fmt.Sprintf("net_tap%d", i)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should reject invalid configurations", func() {
+		_, err := New(simClient, Config{VNI: 100})
+		Expect(err).To(HaveOccurred())
+		_, err = New(simClient, Config{VNI: 100, IPs: []netip.Addr{natIP1}, MinPort: 2000, MaxPort: 1000})
+		Expect(err).To(HaveOccurred())
+		_, err = New(simClient, Config{VNI: 100, IPs: []netip.Addr{natIP1}, MinPort: 1000, MaxPort: 2000, PortsPerInterface: 2000})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should assign free port ranges across the pool's IPs", func() {
+		createInterfaces(3)
+		pool, err := New(simClient, Config{VNI: 100, IPs: []netip.Addr{natIP1, natIP2}, MinPort: 1000, MaxPort: 3000, PortsPerInterface: 1000})
+		Expect(err).NotTo(HaveOccurred())
+
+		nat, err := pool.Assign(ctx, "vm0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*nat.Spec.NatIP).To(Equal(natIP1))
+		Expect(nat.Spec.MinPort).To(BeEquivalentTo(1000))
+		Expect(nat.Spec.MaxPort).To(BeEquivalentTo(2000))
+
+		nat, err = pool.Assign(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*nat.Spec.NatIP).To(Equal(natIP1))
+		Expect(nat.Spec.MinPort).To(BeEquivalentTo(2000))
+
+		nat, err = pool.Assign(ctx, "vm2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*nat.Spec.NatIP).To(Equal(natIP2))
+		Expect(nat.Spec.MinPort).To(BeEquivalentTo(1000))
+	})
+
+	It("should reuse released ranges and report exhaustion", func() {
+		createInterfaces(2)
+		pool, err := New(simClient, Config{VNI: 100, IPs: []netip.Addr{natIP1}, MinPort: 1000, MaxPort: 2000, PortsPerInterface: 1000})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pool.Assign(ctx, "vm0")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = pool.Assign(ctx, "vm1")
+		Expect(err).To(MatchError(ErrExhausted))
+
+		Expect(pool.Release(ctx, "vm0")).To(Succeed())
+		Expect(pool.Release(ctx, "vm0")).To(Succeed())
+		nat, err := pool.Assign(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nat.Spec.MinPort).To(BeEquivalentTo(1000))
+	})
+
+	It("should skip ranges assigned on other nodes", func() {
+		createInterfaces(1)
+		pool, err := New(simClient, Config{VNI: 100, IPs: []netip.Addr{natIP1}, MinPort: 1000, MaxPort: 3000, PortsPerInterface: 1000})
+		Expect(err).NotTo(HaveOccurred())
+
+		remote := &api.Nat{Spec: api.NatSpec{NatIP: &natIP1, MinPort: 1000, MaxPort: 2000}}
+		Expect(pool.AddNeighbor(ctx, remote, netip.MustParseAddr("fc00:2::1"))).To(Succeed())
+
+		nat, err := pool.Assign(ctx, "vm0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nat.Spec.MinPort).To(BeEquivalentTo(2000))
+
+		Expect(pool.RemoveNeighbor(ctx, remote)).To(Succeed())
+		neighbors, err := simClient.ListNeighborNats(ctx, &natIP1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(neighbors.Items).To(BeEmpty())
+	})
+})
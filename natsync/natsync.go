@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package natsync keeps the neighbor NATs of all nodes in sync with their local NATs. Every
+// NAT created on a node needs a neighbor NAT on all its peers, so traffic returning to its
+// port range on another node is forwarded to the node's NAT underlay route.
+//
+//	s := natsync.New(multiclient.New(clients))
+//	nat, err := clients["node1"].CreateNat(ctx, nat)
+//	if err != nil { ... }
+//	err = s.Created(ctx, "node1", nat)
+package natsync
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/ironcore-dev/dpservice-go/multiclient"
+)
+
+// Syncer creates and deletes the neighbor NATs of local NATs on the peer nodes.
+type Syncer struct {
+	nodes *multiclient.MultiClient
+}
+
+// New creates a Syncer for the given nodes.
+func New(nodes *multiclient.MultiClient) *Syncer {
+	return &Syncer{nodes: nodes}
+}
+
+// NeighborNat returns the neighbor NAT the peers of a node need for a NAT of the node.
+func NeighborNat(nat *api.Nat) (*api.NeighborNat, error) {
+	if nat.Spec.NatIP == nil || nat.Spec.UnderlayRoute == nil {
+		return nil, fmt.Errorf("nat of interface %s needs nat ip and underlay route", nat.InterfaceID)
+	}
+	natIP, underlayRoute := *nat.Spec.NatIP, *nat.Spec.UnderlayRoute
+	return &api.NeighborNat{
+		TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+		NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+		Spec: api.NeighborNatSpec{
+			Vni:           nat.Spec.Vni,
+			MinPort:       nat.Spec.MinPort,
+			MaxPort:       nat.Spec.MaxPort,
+			UnderlayRoute: &underlayRoute,
+		},
+	}, nil
+}
+
+// Created creates the neighbor NAT of nat, a NAT created on node, on all peers of node.
+// Peers already having the neighbor NAT are not an error. Failures are returned as an
+// errors.Aggregate keyed by peer.
+func (s *Syncer) Created(ctx context.Context, node string, nat *api.Nat) error {
+	nNat, err := NeighborNat(nat)
+	if err != nil {
+		return err
+	}
+	return multiclient.Errors(s.nodes.Without(node).CreateNeighborNat(ctx, nNat, errors.Ignore(errors.ALREADY_EXISTS)))
+}
+
+// Deleted deletes the neighbor NAT of nat, a NAT deleted on node, on all peers of node.
+// Peers not having the neighbor NAT are not an error.
+func (s *Syncer) Deleted(ctx context.Context, node string, nat *api.Nat) error {
+	nNat, err := NeighborNat(nat)
+	if err != nil {
+		return err
+	}
+	return multiclient.Errors(s.nodes.Without(node).DeleteNeighborNat(ctx, nNat, errors.Ignore(errors.NOT_FOUND)))
+}
+
+// neighborKey identifies a neighbor NAT independent of its underlay route.
+type neighborKey struct {
+	natIP            netip.Addr
+	vni              uint32
+	minPort, maxPort uint32
+}
+
+func keyOf(nNat *api.NeighborNat) neighborKey {
+	return neighborKey{natIP: *nNat.NatIP, vni: nNat.Spec.Vni, minPort: nNat.Spec.MinPort, maxPort: nNat.Spec.MaxPort}
+}
+
+// Sync reconciles the neighbor NATs of natIPs on all nodes with the local NATs of all nodes:
+// missing neighbor NATs are created, neighbor NATs with another underlay route are replaced
+// and neighbor NATs without local NAT on any node are deleted. Use it after restarts, when
+// Created or Deleted calls may have been missed. Failures are returned as an
+// errors.Aggregate keyed by node.
+func (s *Syncer) Sync(ctx context.Context, natIPs ...netip.Addr) error {
+	wanted := make(map[netip.Addr]struct{}, len(natIPs))
+	for _, ip := range natIPs {
+		wanted[ip] = struct{}{}
+	}
+
+	locals := multiclient.Do(ctx, s.nodes, func(ctx context.Context, _ string, c client.Client) ([]*api.NeighborNat, error) {
+		return localNeighborNats(ctx, c, wanted)
+	})
+	if err := multiclient.Errors(locals); err != nil {
+		return err
+	}
+
+	return multiclient.Errors(multiclient.Do(ctx, s.nodes, func(ctx context.Context, node string, c client.Client) (struct{}, error) {
+		desired := make(map[neighborKey]*api.NeighborNat)
+		for _, result := range locals {
+			if result.Node == node {
+				continue
+			}
+			for _, nNat := range result.Value {
+				desired[keyOf(nNat)] = nNat
+			}
+		}
+		return struct{}{}, syncNode(ctx, c, natIPs, desired)
+	}))
+}
+
+// localNeighborNats returns the neighbor NATs the peers of c need for its NATs of wanted IPs.
+func localNeighborNats(ctx context.Context, c client.Client, wanted map[netip.Addr]struct{}) ([]*api.NeighborNat, error) {
+	ifaces, err := c.ListInterfaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing interfaces: %w", err)
+	}
+	var nNats []*api.NeighborNat
+	for _, iface := range ifaces.Items {
+		nat, err := c.GetNat(ctx, iface.ID, errors.Ignore(errors.SNAT_NO_DATA, errors.NOT_FOUND))
+		if err != nil {
+			return nil, fmt.Errorf("error getting nat of interface %s: %w", iface.ID, err)
+		}
+		if nat.Status.Code != 0 || nat.Spec.NatIP == nil {
+			continue
+		}
+		if _, ok := wanted[*nat.Spec.NatIP]; !ok {
+			continue
+		}
+		nat.Spec.Vni = iface.Spec.VNI
+		nNat, err := NeighborNat(nat)
+		if err != nil {
+			return nil, err
+		}
+		nNats = append(nNats, nNat)
+	}
+	return nNats, nil
+}
+
+// syncNode makes the neighbor NATs of natIPs on c match desired.
+func syncNode(ctx context.Context, c client.Client, natIPs []netip.Addr, desired map[neighborKey]*api.NeighborNat) error {
+	agg := &errors.Aggregate{}
+	for _, natIP := range natIPs {
+		natIP := natIP
+		list, err := c.ListNeighborNats(ctx, &natIP)
+		if err != nil {
+			return fmt.Errorf("error listing neighbor nats of %s: %w", natIP, err)
+		}
+		for _, item := range list.Items {
+			live := &api.NeighborNat{
+				TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+				NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+				Spec: api.NeighborNatSpec{
+					Vni:           item.Spec.Vni,
+					MinPort:       item.Spec.MinPort,
+					MaxPort:       item.Spec.MaxPort,
+					UnderlayRoute: item.Spec.UnderlayRoute,
+				},
+			}
+			key := keyOf(live)
+			if want, ok := desired[key]; ok && equalAddr(want.Spec.UnderlayRoute, live.Spec.UnderlayRoute) {
+				delete(desired, key)
+				continue
+			}
+			_, err := c.DeleteNeighborNat(ctx, live, errors.Ignore(errors.NOT_FOUND))
+			agg.Add(live.GetID(), err)
+		}
+	}
+	for _, nNat := range desired {
+		_, err := c.CreateNeighborNat(ctx, nNat, errors.Ignore(errors.ALREADY_EXISTS))
+		agg.Add(nNat.GetID(), err)
+	}
+	return agg.ErrorOrNil()
+}
+
+func equalAddr(a, b *netip.Addr) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsync
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/multiclient"
+)
+
+var _ = Describe("nat sync", func() {
+	ctx := context.TODO()
+	natIP := netip.MustParseAddr("203.0.113.1")
+
+	createNat := func(node string, minPort, maxPort uint32) *api.Nat {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		_, err := clients[node].CreateInterface(ctx, &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm-" + node},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap0"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		nat, err := clients[node].CreateNat(ctx, &api.Nat{
+			NatMeta: api.NatMeta{InterfaceID: "vm-" + node},
+			Spec:    api.NatSpec{NatIP: &natIP, MinPort: minPort, MaxPort: maxPort},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		nat.Spec.Vni = 100
+		return nat
+	}
+
+	neighborNats := func(node string) []api.Nat {
+		list, err := clients[node].ListNeighborNats(ctx, &natIP)
+		Expect(err).NotTo(HaveOccurred())
+		return list.Items
+	}
+
+	It("should mirror created and deleted nats to the peers", func() {
+		s := New(multiclient.New(clients))
+		nat := createNat("node1", 1000, 2000)
+
+		Expect(s.Created(ctx, "node1", nat)).To(Succeed())
+		Expect(s.Created(ctx, "node1", nat)).To(Succeed())
+		Expect(neighborNats("node1")).To(BeEmpty())
+		for _, node := range []string{"node2", "node3"} {
+			Expect(neighborNats(node)).To(ConsistOf(HaveField("Spec", And(
+				HaveField("Vni", BeEquivalentTo(100)),
+				HaveField("MinPort", BeEquivalentTo(1000)),
+				HaveField("MaxPort", BeEquivalentTo(2000)),
+				HaveField("UnderlayRoute", Equal(nat.Spec.UnderlayRoute)),
+			))), node)
+		}
+
+		Expect(s.Deleted(ctx, "node1", nat)).To(Succeed())
+		Expect(s.Deleted(ctx, "node1", nat)).To(Succeed())
+		for _, node := range []string{"node2", "node3"} {
+			Expect(neighborNats(node)).To(BeEmpty(), node)
+		}
+	})
+
+	It("should reject nats without underlay route", func() {
+		s := New(multiclient.New(clients))
+		Expect(s.Created(ctx, "node1", &api.Nat{
+			NatMeta: api.NatMeta{InterfaceID: "vm"},
+			Spec:    api.NatSpec{NatIP: &natIP, MinPort: 1000, MaxPort: 2000},
+		})).To(MatchError("nat of interface vm needs nat ip and underlay route"))
+	})
+
+	It("should reconcile the neighbor nats of all nodes", func() {
+		nat1 := createNat("node1", 1000, 2000)
+		nat2 := createNat("node2", 2000, 3000)
+
+		// node3 has a stale neighbor nat and one with an outdated underlay route.
+		stale := netip.MustParseAddr("fc00::dead")
+		for _, nNat := range []*api.NeighborNat{
+			{NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP}, Spec: api.NeighborNatSpec{Vni: 100, MinPort: 5000, MaxPort: 6000, UnderlayRoute: &stale}},
+			{NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP}, Spec: api.NeighborNatSpec{Vni: 100, MinPort: 1000, MaxPort: 2000, UnderlayRoute: &stale}},
+		} {
+			_, err := clients["node3"].CreateNeighborNat(ctx, nNat)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(New(multiclient.New(clients)).Sync(ctx, natIP)).To(Succeed())
+
+		portsAndRoute := func(nat *api.Nat) types.GomegaMatcher {
+			return HaveField("Spec", And(
+				HaveField("MinPort", Equal(nat.Spec.MinPort)),
+				HaveField("UnderlayRoute", Equal(nat.Spec.UnderlayRoute)),
+			))
+		}
+		Expect(neighborNats("node1")).To(ConsistOf(portsAndRoute(nat2)))
+		Expect(neighborNats("node2")).To(ConsistOf(portsAndRoute(nat1)))
+		Expect(neighborNats("node3")).To(ConsistOf(portsAndRoute(nat1), portsAndRoute(nat2)))
+	})
+})
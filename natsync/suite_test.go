@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var (
+	sims    []*simulator.Simulator
+	clients map[string]client.Client
+)
+
+func TestNatSync(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "NAT Sync Suite")
+}
+
+var _ = BeforeSuite(func() {
+	clients = make(map[string]client.Client)
+	for i := 1; i <= 3; i++ {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		sims = append(sims, sim)
+
+		c, err := client.Dial(context.TODO(), sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		clients[fmt.Sprintf("node%d", i)] = c
+	}
+})
+
+var _ = BeforeEach(func() {
+	for _, sim := range sims {
+		sim.Restart()
+	}
+	for _, c := range clients {
+		_, err := client.EnsureInitialized(context.TODO(), c)
+		Expect(err).NotTo(HaveOccurred())
+	}
+})
+
+var _ = AfterSuite(func() {
+	for _, sim := range sims {
+		sim.Stop()
+	}
+})
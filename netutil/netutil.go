@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package netutil provides the prefix math needed to plan dataplane configuration, e.g.
+// summarizing routes before installing them or checking that load balancer prefixes
+// contain their VIPs.
+//
+//	routes = netutil.SummarizeRoutes(routes)
+//	err := netutil.ValidateLoadBalancerPrefixes(lb, prefixes)
+package netutil
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// MaxSplit is the maximum number of prefixes Split returns.
+const MaxSplit = 1 << 16
+
+// Contains reports whether inner is fully contained in outer. Prefixes of different address
+// families never contain each other.
+func Contains(outer, inner netip.Prefix) bool {
+	return outer.IsValid() && inner.IsValid() &&
+		outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr())
+}
+
+// ContainsAddr reports whether any of prefixes contains addr.
+func ContainsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastAddr returns the last address of prefix.
+func LastAddr(prefix netip.Prefix) netip.Addr {
+	prefix = prefix.Masked()
+	bytes := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// Split splits prefix into the prefixes of length bits it consists of, e.g. a /24 into
+// four /26. Splits into more than MaxSplit prefixes return an error.
+func Split(prefix netip.Prefix, bits int) ([]netip.Prefix, error) {
+	if !prefix.IsValid() {
+		return nil, fmt.Errorf("invalid prefix %s", prefix)
+	}
+	if bits < prefix.Bits() || bits > prefix.Addr().BitLen() {
+		return nil, fmt.Errorf("cannot split %s into /%d prefixes", prefix, bits)
+	}
+	if bits-prefix.Bits() > 16 {
+		return nil, fmt.Errorf("splitting %s into /%d prefixes exceeds %d prefixes", prefix, bits, MaxSplit)
+	}
+
+	prefixes := make([]netip.Prefix, 0, 1<<(bits-prefix.Bits()))
+	for addr := prefix.Masked().Addr(); addr.IsValid() && prefix.Contains(addr); {
+		sub := netip.PrefixFrom(addr, bits)
+		prefixes = append(prefixes, sub)
+		addr = LastAddr(sub).Next()
+	}
+	return prefixes, nil
+}
+
+// Summarize returns the smallest set of prefixes covering exactly the addresses of
+// prefixes, dropping duplicates and prefixes contained in others and merging adjacent
+// prefixes into their parent. The result is sorted by address, IPv4 first.
+func Summarize(prefixes []netip.Prefix) []netip.Prefix {
+	sorted := make([]netip.Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix.IsValid() {
+			sorted = append(sorted, prefix.Masked())
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := sorted[i].Addr().Compare(sorted[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return sorted[i].Bits() < sorted[j].Bits()
+	})
+
+	summary := make([]netip.Prefix, 0, len(sorted))
+	for _, prefix := range sorted {
+		if len(summary) > 0 && Contains(summary[len(summary)-1], prefix) {
+			continue
+		}
+		summary = append(summary, prefix)
+		// merging siblings may allow merging the parent with its sibling in turn
+		for len(summary) >= 2 {
+			parent, ok := mergeSiblings(summary[len(summary)-2], summary[len(summary)-1])
+			if !ok {
+				break
+			}
+			summary = append(summary[:len(summary)-2], parent)
+		}
+	}
+	return summary
+}
+
+// mergeSiblings returns the parent of a and b if they are its two halves.
+func mergeSiblings(a, b netip.Prefix) (netip.Prefix, bool) {
+	if a.Bits() != b.Bits() || a.Bits() == 0 || a.Addr().Is4() != b.Addr().Is4() || a == b {
+		return netip.Prefix{}, false
+	}
+	parent := netip.PrefixFrom(a.Addr(), a.Bits()-1).Masked()
+	if parent.Addr() != a.Addr() || !parent.Contains(b.Addr()) {
+		return netip.Prefix{}, false
+	}
+	return parent, true
+}
+
+// SummarizeRoutes summarizes the prefixes of routes with the same VNI and next hop, see
+// Summarize, e.g. before installing them. Routes without prefix or next hop are returned
+// unchanged. The summarized routes keep the order of the first route of their group.
+func SummarizeRoutes(routes []api.Route) []api.Route {
+	type groupKey struct {
+		vni, nextHopVNI uint32
+		nextHopIP       netip.Addr
+	}
+
+	var (
+		summarized []api.Route
+		order      []groupKey
+		nextHops   = make(map[groupKey]api.RouteNextHop)
+		prefixes   = make(map[groupKey][]netip.Prefix)
+	)
+	for _, route := range routes {
+		if route.Spec.Prefix == nil || route.Spec.NextHop == nil {
+			summarized = append(summarized, route)
+			continue
+		}
+		key := groupKey{vni: route.VNI, nextHopVNI: route.Spec.NextHop.VNI}
+		if route.Spec.NextHop.IP != nil {
+			key.nextHopIP = *route.Spec.NextHop.IP
+		}
+		if _, ok := nextHops[key]; !ok {
+			nextHops[key] = *route.Spec.NextHop
+			order = append(order, key)
+		}
+		prefixes[key] = append(prefixes[key], *route.Spec.Prefix)
+	}
+
+	for _, key := range order {
+		for _, prefix := range Summarize(prefixes[key]) {
+			prefix := prefix
+			nextHop := nextHops[key]
+			summarized = append(summarized, api.Route{
+				TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+				RouteMeta: api.RouteMeta{VNI: key.vni},
+				Spec:      api.RouteSpec{Prefix: &prefix, NextHop: &nextHop},
+			})
+		}
+	}
+	return summarized
+}
+
+// ValidateLoadBalancerPrefixes checks that the VIP of lb is contained in one of prefixes,
+// e.g. the load balancer prefixes routed to the interfaces of its targets.
+func ValidateLoadBalancerPrefixes(lb *api.LoadBalancer, prefixes []api.LoadBalancerPrefix) error {
+	if lb.Spec.LbVipIP == nil || !lb.Spec.LbVipIP.IsValid() {
+		return fmt.Errorf("load balancer %s has no vip", lb.ID)
+	}
+	for _, prefix := range prefixes {
+		if prefix.Spec.Prefix.Contains(*lb.Spec.LbVipIP) {
+			return nil
+		}
+	}
+	return fmt.Errorf("vip %s of load balancer %s is not contained in any of its prefixes", lb.Spec.LbVipIP, lb.ID)
+}
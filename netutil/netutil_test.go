@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package netutil
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("netutil", func() {
+	prefixes := func(ss ...string) []netip.Prefix {
+		var ps []netip.Prefix
+		for _, s := range ss {
+			ps = append(ps, netip.MustParsePrefix(s))
+		}
+		return ps
+	}
+
+	It("should check containment", func() {
+		Expect(Contains(netip.MustParsePrefix("10.0.0.0/16"), netip.MustParsePrefix("10.0.3.0/24"))).To(BeTrue())
+		Expect(Contains(netip.MustParsePrefix("10.0.3.0/24"), netip.MustParsePrefix("10.0.0.0/16"))).To(BeFalse())
+		Expect(Contains(netip.MustParsePrefix("::/0"), netip.MustParsePrefix("10.0.0.0/8"))).To(BeFalse())
+		Expect(ContainsAddr(prefixes("10.0.0.0/24", "fd00::/64"), netip.MustParseAddr("fd00::1"))).To(BeTrue())
+		Expect(LastAddr(netip.MustParsePrefix("10.0.1.7/23"))).To(Equal(netip.MustParseAddr("10.0.1.255")))
+	})
+
+	It("should split prefixes", func() {
+		Expect(Split(netip.MustParsePrefix("10.0.0.0/24"), 26)).To(Equal(prefixes(
+			"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26")))
+		Expect(Split(netip.MustParsePrefix("255.255.255.254/31"), 32)).To(Equal(prefixes(
+			"255.255.255.254/32", "255.255.255.255/32")))
+		Expect(Split(netip.MustParsePrefix("fd00::/64"), 64)).To(Equal(prefixes("fd00::/64")))
+
+		_, err := Split(netip.MustParsePrefix("10.0.0.0/24"), 16)
+		Expect(err).To(HaveOccurred())
+		_, err = Split(netip.MustParsePrefix("fd00::/48"), 128)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should summarize prefixes", func() {
+		Expect(Summarize(prefixes(
+			"10.0.0.192/26", "10.0.0.0/26", "10.0.0.128/26", "10.0.0.64/26",
+			"10.0.1.0/25", "10.0.0.5/32", "fd00::/65", "fd00:0:0:0:8000::/65", "10.0.3.0/24",
+		))).To(Equal(prefixes("10.0.0.0/24", "10.0.1.0/25", "10.0.3.0/24", "fd00::/64")))
+		Expect(Summarize(prefixes("10.0.0.1/24", "10.0.0.0/24"))).To(Equal(prefixes("10.0.0.0/24")))
+	})
+
+	It("should summarize routes per next hop", func() {
+		hop1 := netip.MustParseAddr("fc00::1")
+		hop2 := netip.MustParseAddr("fc00::2")
+		route := func(vni uint32, prefix string, hop *netip.Addr) api.Route {
+			p := netip.MustParsePrefix(prefix)
+			return api.Route{RouteMeta: api.RouteMeta{VNI: vni}, Spec: api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: vni, IP: hop}}}
+		}
+
+		routes := SummarizeRoutes([]api.Route{
+			route(100, "10.0.0.0/25", &hop1),
+			route(100, "10.0.1.0/24", &hop2),
+			route(100, "10.0.0.128/25", &hop1),
+			route(200, "10.0.0.0/25", &hop1),
+		})
+		Expect(routes).To(HaveLen(3))
+		Expect(routes[0].VNI).To(Equal(uint32(100)))
+		Expect(*routes[0].Spec.Prefix).To(Equal(netip.MustParsePrefix("10.0.0.0/24")))
+		Expect(*routes[0].Spec.NextHop.IP).To(Equal(hop1))
+		Expect(*routes[1].Spec.Prefix).To(Equal(netip.MustParsePrefix("10.0.1.0/24")))
+		Expect(routes[2].VNI).To(Equal(uint32(200)))
+	})
+
+	It("should validate that load balancer prefixes contain the vip", func() {
+		vip := netip.MustParseAddr("10.0.0.10")
+		lb := &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{LbVipIP: &vip}}
+		lbPrefix := func(s string) api.LoadBalancerPrefix {
+			return api.LoadBalancerPrefix{Spec: api.LoadBalancerPrefixSpec{Prefix: netip.MustParsePrefix(s)}}
+		}
+
+		Expect(ValidateLoadBalancerPrefixes(lb, []api.LoadBalancerPrefix{lbPrefix("10.0.1.0/24"), lbPrefix("10.0.0.0/28")})).To(Succeed())
+		Expect(ValidateLoadBalancerPrefixes(lb, []api.LoadBalancerPrefix{lbPrefix("10.0.1.0/24")})).To(
+			MatchError("vip 10.0.0.10 of load balancer lb1 is not contained in any of its prefixes"))
+	})
+})
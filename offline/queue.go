@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package offline buffers mutations while dpservice is unreachable and replays them in order
+// once it is reachable again, e.g. for edge deployments with flaky management links.
+//
+//	q := offline.New(c, offline.NewFileStore("/var/lib/agent/queue.json"))
+//	go q.Run(ctx, 10*time.Second)
+//	if _, err := q.Create(ctx, iface); err != nil && !errors.Is(err, offline.ErrQueued) {
+//		return err
+//	}
+//
+// Replays have ensure semantics: creating an object that already exists and deleting one that
+// does not are not errors, so a mutation applied by dpservice before the link failed can be
+// replayed safely. Existing objects are not compared to the queued ones.
+package offline
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// ErrQueued is returned by Queue.Create and Queue.Delete if the mutation was queued instead
+// of applied.
+var ErrQueued = stderrors.New("dpservice is unreachable, mutation queued")
+
+var (
+	alreadyExists = errors.Ignore(errors.ALREADY_EXISTS, errors.ROUTE_EXISTS, errors.SNAT_EXISTS, errors.DNAT_EXISTS)
+	notFound      = errors.Ignore(errors.NOT_FOUND, errors.NO_VM, errors.NO_LB, errors.SNAT_NO_DATA, errors.DNAT_NO_DATA, errors.ROUTE_NOT_FOUND)
+)
+
+// Queue applies mutations to dpservice, queueing them in a Store while dpservice is
+// unreachable. Once a mutation is queued, all following mutations are queued as well until
+// the queue is flushed, so they are applied in order.
+type Queue struct {
+	client client.Client
+	store  Store
+
+	mu sync.Mutex
+}
+
+// New creates a queue applying mutations with c and queueing them in store. Mutations left in
+// store, e.g. by a previous run of the agent, are replayed by the next Flush.
+func New(c client.Client, store Store) *Queue {
+	return &Queue{client: c, store: store}
+}
+
+// Create creates obj. If dpservice is unreachable or mutations are pending, the creation is
+// queued and ErrQueued returned.
+func (q *Queue) Create(ctx context.Context, obj api.Object) (api.Object, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	mutation := Mutation{Op: OpCreate, Object: obj}
+	if queued, err := q.enqueueIfPending(ctx, mutation); queued || err != nil {
+		return nil, err
+	}
+	res, err := client.NewResource[api.Object](q.client).Create(ctx, obj)
+	if isUnreachable(err) {
+		return nil, q.enqueue(ctx, mutation)
+	}
+	return res, err
+}
+
+// Delete deletes obj. If dpservice is unreachable or mutations are pending, the deletion is
+// queued and ErrQueued returned.
+func (q *Queue) Delete(ctx context.Context, obj api.Object, ignoredErrors ...[]uint32) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	mutation := Mutation{Op: OpDelete, Object: obj}
+	if queued, err := q.enqueueIfPending(ctx, mutation); queued || err != nil {
+		return err
+	}
+	err := client.NewResource[api.Object](q.client).Delete(ctx, obj, ignoredErrors...)
+	if isUnreachable(err) {
+		return q.enqueue(ctx, mutation)
+	}
+	return err
+}
+
+// Pending returns the queued mutations in the order they are replayed.
+func (q *Queue) Pending(ctx context.Context) ([]Mutation, error) {
+	return q.store.List(ctx)
+}
+
+// Flush replays the queued mutations in order. It stops at the first mutation failing because
+// dpservice is unreachable, returning that error. Mutations failing otherwise are dropped, as
+// retrying them would block the queue forever; they are returned as an errors.Aggregate keyed
+// by "<kind>/<ID>".
+func (q *Queue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	mutations, err := q.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing queued mutations: %w", err)
+	}
+	agg := &errors.Aggregate{}
+	for _, mutation := range mutations {
+		err := q.replay(ctx, mutation)
+		if isUnreachable(err) {
+			return err
+		}
+		if err != nil {
+			agg.Add(mutationKey(mutation), err)
+		}
+		if err := q.store.DropFirst(ctx); err != nil {
+			return fmt.Errorf("error dropping replayed mutation: %w", err)
+		}
+	}
+	return agg.ErrorOrNil()
+}
+
+// Run flushes the queue every interval until ctx is done. Errors are retried by the next flush.
+func (q *Queue) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_ = q.Flush(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *Queue) replay(ctx context.Context, mutation Mutation) error {
+	objs := client.NewResource[api.Object](q.client)
+	switch mutation.Op {
+	case OpCreate:
+		_, err := objs.Create(ctx, mutation.Object, alreadyExists)
+		return err
+	case OpDelete:
+		return objs.Delete(ctx, mutation.Object, notFound)
+	default:
+		return fmt.Errorf("unknown operation %q", mutation.Op)
+	}
+}
+
+// enqueueIfPending queues mutation if other mutations are pending, reporting whether it did.
+func (q *Queue) enqueueIfPending(ctx context.Context, mutation Mutation) (bool, error) {
+	pending, err := q.store.List(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error listing queued mutations: %w", err)
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+	return true, q.enqueue(ctx, mutation)
+}
+
+func (q *Queue) enqueue(ctx context.Context, mutation Mutation) error {
+	if err := q.store.Append(ctx, mutation); err != nil {
+		return fmt.Errorf("error queueing %s of %s: %w", mutation.Op, mutation.Object.GetID(), err)
+	}
+	return ErrQueued
+}
+
+func mutationKey(mutation Mutation) string {
+	kind, err := api.DefaultScheme.KindOf(mutation.Object)
+	if err != nil {
+		kind = mutation.Object.GetKind()
+	}
+	return kind + "/" + mutation.Object.GetID()
+}
+
+// isUnreachable reports whether err indicates dpservice could not be reached.
+func isUnreachable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package offline
+
+import (
+	"context"
+	"net/netip"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("Queue", func() {
+	ctx := context.TODO()
+	ipv4 := netip.MustParseAddr("10.200.1.1")
+	ipv6 := netip.MustParseAddr("2001:db8:200::1")
+
+	newInterface := func(id string) *api.Interface {
+		return &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: id},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap_" + id},
+		}
+	}
+	newPrefix := func(interfaceID string) *api.Prefix {
+		return &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: interfaceID},
+			Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.200.2.0/24")},
+		}
+	}
+
+	It("should apply mutations directly while dpservice is reachable", func() {
+		q := New(simClient, NewMemoryStore())
+		res, err := q.Create(ctx, newInterface("vm1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.GetID()).To(Equal("vm1"))
+		Expect(q.Pending(ctx)).To(BeEmpty())
+
+		Expect(q.Delete(ctx, newInterface("vm1"))).To(Succeed())
+		_, err = simClient.GetInterface(ctx, "vm1")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+	})
+
+	It("should queue mutations while dpservice is unreachable and replay them in order", func() {
+		q := New(simClient, NewMemoryStore())
+		unreachable.Store(true)
+		_, err := q.Create(ctx, newInterface("vm1"))
+		Expect(err).To(MatchError(ErrQueued))
+
+		unreachable.Store(false)
+		_, err = q.Create(ctx, newPrefix("vm1"))
+		Expect(err).To(MatchError(ErrQueued), "mutations must stay in order while others are pending")
+		Expect(q.Pending(ctx)).To(HaveLen(2))
+
+		Expect(q.Flush(ctx)).To(Succeed())
+		Expect(q.Pending(ctx)).To(BeEmpty())
+		prefixes, err := simClient.ListPrefixes(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes.Items).To(HaveLen(1))
+	})
+
+	It("should replay mutations idempotently", func() {
+		q := New(simClient, NewMemoryStore())
+		_, err := simClient.CreateInterface(ctx, newInterface("vm1"))
+		Expect(err).NotTo(HaveOccurred())
+
+		unreachable.Store(true)
+		_, err = q.Create(ctx, newInterface("vm1"))
+		Expect(err).To(MatchError(ErrQueued))
+		Expect(q.Delete(ctx, newInterface("vm2"))).To(MatchError(ErrQueued))
+		Expect(q.Flush(ctx)).To(MatchError(ContainSubstring("connection refused")))
+		Expect(q.Pending(ctx)).To(HaveLen(2))
+
+		unreachable.Store(false)
+		Expect(q.Flush(ctx)).To(Succeed())
+		Expect(q.Pending(ctx)).To(BeEmpty())
+	})
+
+	It("should drop mutations failing for other reasons", func() {
+		q := New(simClient, NewMemoryStore())
+		unreachable.Store(true)
+		_, err := q.Create(ctx, newPrefix("vm1"))
+		Expect(err).To(MatchError(ErrQueued))
+		_, err = q.Create(ctx, newInterface("vm2"))
+		Expect(err).To(MatchError(ErrQueued))
+
+		unreachable.Store(false)
+		err = q.Flush(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*errors.Aggregate).IDs()).To(ConsistOf("Prefix/vm1/10.200.2.0/24"))
+		Expect(q.Pending(ctx)).To(BeEmpty())
+		_, err = simClient.GetInterface(ctx, "vm2")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should persist mutations in a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "queue.json")
+		unreachable.Store(true)
+		_, err := New(simClient, NewFileStore(path)).Create(ctx, newInterface("vm1"))
+		Expect(err).To(MatchError(ErrQueued))
+
+		unreachable.Store(false)
+		q := New(simClient, NewFileStore(path))
+		pending, err := q.Pending(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+		Expect(pending[0].Op).To(Equal(OpCreate))
+		Expect(pending[0].Object).To(BeAssignableToTypeOf(&api.Interface{}))
+
+		Expect(q.Flush(ctx)).To(Succeed())
+		Expect(NewFileStore(path).List(ctx)).To(BeEmpty())
+		_, err = simClient.GetInterface(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
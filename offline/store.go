@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// Op is the operation of a Mutation.
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpDelete Op = "delete"
+)
+
+// Mutation is a queued creation or deletion of an object.
+type Mutation struct {
+	Op     Op
+	Object api.Object
+}
+
+type encodedMutation struct {
+	Op     Op              `json:"op"`
+	Kind   string          `json:"kind"`
+	Object json.RawMessage `json:"object"`
+}
+
+func (m Mutation) MarshalJSON() ([]byte, error) {
+	kind, err := api.DefaultScheme.KindOf(m.Object)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := json.Marshal(m.Object)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encodedMutation{Op: m.Op, Kind: kind, Object: obj})
+}
+
+func (m *Mutation) UnmarshalJSON(data []byte) error {
+	var encoded encodedMutation
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	obj, err := api.DefaultScheme.New(encoded.Kind)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(encoded.Object, obj); err != nil {
+		return fmt.Errorf("error decoding %s: %w", encoded.Kind, err)
+	}
+	m.Op, m.Object = encoded.Op, obj
+	return nil
+}
+
+// Store persists the queued mutations in order.
+type Store interface {
+	// Append adds mutation to the end of the queue.
+	Append(ctx context.Context, mutation Mutation) error
+	// List returns the queued mutations, the oldest first.
+	List(ctx context.Context) ([]Mutation, error)
+	// DropFirst removes the oldest mutation. Dropping from an empty queue is not an error.
+	DropFirst(ctx context.Context) error
+}
+
+// MemoryStore is a Store keeping the mutations in memory, for tests or agents that rebuild
+// their desired state after a restart anyway.
+type MemoryStore struct {
+	mu        sync.Mutex
+	mutations []Mutation
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(_ context.Context, mutation Mutation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mutations = append(s.mutations, mutation)
+	return nil
+}
+
+func (s *MemoryStore) List(context.Context) ([]Mutation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Mutation(nil), s.mutations...), nil
+}
+
+func (s *MemoryStore) DropFirst(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.mutations) > 0 {
+		s.mutations = s.mutations[1:]
+	}
+	return nil
+}
+
+// FileStore is a Store persisting the mutations in a JSON file, so they survive restarts of
+// the agent. The file is rewritten atomically on every change. A store must only be used by
+// one process at a time.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a store persisting the mutations in the file at path. A missing file
+// is treated as an empty queue and created on the first change.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Append(_ context.Context, mutation Mutation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mutations, err := s.read()
+	if err != nil {
+		return err
+	}
+	return s.write(append(mutations, mutation))
+}
+
+func (s *FileStore) List(context.Context) ([]Mutation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read()
+}
+
+func (s *FileStore) DropFirst(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mutations, err := s.read()
+	if err != nil || len(mutations) == 0 {
+		return err
+	}
+	return s.write(mutations[1:])
+}
+
+func (s *FileStore) read() ([]Mutation, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading mutation queue: %w", err)
+	}
+	var mutations []Mutation
+	if err := json.Unmarshal(data, &mutations); err != nil {
+		return nil, fmt.Errorf("error decoding mutation queue %s: %w", s.path, err)
+	}
+	return mutations, nil
+}
+
+func (s *FileStore) write(mutations []Mutation) error {
+	if mutations == nil {
+		mutations = []Mutation{}
+	}
+	data, err := json.MarshalIndent(mutations, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing mutation queue: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing mutation queue: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing mutation queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing mutation queue: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error writing mutation queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package offline
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var (
+	sim       *simulator.Simulator
+	simClient *client.ConnectedClient
+	// unreachable makes every call of simClient fail as if dpservice was unreachable.
+	unreachable atomic.Bool
+)
+
+func TestOffline(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Offline Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	sim, err = simulator.Start("")
+	Expect(err).NotTo(HaveOccurred())
+
+	simClient, err = client.Dial(context.TODO(), sim.Addr(), client.WithUnaryInterceptors(
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if unreachable.Load() {
+				return status.Error(codes.Unavailable, "connection refused")
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}))
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = BeforeEach(func() {
+	unreachable.Store(false)
+	sim.Restart()
+	_, err := client.EnsureInitialized(context.TODO(), simClient)
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	if simClient != nil {
+		Expect(simClient.Close()).To(Succeed())
+	}
+	if sim != nil {
+		sim.Stop()
+	}
+})
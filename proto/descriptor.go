@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dpdkproto
+
+import (
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FileDescriptorSet returns the compiled descriptors of the dpservice proto and the files it
+// imports, dependencies first, e.g. for grpcurl -protoset or schema diffs against the
+// descriptors a server reports.
+func FileDescriptorSet() *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(File_proto_dpdk_proto)
+	return set
+}
+
+// RegisterReflection registers the gRPC server reflection service on s, so dynamic tooling
+// like grpcurl can call a dpservice implementation, e.g. the simulator, without the proto
+// files. The dpservice descriptors are registered with the global registry by this package.
+func RegisterReflection(s reflection.GRPCServer) {
+	reflection.Register(s)
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ribexport writes the routing table of a VNI in formats understood by external
+// routing analysis tools.
+package ribexport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// WriteIPRoute writes routes in the text format of "ip route", one route per line, e.g.
+//
+//	10.0.1.0/24 encap ip6 id 200 dst fc00::1 table 100
+//
+// The table is the VNI of the routes, the encap id the VNI of the next hop.
+func WriteIPRoute(w io.Writer, routes *api.RouteList) error {
+	bw := bufio.NewWriter(w)
+	for _, route := range routes.Items {
+		if route.Spec.Prefix == nil || route.Spec.NextHop == nil || route.Spec.NextHop.IP == nil {
+			return fmt.Errorf("incomplete route %s", route.String())
+		}
+		encap := "ip"
+		if route.Spec.NextHop.IP.Is6() {
+			encap = "ip6"
+		}
+		fmt.Fprintf(bw, "%s encap %s id %d dst %s table %d\n",
+			route.Spec.Prefix, encap, route.Spec.NextHop.VNI, route.Spec.NextHop.IP, routes.VNI)
+	}
+	return bw.Flush()
+}
+
+// MRT record types and subtypes of RFC 6396.
+const (
+	mrtTypeTableDumpV2    = 13
+	mrtSubtypePeerIndex   = 1
+	mrtSubtypeRIBIPv4     = 2
+	mrtSubtypeRIBIPv6     = 4
+	mrtPeerTypeAS4        = 0x02
+	bgpAttrFlagTransitive = 0x40
+	bgpAttrFlagOptional   = 0x80
+	bgpAttrOrigin         = 1
+	bgpAttrASPath         = 2
+	bgpAttrNextHop        = 3
+	bgpAttrMPReachNLRI    = 14
+	bgpOriginIncomplete   = 2
+)
+
+// WriteMRT writes routes as MRT TABLE_DUMP_V2 records (RFC 6396), as read by bgpdump and
+// similar tools. The dump has a single peer standing for dpservice and the view name
+// "vni-<VNI>". Next hops are written as BGP next hops, their VNIs are not part of the dump.
+func WriteMRT(w io.Writer, routes *api.RouteList, timestamp time.Time) error {
+	ts := uint32(timestamp.Unix())
+	bw := bufio.NewWriter(w)
+
+	viewName := fmt.Sprintf("vni-%d", routes.VNI)
+	var peerIndex []byte
+	peerIndex = binary.BigEndian.AppendUint32(peerIndex, 0) // collector BGP ID
+	peerIndex = binary.BigEndian.AppendUint16(peerIndex, uint16(len(viewName)))
+	peerIndex = append(peerIndex, viewName...)
+	peerIndex = binary.BigEndian.AppendUint16(peerIndex, 1) // peer count
+	peerIndex = append(peerIndex, mrtPeerTypeAS4)           // IPv4 peer address, 4 byte AS
+	peerIndex = binary.BigEndian.AppendUint32(peerIndex, 0) // peer BGP ID
+	peerIndex = append(peerIndex, 0, 0, 0, 0)               // peer address
+	peerIndex = binary.BigEndian.AppendUint32(peerIndex, 0) // peer AS
+	writeMRTRecord(bw, ts, mrtSubtypePeerIndex, peerIndex)
+
+	for i, route := range routes.Items {
+		if route.Spec.Prefix == nil || route.Spec.NextHop == nil || route.Spec.NextHop.IP == nil {
+			return fmt.Errorf("incomplete route %s", route.String())
+		}
+		prefix := route.Spec.Prefix.Masked()
+		subtype := uint16(mrtSubtypeRIBIPv6)
+		if prefix.Addr().Is4() {
+			subtype = mrtSubtypeRIBIPv4
+		}
+
+		var rib []byte
+		rib = binary.BigEndian.AppendUint32(rib, uint32(i)) // sequence number
+		rib = append(rib, byte(prefix.Bits()))
+		rib = append(rib, prefix.Addr().AsSlice()[:(prefix.Bits()+7)/8]...)
+		rib = binary.BigEndian.AppendUint16(rib, 1) // entry count
+		rib = binary.BigEndian.AppendUint16(rib, 0) // peer index
+		rib = binary.BigEndian.AppendUint32(rib, ts)
+		attrs := bgpAttributes(route)
+		rib = binary.BigEndian.AppendUint16(rib, uint16(len(attrs)))
+		rib = append(rib, attrs...)
+		writeMRTRecord(bw, ts, subtype, rib)
+	}
+	return bw.Flush()
+}
+
+func writeMRTRecord(w *bufio.Writer, timestamp uint32, subtype uint16, body []byte) {
+	var header []byte
+	header = binary.BigEndian.AppendUint32(header, timestamp)
+	header = binary.BigEndian.AppendUint16(header, mrtTypeTableDumpV2)
+	header = binary.BigEndian.AppendUint16(header, subtype)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(body)))
+	_, _ = w.Write(header)
+	_, _ = w.Write(body)
+}
+
+// bgpAttributes returns the BGP path attributes of route. IPv4 next hops of IPv4 prefixes
+// use NEXT_HOP, all others the abbreviated MP_REACH_NLRI of RFC 6396 section 4.3.4.
+func bgpAttributes(route api.Route) []byte {
+	attrs := []byte{
+		bgpAttrFlagTransitive, bgpAttrOrigin, 1, bgpOriginIncomplete,
+		bgpAttrFlagTransitive, bgpAttrASPath, 0,
+	}
+	nextHop := route.Spec.NextHop.IP.AsSlice()
+	if route.Spec.Prefix.Addr().Is4() && route.Spec.NextHop.IP.Is4() {
+		attrs = append(attrs, bgpAttrFlagTransitive, bgpAttrNextHop, byte(len(nextHop)))
+		return append(attrs, nextHop...)
+	}
+	attrs = append(attrs, bgpAttrFlagOptional, bgpAttrMPReachNLRI, byte(1+len(nextHop)), byte(len(nextHop)))
+	return append(attrs, nextHop...)
+}
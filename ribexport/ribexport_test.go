@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ribexport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/netip"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+var _ = Describe("RIB export", func() {
+	route := func(prefix, nextHop string, nextHopVNI uint32) api.Route {
+		p := netip.MustParsePrefix(prefix)
+		nh := netip.MustParseAddr(nextHop)
+		return api.Route{
+			RouteMeta: api.RouteMeta{VNI: 100},
+			Spec:      api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: nextHopVNI, IP: &nh}},
+		}
+	}
+	routes := &api.RouteList{
+		RouteListMeta: api.RouteListMeta{VNI: 100},
+		Items: []api.Route{
+			route("10.0.1.0/24", "fc00::1", 200),
+			route("2001:db8::/64", "fc00::2", 100),
+		},
+	}
+
+	It("should write routes in ip route format", func() {
+		var buf bytes.Buffer
+		Expect(WriteIPRoute(&buf, routes)).To(Succeed())
+		Expect(buf.String()).To(Equal(
+			"10.0.1.0/24 encap ip6 id 200 dst fc00::1 table 100\n" +
+				"2001:db8::/64 encap ip6 id 100 dst fc00::2 table 100\n"))
+	})
+
+	It("should reject incomplete routes", func() {
+		var buf bytes.Buffer
+		Expect(WriteIPRoute(&buf, &api.RouteList{Items: []api.Route{{}}})).NotTo(Succeed())
+		Expect(WriteMRT(&buf, &api.RouteList{Items: []api.Route{{}}}, time.Now())).NotTo(Succeed())
+	})
+
+	It("should write routes as MRT TABLE_DUMP_V2 records", func() {
+		var buf bytes.Buffer
+		timestamp := time.Unix(1700000000, 0)
+		Expect(WriteMRT(&buf, routes, timestamp)).To(Succeed())
+
+		type record struct {
+			subtype uint16
+			body    []byte
+		}
+		var records []record
+		data := buf.Bytes()
+		for len(data) > 0 {
+			Expect(len(data)).To(BeNumerically(">=", 12))
+			Expect(binary.BigEndian.Uint32(data)).To(BeEquivalentTo(1700000000))
+			Expect(binary.BigEndian.Uint16(data[4:])).To(BeEquivalentTo(mrtTypeTableDumpV2))
+			length := binary.BigEndian.Uint32(data[8:])
+			records = append(records, record{subtype: binary.BigEndian.Uint16(data[6:]), body: data[12 : 12+length]})
+			data = data[12+length:]
+		}
+		Expect(records).To(HaveLen(3))
+
+		Expect(records[0].subtype).To(BeEquivalentTo(mrtSubtypePeerIndex))
+		Expect(string(records[0].body[6:13])).To(Equal("vni-100"))
+
+		Expect(records[1].subtype).To(BeEquivalentTo(mrtSubtypeRIBIPv4))
+		ipv4 := records[1].body
+		Expect(ipv4[4]).To(BeEquivalentTo(24))
+		Expect(ipv4[5:8]).To(Equal([]byte{10, 0, 1}))
+		Expect(binary.BigEndian.Uint16(ipv4[8:])).To(BeEquivalentTo(1))
+		attrs := ipv4[18:]
+		Expect(binary.BigEndian.Uint16(ipv4[16:])).To(BeEquivalentTo(len(attrs)))
+		nextHop := netip.MustParseAddr("fc00::1").As16()
+		Expect(attrs[7:11]).To(Equal([]byte{bgpAttrFlagOptional, bgpAttrMPReachNLRI, 17, 16}))
+		Expect(attrs[11:]).To(Equal(nextHop[:]))
+
+		Expect(records[2].subtype).To(BeEquivalentTo(mrtSubtypeRIBIPv6))
+		Expect(records[2].body[4]).To(BeEquivalentTo(64))
+		Expect(binary.BigEndian.Uint32(records[2].body)).To(BeEquivalentTo(1))
+	})
+})
@@ -0,0 +1,360 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+func (s *Server) ListInterfaces(context.Context, *dpdkproto.ListInterfacesRequest) (*dpdkproto.ListInterfacesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := &dpdkproto.ListInterfacesResponse{Status: ok()}
+	for _, i := range s.interfaces {
+		res.Interfaces = append(res.Interfaces, clone(i.proto))
+	}
+	return res, nil
+}
+
+func (s *Server) GetInterface(_ context.Context, req *dpdkproto.GetInterfaceRequest) (*dpdkproto.GetInterfaceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.GetInterfaceResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	return &dpdkproto.GetInterfaceResponse{Status: ok(), Interface: clone(i.proto)}, nil
+}
+
+func (s *Server) CreateInterface(_ context.Context, req *dpdkproto.CreateInterfaceRequest) (*dpdkproto.CreateInterfaceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := string(req.GetInterfaceId())
+	if id == "" {
+		return nil, invalid("interface_id")
+	}
+	if addr, err := netip.ParseAddr(string(req.GetIpv4Config().GetPrimaryAddress())); err != nil || !addr.Is4() {
+		return nil, invalid("ipv4_config.primary_address")
+	}
+	if addr, err := netip.ParseAddr(string(req.GetIpv6Config().GetPrimaryAddress())); err != nil || !addr.Is6() {
+		return nil, invalid("ipv6_config.primary_address")
+	}
+	vfName := req.GetDeviceName()
+	if vfName == "" {
+		return nil, invalid("device_name")
+	}
+	if _, found := s.interfaces[id]; found {
+		return &dpdkproto.CreateInterfaceResponse{Status: fail(errors.ALREADY_EXISTS)}, nil
+	}
+
+	underlayRoute := s.allocateUnderlay()
+	s.interfaces[id] = &iface{
+		proto: &dpdkproto.Interface{
+			Id:             []byte(id),
+			Vni:            req.GetVni(),
+			PrimaryIpv4:    req.GetIpv4Config().GetPrimaryAddress(),
+			PrimaryIpv6:    req.GetIpv6Config().GetPrimaryAddress(),
+			UnderlayRoute:  underlayRoute,
+			PciName:        vfName,
+			MeteringParams: meteringParams(vfName, req.GetMeteringParameters()),
+		},
+		vfName:     vfName,
+		prefixes:   make(map[string]*dpdkproto.Prefix),
+		lbPrefixes: make(map[string]*dpdkproto.Prefix),
+		rules:      make(map[string]*dpdkproto.FirewallRule),
+	}
+	s.useVni(req.GetVni())
+	return &dpdkproto.CreateInterfaceResponse{
+		Status:        ok(),
+		UnderlayRoute: underlayRoute,
+		Vf:            &dpdkproto.VirtualFunction{Name: vfName},
+	}, nil
+}
+
+func (s *Server) DeleteInterface(_ context.Context, req *dpdkproto.DeleteInterfaceRequest) (*dpdkproto.DeleteInterfaceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := string(req.GetInterfaceId())
+	i, found := s.interfaces[id]
+	if !found {
+		return &dpdkproto.DeleteInterfaceResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	delete(s.interfaces, id)
+	s.releaseVni(i.proto.GetVni())
+	return &dpdkproto.DeleteInterfaceResponse{Status: ok()}, nil
+}
+
+func (s *Server) ListPrefixes(_ context.Context, req *dpdkproto.ListPrefixesRequest) (*dpdkproto.ListPrefixesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.ListPrefixesResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	res := &dpdkproto.ListPrefixesResponse{Status: ok()}
+	for _, prefix := range i.prefixes {
+		res.Prefixes = append(res.Prefixes, clone(prefix))
+	}
+	return res, nil
+}
+
+func (s *Server) CreatePrefix(_ context.Context, req *dpdkproto.CreatePrefixRequest) (*dpdkproto.CreatePrefixResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	underlayRoute, status, err := s.createPrefix(req.GetInterfaceId(), req.GetPrefix(), errors.ROUTE_EXISTS, func(i *iface) map[string]*dpdkproto.Prefix { return i.prefixes })
+	if err != nil {
+		return nil, err
+	}
+	return &dpdkproto.CreatePrefixResponse{Status: status, UnderlayRoute: underlayRoute}, nil
+}
+
+func (s *Server) DeletePrefix(_ context.Context, req *dpdkproto.DeletePrefixRequest) (*dpdkproto.DeletePrefixResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.deletePrefix(req.GetInterfaceId(), req.GetPrefix(), errors.ROUTE_NOT_FOUND, func(i *iface) map[string]*dpdkproto.Prefix { return i.prefixes })
+	return &dpdkproto.DeletePrefixResponse{Status: status}, nil
+}
+
+func (s *Server) ListLoadBalancerPrefixes(_ context.Context, req *dpdkproto.ListLoadBalancerPrefixesRequest) (*dpdkproto.ListLoadBalancerPrefixesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.ListLoadBalancerPrefixesResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	res := &dpdkproto.ListLoadBalancerPrefixesResponse{Status: ok()}
+	for _, prefix := range i.lbPrefixes {
+		res.Prefixes = append(res.Prefixes, clone(prefix))
+	}
+	return res, nil
+}
+
+func (s *Server) CreateLoadBalancerPrefix(_ context.Context, req *dpdkproto.CreateLoadBalancerPrefixRequest) (*dpdkproto.CreateLoadBalancerPrefixResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	underlayRoute, status, err := s.createPrefix(req.GetInterfaceId(), req.GetPrefix(), errors.ALREADY_EXISTS, func(i *iface) map[string]*dpdkproto.Prefix { return i.lbPrefixes })
+	if err != nil {
+		return nil, err
+	}
+	return &dpdkproto.CreateLoadBalancerPrefixResponse{Status: status, UnderlayRoute: underlayRoute}, nil
+}
+
+func (s *Server) DeleteLoadBalancerPrefix(_ context.Context, req *dpdkproto.DeleteLoadBalancerPrefixRequest) (*dpdkproto.DeleteLoadBalancerPrefixResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.deletePrefix(req.GetInterfaceId(), req.GetPrefix(), errors.NOT_FOUND, func(i *iface) map[string]*dpdkproto.Prefix { return i.lbPrefixes })
+	return &dpdkproto.DeleteLoadBalancerPrefixResponse{Status: status}, nil
+}
+
+// createPrefix adds prefix to the interface, failing with existsCode if it is already there.
+func (s *Server) createPrefix(interfaceID []byte, prefix *dpdkproto.Prefix, existsCode uint32, prefixes func(*iface) map[string]*dpdkproto.Prefix) ([]byte, *dpdkproto.Status, error) {
+	if len(interfaceID) == 0 {
+		return nil, nil, invalid("interface_id")
+	}
+	if !validPrefix(prefix) {
+		return nil, nil, invalid("prefix.ip")
+	}
+	i, found := s.interfaces[string(interfaceID)]
+	if !found {
+		return nil, fail(errors.NO_VM), nil
+	}
+	key := prefixKey(prefix)
+	if _, found := prefixes(i)[key]; found {
+		return nil, fail(existsCode), nil
+	}
+	underlayRoute := s.allocateUnderlay()
+	prefixes(i)[key] = &dpdkproto.Prefix{Ip: clone(prefix.GetIp()), Length: prefix.GetLength(), UnderlayRoute: underlayRoute}
+	return underlayRoute, ok(), nil
+}
+
+// deletePrefix removes prefix from the interface, failing with notFoundCode if it is not there.
+func (s *Server) deletePrefix(interfaceID []byte, prefix *dpdkproto.Prefix, notFoundCode uint32, prefixes func(*iface) map[string]*dpdkproto.Prefix) *dpdkproto.Status {
+	i, found := s.interfaces[string(interfaceID)]
+	if !found {
+		return fail(errors.NO_VM)
+	}
+	key := prefixKey(prefix)
+	if _, found := prefixes(i)[key]; !found {
+		return fail(notFoundCode)
+	}
+	delete(prefixes(i), key)
+	return ok()
+}
+
+func (s *Server) CreateVip(_ context.Context, req *dpdkproto.CreateVipRequest) (*dpdkproto.CreateVipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !validAddr(req.GetVipIp()) {
+		return nil, invalid("vip_ip")
+	}
+	if len(req.GetInterfaceId()) == 0 {
+		return nil, invalid("interface_id")
+	}
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.CreateVipResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.vip != nil {
+		return &dpdkproto.CreateVipResponse{Status: fail(errors.SNAT_EXISTS)}, nil
+	}
+	underlayRoute := s.allocateUnderlay()
+	i.vip = &dpdkproto.GetVipResponse{VipIp: clone(req.GetVipIp()), UnderlayRoute: underlayRoute}
+	return &dpdkproto.CreateVipResponse{Status: ok(), UnderlayRoute: underlayRoute}, nil
+}
+
+func (s *Server) GetVip(_ context.Context, req *dpdkproto.GetVipRequest) (*dpdkproto.GetVipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.GetVipResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.vip == nil {
+		return &dpdkproto.GetVipResponse{Status: fail(errors.SNAT_NO_DATA)}, nil
+	}
+	res := clone(i.vip)
+	res.Status = ok()
+	return res, nil
+}
+
+func (s *Server) DeleteVip(_ context.Context, req *dpdkproto.DeleteVipRequest) (*dpdkproto.DeleteVipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.DeleteVipResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.vip == nil {
+		return &dpdkproto.DeleteVipResponse{Status: fail(errors.SNAT_NO_DATA)}, nil
+	}
+	i.vip = nil
+	return &dpdkproto.DeleteVipResponse{Status: ok()}, nil
+}
+
+func (s *Server) ListFirewallRules(_ context.Context, req *dpdkproto.ListFirewallRulesRequest) (*dpdkproto.ListFirewallRulesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.ListFirewallRulesResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	res := &dpdkproto.ListFirewallRulesResponse{Status: ok()}
+	for _, rule := range i.rules {
+		res.Rules = append(res.Rules, clone(rule))
+	}
+	return res, nil
+}
+
+func (s *Server) CreateFirewallRule(_ context.Context, req *dpdkproto.CreateFirewallRuleRequest) (*dpdkproto.CreateFirewallRuleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(req.GetInterfaceId()) == 0 {
+		return nil, invalid("interface_id")
+	}
+	rule := req.GetRule()
+	if err := validateFirewallRule(rule); err != nil {
+		return nil, err
+	}
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.CreateFirewallRuleResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	id := string(rule.GetId())
+	if _, found := i.rules[id]; found {
+		return &dpdkproto.CreateFirewallRuleResponse{Status: fail(errors.ALREADY_EXISTS), RuleId: []byte(id)}, nil
+	}
+	i.rules[id] = clone(rule)
+	return &dpdkproto.CreateFirewallRuleResponse{Status: ok(), RuleId: []byte(id)}, nil
+}
+
+func (s *Server) GetFirewallRule(_ context.Context, req *dpdkproto.GetFirewallRuleRequest) (*dpdkproto.GetFirewallRuleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.GetFirewallRuleResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	rule, found := i.rules[string(req.GetRuleId())]
+	if !found {
+		return &dpdkproto.GetFirewallRuleResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	return &dpdkproto.GetFirewallRuleResponse{Status: ok(), Rule: clone(rule)}, nil
+}
+
+func (s *Server) DeleteFirewallRule(_ context.Context, req *dpdkproto.DeleteFirewallRuleRequest) (*dpdkproto.DeleteFirewallRuleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.DeleteFirewallRuleResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if _, found := i.rules[string(req.GetRuleId())]; !found {
+		return &dpdkproto.DeleteFirewallRuleResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	delete(i.rules, string(req.GetRuleId()))
+	return &dpdkproto.DeleteFirewallRuleResponse{Status: ok()}, nil
+}
+
+func validateFirewallRule(rule *dpdkproto.FirewallRule) error {
+	if len(rule.GetId()) == 0 {
+		return invalid("rule id")
+	}
+	if !validPrefix(rule.GetSourcePrefix()) {
+		return invalid("source_prefix")
+	}
+	if !validPrefix(rule.GetDestinationPrefix()) {
+		return invalid("destination_prefix")
+	}
+	switch filter := rule.GetProtocolFilter().GetFilter().(type) {
+	case *dpdkproto.ProtocolFilter_Tcp:
+		tcp := filter.Tcp
+		return validatePortFilter("tcp", tcp.GetSrcPortLower(), tcp.GetSrcPortUpper(), tcp.GetDstPortLower(), tcp.GetDstPortUpper())
+	case *dpdkproto.ProtocolFilter_Udp:
+		udp := filter.Udp
+		return validatePortFilter("udp", udp.GetSrcPortLower(), udp.GetSrcPortUpper(), udp.GetDstPortLower(), udp.GetDstPortUpper())
+	case *dpdkproto.ProtocolFilter_Icmp:
+		if t := filter.Icmp.GetIcmpType(); t < -1 || t > 255 {
+			return invalid("icmp.icmp_type")
+		}
+		if c := filter.Icmp.GetIcmpCode(); c < -1 || c > 255 {
+			return invalid("icmp.icmp_code")
+		}
+	}
+	return nil
+}
+
+func validatePortFilter(protocol string, srcLower, srcUpper, dstLower, dstUpper int32) error {
+	for _, ports := range []struct {
+		name         string
+		lower, upper int32
+	}{
+		{"src_port", srcLower, srcUpper},
+		{"dst_port", dstLower, dstUpper},
+	} {
+		switch {
+		case !validPort(ports.lower):
+			return invalid(fmt.Sprintf("%s.%s_lower", protocol, ports.name))
+		case !validPort(ports.upper):
+			return invalid(fmt.Sprintf("%s.%s_upper", protocol, ports.name))
+		case ports.lower > ports.upper:
+			return invalid(fmt.Sprintf("%s.%s range", protocol, ports.name))
+		}
+	}
+	return nil
+}
+
+// meteringParams returns the metering dpservice applies to device. Like dpservice, metering
+// only takes effect on virtual functions and is ignored for TAP devices.
+func meteringParams(device string, params *dpdkproto.MeteringParams) *dpdkproto.MeteringParams {
+	if strings.HasPrefix(device, "net_tap") {
+		return &dpdkproto.MeteringParams{}
+	}
+	return clone(params)
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+func (s *Server) CreateLoadBalancer(_ context.Context, req *dpdkproto.CreateLoadBalancerRequest) (*dpdkproto.CreateLoadBalancerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := string(req.GetLoadbalancerId())
+	if id == "" {
+		return &dpdkproto.CreateLoadBalancerResponse{Status: fail(errors.BAD_REQUEST)}, nil
+	}
+	if !validAddr(req.GetLoadbalancedIp()) {
+		return &dpdkproto.CreateLoadBalancerResponse{Status: fail(errors.BAD_IPVER)}, nil
+	}
+	if _, found := s.loadBalancers[id]; found {
+		return &dpdkproto.CreateLoadBalancerResponse{Status: fail(errors.ALREADY_EXISTS)}, nil
+	}
+	for _, port := range req.GetLoadbalancedPorts() {
+		if port.GetPort() == 0 || port.GetPort() > 65535 {
+			return &dpdkproto.CreateLoadBalancerResponse{Status: fail(errors.ROUTE_BAD_PORT)}, nil
+		}
+	}
+
+	underlayRoute := s.allocateUnderlay()
+	lb := &dpdkproto.GetLoadBalancerResponse{
+		LoadbalancedIp: clone(req.GetLoadbalancedIp()),
+		Vni:            req.GetVni(),
+		UnderlayRoute:  underlayRoute,
+	}
+	for _, port := range req.GetLoadbalancedPorts() {
+		lb.LoadbalancedPorts = append(lb.LoadbalancedPorts, clone(port))
+	}
+	s.loadBalancers[id] = &loadBalancer{proto: lb, targets: make(map[string]*dpdkproto.IpAddress)}
+	s.useVni(req.GetVni())
+	return &dpdkproto.CreateLoadBalancerResponse{Status: ok(), UnderlayRoute: underlayRoute}, nil
+}
+
+func (s *Server) GetLoadBalancer(_ context.Context, req *dpdkproto.GetLoadBalancerRequest) (*dpdkproto.GetLoadBalancerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, found := s.loadBalancers[string(req.GetLoadbalancerId())]
+	if !found {
+		return &dpdkproto.GetLoadBalancerResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	res := clone(lb.proto)
+	res.Status = ok()
+	return res, nil
+}
+
+func (s *Server) DeleteLoadBalancer(_ context.Context, req *dpdkproto.DeleteLoadBalancerRequest) (*dpdkproto.DeleteLoadBalancerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := string(req.GetLoadbalancerId())
+	lb, found := s.loadBalancers[id]
+	if !found {
+		return &dpdkproto.DeleteLoadBalancerResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	delete(s.loadBalancers, id)
+	s.releaseVni(lb.proto.GetVni())
+	return &dpdkproto.DeleteLoadBalancerResponse{Status: ok()}, nil
+}
+
+func (s *Server) CreateLoadBalancerTarget(_ context.Context, req *dpdkproto.CreateLoadBalancerTargetRequest) (*dpdkproto.CreateLoadBalancerTargetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, found := s.loadBalancers[string(req.GetLoadbalancerId())]
+	if !found {
+		return &dpdkproto.CreateLoadBalancerTargetResponse{Status: fail(errors.NO_LB)}, nil
+	}
+	if !validAddr(req.GetTargetIp()) {
+		return &dpdkproto.CreateLoadBalancerTargetResponse{Status: fail(errors.BAD_IPVER)}, nil
+	}
+	key := addrKey(req.GetTargetIp())
+	if _, found := lb.targets[key]; found {
+		return &dpdkproto.CreateLoadBalancerTargetResponse{Status: fail(errors.ALREADY_EXISTS)}, nil
+	}
+	lb.targets[key] = clone(req.GetTargetIp())
+	return &dpdkproto.CreateLoadBalancerTargetResponse{Status: ok()}, nil
+}
+
+func (s *Server) ListLoadBalancerTargets(_ context.Context, req *dpdkproto.ListLoadBalancerTargetsRequest) (*dpdkproto.ListLoadBalancerTargetsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, found := s.loadBalancers[string(req.GetLoadbalancerId())]
+	if !found {
+		return &dpdkproto.ListLoadBalancerTargetsResponse{Status: fail(errors.NO_LB)}, nil
+	}
+	res := &dpdkproto.ListLoadBalancerTargetsResponse{Status: ok()}
+	for _, target := range lb.targets {
+		res.TargetIps = append(res.TargetIps, clone(target))
+	}
+	return res, nil
+}
+
+func (s *Server) DeleteLoadBalancerTarget(_ context.Context, req *dpdkproto.DeleteLoadBalancerTargetRequest) (*dpdkproto.DeleteLoadBalancerTargetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb, found := s.loadBalancers[string(req.GetLoadbalancerId())]
+	if !found {
+		return &dpdkproto.DeleteLoadBalancerTargetResponse{Status: fail(errors.NO_LB)}, nil
+	}
+	key := addrKey(req.GetTargetIp())
+	if _, found := lb.targets[key]; !found {
+		return &dpdkproto.DeleteLoadBalancerTargetResponse{Status: fail(errors.NOT_FOUND)}, nil
+	}
+	delete(lb.targets, key)
+	return &dpdkproto.DeleteLoadBalancerTargetResponse{Status: ok()}, nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+func validatePorts(minPort, maxPort uint32) error {
+	switch {
+	case minPort > 65535:
+		return invalid("min_port")
+	case maxPort > 65536:
+		return invalid("max_port")
+	case minPort == 0 || minPort >= maxPort:
+		return invalid("port range")
+	}
+	return nil
+}
+
+func overlaps(a, b *dpdkproto.NatEntry) bool {
+	return a.GetMinPort() < b.GetMaxPort() && b.GetMinPort() < a.GetMaxPort()
+}
+
+// localNatInUse reports whether the port range of entry on its NAT IP is taken by a local NAT.
+func (s *Server) localNatInUse(entry *dpdkproto.NatEntry) bool {
+	for _, i := range s.interfaces {
+		if i.nat != nil && addrKey(i.nat.GetNatIp()) == addrKey(entry.GetNatIp()) && overlaps(i.nat, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// neighborNatInUse reports whether the port range of entry on its NAT IP is taken by a neighbor NAT.
+func (s *Server) neighborNatInUse(entry *dpdkproto.NatEntry) bool {
+	for _, neighbor := range s.neighborNats {
+		if addrKey(neighbor.GetNatIp()) == addrKey(entry.GetNatIp()) && overlaps(neighbor, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) CreateNat(_ context.Context, req *dpdkproto.CreateNatRequest) (*dpdkproto.CreateNatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(req.GetInterfaceId()) == 0 {
+		return nil, invalid("interface_id")
+	}
+	if !validAddr(req.GetNatIp()) {
+		return nil, invalid("nat_ip")
+	}
+	if err := validatePorts(req.GetMinPort(), req.GetMaxPort()); err != nil {
+		return nil, err
+	}
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.CreateNatResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.nat != nil {
+		return &dpdkproto.CreateNatResponse{Status: fail(errors.SNAT_EXISTS)}, nil
+	}
+	entry := &dpdkproto.NatEntry{
+		NatIp:   clone(req.GetNatIp()),
+		MinPort: req.GetMinPort(),
+		MaxPort: req.GetMaxPort(),
+		Vni:     i.proto.GetVni(),
+	}
+	if s.localNatInUse(entry) {
+		return &dpdkproto.CreateNatResponse{Status: fail(errors.ALREADY_EXISTS)}, nil
+	}
+	entry.UnderlayRoute = s.allocateUnderlay()
+	i.nat = entry
+	return &dpdkproto.CreateNatResponse{Status: ok(), UnderlayRoute: entry.UnderlayRoute}, nil
+}
+
+func (s *Server) GetNat(_ context.Context, req *dpdkproto.GetNatRequest) (*dpdkproto.GetNatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.GetNatResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.nat == nil {
+		return &dpdkproto.GetNatResponse{Status: fail(errors.SNAT_NO_DATA)}, nil
+	}
+	return &dpdkproto.GetNatResponse{
+		Status:        ok(),
+		NatIp:         clone(i.nat.GetNatIp()),
+		MinPort:       i.nat.GetMinPort(),
+		MaxPort:       i.nat.GetMaxPort(),
+		UnderlayRoute: i.nat.GetUnderlayRoute(),
+	}, nil
+}
+
+func (s *Server) DeleteNat(_ context.Context, req *dpdkproto.DeleteNatRequest) (*dpdkproto.DeleteNatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, found := s.interfaces[string(req.GetInterfaceId())]
+	if !found {
+		return &dpdkproto.DeleteNatResponse{Status: fail(errors.NO_VM)}, nil
+	}
+	if i.nat == nil {
+		return &dpdkproto.DeleteNatResponse{Status: fail(errors.SNAT_NO_DATA)}, nil
+	}
+	i.nat = nil
+	return &dpdkproto.DeleteNatResponse{Status: ok()}, nil
+}
+
+// ListLocalNats lists the primary IPs of the interfaces using the given NAT IP.
+func (s *Server) ListLocalNats(_ context.Context, req *dpdkproto.ListLocalNatsRequest) (*dpdkproto.ListLocalNatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := &dpdkproto.ListLocalNatsResponse{Status: ok()}
+	for _, i := range s.interfaces {
+		if i.nat == nil || (req.GetNatIp() != nil && addrKey(i.nat.GetNatIp()) != addrKey(req.GetNatIp())) {
+			continue
+		}
+		res.NatEntries = append(res.NatEntries, &dpdkproto.NatEntry{
+			NatIp:   &dpdkproto.IpAddress{Ipver: dpdkproto.IpVersion_IPV4, Address: i.proto.GetPrimaryIpv4()},
+			MinPort: i.nat.GetMinPort(),
+			MaxPort: i.nat.GetMaxPort(),
+			Vni:     i.nat.GetVni(),
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) CreateNeighborNat(_ context.Context, req *dpdkproto.CreateNeighborNatRequest) (*dpdkproto.CreateNeighborNatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !validAddr(req.GetNatIp()) {
+		return nil, invalid("nat_ip")
+	}
+	if err := validatePorts(req.GetMinPort(), req.GetMaxPort()); err != nil {
+		return nil, err
+	}
+	entry := &dpdkproto.NatEntry{
+		NatIp:         clone(req.GetNatIp()),
+		MinPort:       req.GetMinPort(),
+		MaxPort:       req.GetMaxPort(),
+		Vni:           req.GetVni(),
+		UnderlayRoute: req.GetUnderlayRoute(),
+	}
+	if s.neighborNatInUse(entry) {
+		return &dpdkproto.CreateNeighborNatResponse{Status: fail(errors.ALREADY_EXISTS)}, nil
+	}
+	s.neighborNats = append(s.neighborNats, entry)
+	return &dpdkproto.CreateNeighborNatResponse{Status: ok()}, nil
+}
+
+func (s *Server) DeleteNeighborNat(_ context.Context, req *dpdkproto.DeleteNeighborNatRequest) (*dpdkproto.DeleteNeighborNatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for idx, entry := range s.neighborNats {
+		if addrKey(entry.GetNatIp()) == addrKey(req.GetNatIp()) && entry.GetVni() == req.GetVni() &&
+			entry.GetMinPort() == req.GetMinPort() && entry.GetMaxPort() == req.GetMaxPort() {
+			s.neighborNats = append(s.neighborNats[:idx], s.neighborNats[idx+1:]...)
+			return &dpdkproto.DeleteNeighborNatResponse{Status: ok()}, nil
+		}
+	}
+	return &dpdkproto.DeleteNeighborNatResponse{Status: fail(errors.NOT_FOUND)}, nil
+}
+
+func (s *Server) ListNeighborNats(_ context.Context, req *dpdkproto.ListNeighborNatsRequest) (*dpdkproto.ListNeighborNatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := &dpdkproto.ListNeighborNatsResponse{Status: ok()}
+	for _, entry := range s.neighborNats {
+		if req.GetNatIp() == nil || addrKey(entry.GetNatIp()) == addrKey(req.GetNatIp()) {
+			res.NatEntries = append(res.NatEntries, clone(entry))
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+func (s *Server) ListRoutes(_ context.Context, req *dpdkproto.ListRoutesRequest) (*dpdkproto.ListRoutesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := &dpdkproto.ListRoutesResponse{Status: ok()}
+	for _, route := range s.routes[req.GetVni()] {
+		res.Routes = append(res.Routes, clone(route))
+	}
+	return res, nil
+}
+
+func (s *Server) CreateRoute(_ context.Context, req *dpdkproto.CreateRouteRequest) (*dpdkproto.CreateRouteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := req.GetRoute()
+	if !validPrefix(route.GetPrefix()) {
+		return nil, invalid("route.prefix.ip")
+	}
+	if !validAddr(route.GetNexthopAddress()) {
+		return nil, invalid("route.nexthop_address")
+	}
+	if s.vniUsage[req.GetVni()] == 0 {
+		return &dpdkproto.CreateRouteResponse{Status: fail(errors.NO_VNI)}, nil
+	}
+	key := prefixKey(route.GetPrefix())
+	if _, found := s.routes[req.GetVni()][key]; found {
+		return &dpdkproto.CreateRouteResponse{Status: fail(errors.ROUTE_EXISTS)}, nil
+	}
+	if s.routes[req.GetVni()] == nil {
+		s.routes[req.GetVni()] = make(map[string]*dpdkproto.Route)
+	}
+	s.routes[req.GetVni()][key] = clone(route)
+	return &dpdkproto.CreateRouteResponse{Status: ok()}, nil
+}
+
+func (s *Server) DeleteRoute(_ context.Context, req *dpdkproto.DeleteRouteRequest) (*dpdkproto.DeleteRouteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := prefixKey(req.GetRoute().GetPrefix())
+	if _, found := s.routes[req.GetVni()][key]; !found {
+		return &dpdkproto.DeleteRouteResponse{Status: fail(errors.ROUTE_NOT_FOUND)}, nil
+	}
+	delete(s.routes[req.GetVni()], key)
+	return &dpdkproto.DeleteRouteResponse{Status: ok()}, nil
+}
+
+// CheckVniInUse reports a VNI in use while interfaces or loadbalancers exist in it.
+func (s *Server) CheckVniInUse(_ context.Context, req *dpdkproto.CheckVniInUseRequest) (*dpdkproto.CheckVniInUseResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &dpdkproto.CheckVniInUseResponse{Status: ok(), InUse: s.vniUsage[req.GetVni()] > 0}, nil
+}
+
+// ResetVni drops all routes of a VNI in use.
+func (s *Server) ResetVni(_ context.Context, req *dpdkproto.ResetVniRequest) (*dpdkproto.ResetVniResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.vniUsage[req.GetVni()] == 0 {
+		return &dpdkproto.ResetVniResponse{Status: fail(errors.NO_VNI)}, nil
+	}
+	delete(s.routes, req.GetVni())
+	return &dpdkproto.ResetVniResponse{Status: ok()}, nil
+}
+
+func (s *Server) CaptureStart(_ context.Context, req *dpdkproto.CaptureStartRequest) (*dpdkproto.CaptureStartResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config := req.GetCaptureConfig()
+	if sink := config.GetSinkNodeIp(); !validAddr(sink) || sink.GetIpver() != dpdkproto.IpVersion_IPV6 {
+		return nil, invalid("sink_node_ip")
+	}
+	if config.GetUdpSrcPort() > 65535 {
+		return nil, invalid("udp_src_port")
+	}
+	if config.GetUdpDstPort() > 65535 {
+		return nil, invalid("udp_dst_port")
+	}
+	if s.capture != nil {
+		return &dpdkproto.CaptureStartResponse{Status: fail(errors.ALREADY_ACTIVE)}, nil
+	}
+	s.capture = clone(config)
+	return &dpdkproto.CaptureStartResponse{Status: ok()}, nil
+}
+
+func (s *Server) CaptureStop(context.Context, *dpdkproto.CaptureStopRequest) (*dpdkproto.CaptureStopResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.capture == nil {
+		return &dpdkproto.CaptureStopResponse{Status: fail(errors.NOT_ACTIVE)}, nil
+	}
+	stopped := uint32(len(s.capture.GetInterfaces()))
+	s.capture = nil
+	return &dpdkproto.CaptureStopResponse{Status: ok(), StoppedInterfaceCnt: stopped}, nil
+}
+
+func (s *Server) CaptureStatus(context.Context, *dpdkproto.CaptureStatusRequest) (*dpdkproto.CaptureStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.capture == nil {
+		return &dpdkproto.CaptureStatusResponse{Status: ok()}, nil
+	}
+	return &dpdkproto.CaptureStatusResponse{Status: ok(), IsActive: true, CaptureConfig: clone(s.capture)}, nil
+}
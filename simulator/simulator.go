@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package simulator provides an in-process dpservice implementing the DPDKironcore gRPC
+// service, so integration tests can run against a real gRPC transport without DPDK hardware.
+//
+//	sim, err := simulator.Start("")
+//	if err != nil { ... }
+//	defer sim.Stop()
+//	c, err := client.Dial(ctx, sim.Addr())
+package simulator
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip" // dpservice accepts gzip compressed requests
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultUnderlayPrefix is the prefix underlay routes are allocated from unless configured otherwise.
+var DefaultUnderlayPrefix = netip.MustParsePrefix("fc00:1::/64")
+
+// ServiceVersion is the dpservice version reported by the simulator.
+const ServiceVersion = "simulator"
+
+// Server implements the DPDKironcore service in memory. It allocates underlay routes,
+// detects duplicates and keeps track of VNI usage like dpservice does, but does not
+// forward any traffic. All calls but CheckInitialized, Initialize and GetVersion fail
+// with codes.Aborted until Initialize was called.
+type Server struct {
+	dpdkproto.UnimplementedDPDKironcoreServer
+
+	mu            sync.Mutex
+	uuid          string
+	underlay      netip.Prefix
+	lastUnderlay  netip.Addr
+	interfaces    map[string]*iface
+	loadBalancers map[string]*loadBalancer
+	routes        map[uint32]map[string]*dpdkproto.Route
+	neighborNats  []*dpdkproto.NatEntry
+	vniUsage      map[uint32]int
+	capture       *dpdkproto.CaptureConfig
+}
+
+type iface struct {
+	proto      *dpdkproto.Interface
+	vfName     string
+	vip        *dpdkproto.GetVipResponse
+	nat        *dpdkproto.NatEntry
+	prefixes   map[string]*dpdkproto.Prefix
+	lbPrefixes map[string]*dpdkproto.Prefix
+	rules      map[string]*dpdkproto.FirewallRule
+}
+
+type loadBalancer struct {
+	proto   *dpdkproto.GetLoadBalancerResponse
+	targets map[string]*dpdkproto.IpAddress
+}
+
+// NewServer creates an uninitialized simulator allocating underlay routes from underlayPrefix.
+// An invalid prefix selects DefaultUnderlayPrefix.
+func NewServer(underlayPrefix netip.Prefix) *Server {
+	if !underlayPrefix.IsValid() {
+		underlayPrefix = DefaultUnderlayPrefix
+	}
+	s := &Server{underlay: underlayPrefix.Masked()}
+	s.reset()
+	return s
+}
+
+// reset drops all state as a restart of dpservice does.
+func (s *Server) reset() {
+	s.uuid = ""
+	s.lastUnderlay = s.underlay.Addr()
+	s.interfaces = make(map[string]*iface)
+	s.loadBalancers = make(map[string]*loadBalancer)
+	s.routes = make(map[uint32]map[string]*dpdkproto.Route)
+	s.neighborNats = nil
+	s.vniUsage = make(map[uint32]int)
+	s.capture = nil
+}
+
+// Restart drops all state and requires a new Initialize, simulating a dpservice restart.
+func (s *Server) Restart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reset()
+}
+
+// Register registers the simulator on a gRPC server.
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	dpdkproto.RegisterDPDKironcoreServer(registrar, s)
+}
+
+// UnaryInterceptor rejects calls until the simulator is initialized. Register it on the
+// gRPC server serving the simulator.
+func (s *Server) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		switch info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:] {
+		case "CheckInitialized", "Initialize", "GetVersion":
+		default:
+			s.mu.Lock()
+			initialized := s.uuid != ""
+			s.mu.Unlock()
+			if !initialized {
+				return nil, status.Error(codes.Aborted, "dpservice not initialized")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Simulator is a simulator served on a local gRPC listener.
+type Simulator struct {
+	*Server
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// Start serves a new simulator on address, with gRPC server reflection enabled. An empty
+// address selects a free local port.
+func Start(address string) (*Simulator, error) {
+	if address == "" {
+		address = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s: %w", address, err)
+	}
+
+	server := NewServer(netip.Prefix{})
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(server.UnaryInterceptor()))
+	server.Register(grpcServer)
+	dpdkproto.RegisterReflection(grpcServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	return &Simulator{
+		Server:     server,
+		grpcServer: grpcServer,
+		listener:   listener,
+	}, nil
+}
+
+// Addr returns the address the simulator listens on.
+func (s *Simulator) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Stop stops serving and closes all connections.
+func (s *Simulator) Stop() {
+	s.grpcServer.Stop()
+}
+
+func ok() *dpdkproto.Status {
+	return &dpdkproto.Status{}
+}
+
+func fail(code uint32) *dpdkproto.Status {
+	// dpservice sends the name of the status code as message
+	return &dpdkproto.Status{Code: code, Message: errors.StatusName(code)}
+}
+
+// invalid returns the gRPC error dpservice rejects malformed request fields with.
+func invalid(field string) error {
+	return status.Errorf(codes.InvalidArgument, "Invalid %s", field)
+}
+
+func validPort(port int32) bool {
+	return port >= -1 && port <= 65535
+}
+
+// allocateUnderlay returns the next free underlay route.
+func (s *Server) allocateUnderlay() []byte {
+	s.lastUnderlay = s.lastUnderlay.Next()
+	return []byte(s.lastUnderlay.String())
+}
+
+func (s *Server) useVni(vni uint32) {
+	s.vniUsage[vni]++
+}
+
+func (s *Server) releaseVni(vni uint32) {
+	if s.vniUsage[vni]--; s.vniUsage[vni] <= 0 {
+		delete(s.vniUsage, vni)
+	}
+}
+
+func addrKey(ip *dpdkproto.IpAddress) string {
+	return string(ip.GetAddress())
+}
+
+func prefixKey(prefix *dpdkproto.Prefix) string {
+	return fmt.Sprintf("%s/%d", prefix.GetIp().GetAddress(), prefix.GetLength())
+}
+
+func validAddr(ip *dpdkproto.IpAddress) bool {
+	addr, err := netip.ParseAddr(string(ip.GetAddress()))
+	return err == nil && addr.Is4() == (ip.GetIpver() == dpdkproto.IpVersion_IPV4)
+}
+
+func validPrefix(prefix *dpdkproto.Prefix) bool {
+	if !validAddr(prefix.GetIp()) {
+		return false
+	}
+	maxLength := uint32(128)
+	if prefix.GetIp().GetIpver() == dpdkproto.IpVersion_IPV4 {
+		maxLength = 32
+	}
+	return prefix.GetLength() <= maxLength
+}
+
+func (s *Server) CheckInitialized(context.Context, *dpdkproto.CheckInitializedRequest) (*dpdkproto.CheckInitializedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.uuid == "" {
+		return &dpdkproto.CheckInitializedResponse{Status: fail(errors.NOT_ACTIVE)}, nil
+	}
+	return &dpdkproto.CheckInitializedResponse{Status: ok(), Uuid: s.uuid}, nil
+}
+
+func (s *Server) Initialize(context.Context, *dpdkproto.InitializeRequest) (*dpdkproto.InitializeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.uuid == "" {
+		s.uuid = newUUID()
+	}
+	return &dpdkproto.InitializeResponse{Status: ok(), Uuid: s.uuid}, nil
+}
+
+func (s *Server) GetVersion(context.Context, *dpdkproto.GetVersionRequest) (*dpdkproto.GetVersionResponse, error) {
+	return &dpdkproto.GetVersionResponse{
+		Status:          ok(),
+		ServiceProtocol: strings.TrimSpace(dpdkproto.GeneratedFrom),
+		ServiceVersion:  ServiceVersion,
+	}, nil
+}
+
+// clone returns a deep copy of msg so callers cannot modify the simulator state.
+func clone[T proto.Message](msg T) T {
+	return proto.Clone(msg).(T)
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
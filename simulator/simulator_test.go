@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+var _ = Describe("Simulator", Ordered, func() {
+	ctx := context.TODO()
+
+	It("should reject calls until initialized", func() {
+		sim.Restart()
+		_, err := simClient.ListInterfaces(ctx)
+		Expect(status.Code(err)).To(Equal(codes.Aborted))
+
+		uuid, err := client.EnsureInitialized(ctx, simClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uuid).NotTo(BeEmpty())
+	})
+
+	It("should allocate underlay routes and detect duplicates", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2000:100::1")
+		iface := &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap1"},
+		}
+		created, err := simClient.CreateInterface(ctx, iface)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Spec.UnderlayRoute.String()).To(Equal("fc00:1::1"))
+		Expect(created.Spec.VirtualFunction.Name).To(Equal("net_tap1"))
+
+		_, err = simClient.CreateInterface(ctx, iface)
+		Expect(errors.IsStatusErrorCode(err, errors.ALREADY_EXISTS)).To(BeTrue())
+
+		prefix := netip.MustParsePrefix("10.0.1.0/24")
+		createdPrefix, err := simClient.CreatePrefix(ctx, &api.Prefix{
+			PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+			Spec:       api.PrefixSpec{Prefix: prefix},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(createdPrefix.Spec.UnderlayRoute.String()).To(Equal("fc00:1::2"))
+
+		prefixes, err := simClient.ListPrefixes(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefixes.Items).To(HaveLen(1))
+		Expect(prefixes.Items[0].Spec.Prefix).To(Equal(prefix))
+	})
+
+	It("should keep track of VNI usage", func() {
+		vni, err := simClient.GetVni(ctx, 100, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vni.Spec.InUse).To(BeTrue())
+
+		prefix := netip.MustParsePrefix("10.0.2.0/24")
+		nextHop := netip.MustParseAddr("fc00:2::1")
+		_, err = simClient.CreateRoute(ctx, &api.Route{
+			RouteMeta: api.RouteMeta{VNI: 100},
+			Spec:      api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 200, IP: &nextHop}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = simClient.ResetVni(ctx, 100, 0)
+		Expect(err).NotTo(HaveOccurred())
+		routes, err := simClient.ListRoutes(ctx, 100)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes.Items).To(BeEmpty())
+
+		_, err = simClient.DeleteInterface(ctx, "vm1")
+		Expect(err).NotTo(HaveOccurred())
+		vni, err = simClient.GetVni(ctx, 100, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vni.Spec.InUse).To(BeFalse())
+
+		_, err = simClient.GetInterface(ctx, "vm1")
+		Expect(errors.IsStatusErrorCode(err, errors.NOT_FOUND)).To(BeTrue())
+	})
+})
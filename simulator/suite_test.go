@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+var (
+	sim       *Simulator
+	simClient *client.ConnectedClient
+)
+
+func TestSimulator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Simulator Suite")
+}
+
+var _ = BeforeSuite(func() {
+	var err error
+	sim, err = Start("")
+	Expect(err).NotTo(HaveOccurred())
+
+	simClient, err = client.Dial(context.TODO(), sim.Addr())
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	if simClient != nil {
+		Expect(simClient.Close()).To(Succeed())
+	}
+	if sim != nil {
+		sim.Stop()
+	}
+})
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileFormatVersion is the version of the backup file layout written by Save.
+const FileFormatVersion = 1
+
+// file is the on-disk envelope of a snapshot.
+type file struct {
+	FormatVersion   int             `json:"format_version"`
+	ServiceProtocol string          `json:"service_protocol,omitempty"`
+	Checksum        string          `json:"checksum"`
+	Snapshot        json.RawMessage `json:"snapshot"`
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Save writes the snapshot as versioned JSON including an integrity checksum.
+func Save(w io.Writer, snap *Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("error marshaling snapshot: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&file{
+		FormatVersion:   FileFormatVersion,
+		ServiceProtocol: snap.ServiceProtocol,
+		Checksum:        checksum(data),
+		Snapshot:        data,
+	})
+}
+
+// Load reads a snapshot written by Save and verifies its format version and checksum.
+func Load(r io.Reader) (*Snapshot, error) {
+	f := &file{}
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot file: %w", err)
+	}
+	if f.FormatVersion != FileFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d", f.FormatVersion)
+	}
+	// the envelope is indented, so hash the compact form that was checksummed on save
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, f.Snapshot); err != nil {
+		return nil, fmt.Errorf("error compacting snapshot: %w", err)
+	}
+	if sum := checksum(compact.Bytes()); sum != f.Checksum {
+		return nil, fmt.Errorf("snapshot checksum mismatch: expected %s, got %s", f.Checksum, sum)
+	}
+
+	snap := &Snapshot{}
+	if err := json.Unmarshal(f.Snapshot, snap); err != nil {
+		return nil, fmt.Errorf("error unmarshaling snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// SaveFile atomically writes the snapshot to path.
+func SaveFile(path string, snap *Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Save(tmp, snap); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFile reads a snapshot written by SaveFile.
+func LoadFile(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"bytes"
+	"net/netip"
+	"path/filepath"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("snapshot file", func() {
+	var snap *Snapshot
+
+	BeforeEach(func() {
+		ipv4 := netip.MustParseAddr("10.200.1.5")
+		src := netip.MustParsePrefix("10.0.0.0/8")
+		dst := netip.MustParsePrefix("0.0.0.0/0")
+		snap = &Snapshot{
+			ServiceProtocol: "v0.3.0",
+			Interfaces: []api.Interface{{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+				Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4},
+			}},
+			FirewallRules: []api.FirewallRule{{
+				TypeMeta:         api.TypeMeta{Kind: api.FirewallRuleKind},
+				FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"},
+				Spec: api.FirewallRuleSpec{
+					RuleID:            "fr1",
+					TrafficDirection:  "Ingress",
+					FirewallAction:    "Accept",
+					SourcePrefix:      &src,
+					DestinationPrefix: &dst,
+					ProtocolFilter: &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{
+						SrcPortLower: -1, DstPortLower: 443, DstPortUpper: 443,
+					}}},
+				},
+			}},
+		}
+	})
+
+	It("should round-trip through Save and Load", func() {
+		buf := &bytes.Buffer{}
+		Expect(Save(buf, snap)).To(Succeed())
+
+		loaded, err := Load(buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.ServiceProtocol).To(Equal("v0.3.0"))
+		Expect(loaded.Interfaces).To(HaveLen(1))
+		Expect(loaded.Interfaces[0].Spec.IPv4.String()).To(Equal("10.200.1.5"))
+		Expect(loaded.FirewallRules).To(HaveLen(1))
+		Expect(loaded.FirewallRules[0].Spec.ProtocolFilter.GetTcp().GetDstPortLower()).To(Equal(int32(443)))
+	})
+
+	It("should round-trip through SaveFile and LoadFile", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "backup.json")
+		Expect(SaveFile(path, snap)).To(Succeed())
+
+		loaded, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Interfaces[0].ID).To(Equal("vm1"))
+	})
+
+	It("should reject a tampered file", func() {
+		buf := &bytes.Buffer{}
+		Expect(Save(buf, snap)).To(Succeed())
+
+		tampered := strings.Replace(buf.String(), "10.200.1.5", "10.200.1.6", 1)
+		_, err := Load(strings.NewReader(tampered))
+		Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+	})
+
+	It("should check protocol compatibility", func() {
+		Expect(ProtocolsCompatible("v0.3.0", "v0.3.1")).To(BeTrue())
+		Expect(ProtocolsCompatible("v0.3.0", "v0.4.0")).To(BeFalse())
+		Expect(ProtocolsCompatible("v1.2.0", "v1.5.0")).To(BeTrue())
+		Expect(ProtocolsCompatible("v1.2.0", "v2.0.0")).To(BeFalse())
+		Expect(ProtocolsCompatible("", "v2.0.0")).To(BeTrue())
+	})
+})
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// alreadyExists are the status codes dpservice reports when creating an object that exists.
+var alreadyExists = errors.Ignore(errors.ALREADY_EXISTS, errors.SNAT_EXISTS, errors.DNAT_EXISTS, errors.ROUTE_EXISTS)
+
+type restoreOptions struct {
+	force bool
+}
+
+type RestoreOption func(*restoreOptions)
+
+// WithForce skips the protocol compatibility check before restoring.
+func WithForce() RestoreOption {
+	return func(o *restoreOptions) {
+		o.force = true
+	}
+}
+
+// CheckCompatibility verifies that the snapshot was taken from a dpservice speaking
+// a protocol compatible with the one served by c.
+func CheckCompatibility(ctx context.Context, c client.Client, snap *Snapshot) error {
+	version, err := c.GetVersion(ctx, &api.Version{})
+	if err != nil {
+		return fmt.Errorf("error getting version: %w", err)
+	}
+	if !ProtocolsCompatible(snap.ServiceProtocol, version.Spec.ServiceProtocol) {
+		return fmt.Errorf("snapshot protocol %q is not compatible with service protocol %q", snap.ServiceProtocol, version.Spec.ServiceProtocol)
+	}
+	return nil
+}
+
+// ProtocolsCompatible reports whether two dpservice protocol versions (e.g. "v0.3.0")
+// share the same major version, and for v0 also the same minor version.
+// An empty version is treated as unknown and thus compatible.
+func ProtocolsCompatible(a, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	pa, pb := protocolParts(a), protocolParts(b)
+	if pa[0] != pb[0] {
+		return false
+	}
+	return pa[0] != "0" || pa[1] == pb[1]
+}
+
+func protocolParts(version string) [2]string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "-")
+	parts := strings.SplitN(version, ".", 3)
+	var res [2]string
+	copy(res[:], parts)
+	return res
+}
+
+// Restore creates all objects of the snapshot in dependency order.
+// Objects that already exist are left untouched.
+func Restore(ctx context.Context, c client.Client, snap *Snapshot, opts ...RestoreOption) error {
+	o := &restoreOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if !o.force {
+		if err := CheckCompatibility(ctx, c, snap); err != nil {
+			return err
+		}
+	}
+
+	for i := range snap.Interfaces {
+		if _, err := c.CreateInterface(ctx, &snap.Interfaces[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring interface %s: %w", snap.Interfaces[i].ID, err)
+		}
+	}
+	for i := range snap.VirtualIPs {
+		if _, err := c.CreateVirtualIP(ctx, &snap.VirtualIPs[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring virtual ip of interface %s: %w", snap.VirtualIPs[i].InterfaceID, err)
+		}
+	}
+	for i := range snap.Nats {
+		if _, err := c.CreateNat(ctx, &snap.Nats[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring nat of interface %s: %w", snap.Nats[i].InterfaceID, err)
+		}
+	}
+	for i := range snap.Prefixes {
+		if _, err := c.CreatePrefix(ctx, &snap.Prefixes[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring prefix %s: %w", snap.Prefixes[i].GetName(), err)
+		}
+	}
+	for i := range snap.LoadBalancers {
+		if _, err := c.CreateLoadBalancer(ctx, &snap.LoadBalancers[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring loadbalancer %s: %w", snap.LoadBalancers[i].ID, err)
+		}
+	}
+	for i := range snap.LoadBalancerTargets {
+		if _, err := c.CreateLoadBalancerTarget(ctx, &snap.LoadBalancerTargets[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring loadbalancer target %s: %w", snap.LoadBalancerTargets[i].Spec.TargetIP, err)
+		}
+	}
+	for i := range snap.LoadBalancerPrefixes {
+		if _, err := c.CreateLoadBalancerPrefix(ctx, &snap.LoadBalancerPrefixes[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring loadbalancer prefix %s: %w", snap.LoadBalancerPrefixes[i].GetName(), err)
+		}
+	}
+	for i := range snap.FirewallRules {
+		if _, err := c.CreateFirewallRule(ctx, &snap.FirewallRules[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring firewall rule %s: %w", snap.FirewallRules[i].GetName(), err)
+		}
+	}
+	for i := range snap.Routes {
+		if _, err := c.CreateRoute(ctx, &snap.Routes[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring route %s: %w", snap.Routes[i].GetName(), err)
+		}
+	}
+	for i := range snap.NeighborNats {
+		if _, err := c.CreateNeighborNat(ctx, &snap.NeighborNats[i], alreadyExists); err != nil {
+			return fmt.Errorf("error restoring neighbor nat %s: %w", snap.NeighborNats[i].GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/simulator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("restore", func() {
+	ctx := context.TODO()
+	var c *client.ConnectedClient
+
+	BeforeEach(func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		c, err = client.Dial(ctx, sim.Addr())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(c.Close)
+		_, err = client.EnsureInitialized(ctx, c)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should leave existing objects untouched", func() {
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("2001:db8::1")
+		vip := netip.MustParseAddr("20.0.0.1")
+		natIP := netip.MustParseAddr("20.0.0.2")
+		lbIP := netip.MustParseAddr("20.0.0.3")
+		routePrefix := netip.MustParsePrefix("30.0.0.0/24")
+		nextHop := netip.MustParseAddr("ff80::1")
+		snap := &Snapshot{
+			Interfaces: []api.Interface{{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+				Spec:          api.InterfaceSpec{VNI: 100, IPv4: &ipv4, IPv6: &ipv6, Device: "net_tap1"},
+			}},
+			VirtualIPs: []api.VirtualIP{{
+				TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+				VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm1"},
+				Spec:          api.VirtualIPSpec{IP: &vip},
+			}},
+			Nats: []api.Nat{{
+				TypeMeta: api.TypeMeta{Kind: api.NatKind},
+				NatMeta:  api.NatMeta{InterfaceID: "vm1"},
+				Spec:     api.NatSpec{NatIP: &natIP, MinPort: 1000, MaxPort: 2000},
+			}},
+			Prefixes: []api.Prefix{{
+				TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+				PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+				Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24")},
+			}},
+			LoadBalancers: []api.LoadBalancer{{
+				TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+				LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"},
+				Spec:             api.LoadBalancerSpec{VNI: 100, LbVipIP: &lbIP, Lbports: []api.LBPort{{Protocol: 6, Port: 443}}},
+			}},
+			Routes: []api.Route{{
+				TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+				RouteMeta: api.RouteMeta{VNI: 100},
+				Spec:      api.RouteSpec{Prefix: &routePrefix, NextHop: &api.RouteNextHop{VNI: 200, IP: &nextHop}},
+			}},
+		}
+
+		Expect(Restore(ctx, c, snap)).To(Succeed())
+		Expect(Restore(ctx, c, snap)).To(Succeed())
+
+		restored, err := Take(ctx, c, WithLoadBalancers("lb1"), WithVNIs(100))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.Interfaces).To(HaveLen(1))
+		Expect(restored.VirtualIPs).To(HaveLen(1))
+		Expect(restored.Nats).To(HaveLen(1))
+		Expect(restored.Prefixes).To(HaveLen(1))
+		Expect(restored.LoadBalancers).To(HaveLen(1))
+		Expect(restored.Routes).To(HaveLen(1))
+	})
+})
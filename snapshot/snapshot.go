@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// Snapshot is a point-in-time copy of the objects configured on a dpservice instance.
+type Snapshot struct {
+	ServiceProtocol      string                   `json:"service_protocol,omitempty"`
+	Interfaces           []api.Interface          `json:"interfaces,omitempty"`
+	VirtualIPs           []api.VirtualIP          `json:"virtual_ips,omitempty"`
+	Nats                 []api.Nat                `json:"nats,omitempty"`
+	NeighborNats         []api.NeighborNat        `json:"neighbor_nats,omitempty"`
+	Prefixes             []api.Prefix             `json:"prefixes,omitempty"`
+	LoadBalancers        []api.LoadBalancer       `json:"loadbalancers,omitempty"`
+	LoadBalancerTargets  []api.LoadBalancerTarget `json:"loadbalancer_targets,omitempty"`
+	LoadBalancerPrefixes []api.LoadBalancerPrefix `json:"loadbalancer_prefixes,omitempty"`
+	FirewallRules        []api.FirewallRule       `json:"firewall_rules,omitempty"`
+	Routes               []api.Route              `json:"routes,omitempty"`
+}
+
+// notFound lists the status codes dpservice returns when a per-interface object does not exist.
+var notFound = errors.Ignore(errors.NOT_FOUND, errors.SNAT_NO_DATA, errors.DNAT_NO_DATA)
+
+type takeOptions struct {
+	loadBalancerIDs []string
+	vnis            []uint32
+}
+
+type TakeOption func(*takeOptions)
+
+// WithLoadBalancers adds the given load balancers to the snapshot, skipping those that do not exist.
+// dpservice cannot list load balancers, so they have to be named explicitly.
+func WithLoadBalancers(ids ...string) TakeOption {
+	return func(o *takeOptions) {
+		o.loadBalancerIDs = append(o.loadBalancerIDs, ids...)
+	}
+}
+
+// WithVNIs adds the routes of the given VNIs in addition to the VNIs used by interfaces.
+func WithVNIs(vnis ...uint32) TakeOption {
+	return func(o *takeOptions) {
+		o.vnis = append(o.vnis, vnis...)
+	}
+}
+
+// Take reads the current state of dpservice into a Snapshot. Its calls count as
+// client.HeavyOperation for client.WithOperationTimeout.
+func Take(ctx context.Context, c client.Client, opts ...TakeOption) (*Snapshot, error) {
+	o := &takeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ctx = client.WithCallOptions(ctx, client.WithOperationType(client.HeavyOperation))
+
+	version, err := c.GetVersion(ctx, &api.Version{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting version: %w", err)
+	}
+	snap := &Snapshot{ServiceProtocol: version.Spec.ServiceProtocol}
+
+	ifaces, err := c.ListInterfaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing interfaces: %w", err)
+	}
+
+	vnis := map[uint32]struct{}{}
+	for _, vni := range o.vnis {
+		vnis[vni] = struct{}{}
+	}
+	natIPs := map[netip.Addr]struct{}{}
+
+	for _, iface := range ifaces.Items {
+		iface.Spec.VirtualFunction = nil
+		snap.Interfaces = append(snap.Interfaces, iface)
+		vnis[iface.Spec.VNI] = struct{}{}
+
+		vip, err := c.GetVirtualIP(ctx, iface.ID, notFound)
+		if err != nil {
+			return nil, fmt.Errorf("error getting virtual ip of interface %s: %w", iface.ID, err)
+		}
+		if vip.Status.Code == 0 {
+			snap.VirtualIPs = append(snap.VirtualIPs, *vip)
+		}
+
+		nat, err := c.GetNat(ctx, iface.ID, notFound)
+		if err != nil {
+			return nil, fmt.Errorf("error getting nat of interface %s: %w", iface.ID, err)
+		}
+		if nat.Status.Code == 0 {
+			nat.Spec.Vni = iface.Spec.VNI
+			snap.Nats = append(snap.Nats, *nat)
+			if nat.Spec.NatIP != nil && nat.Spec.NatIP.IsValid() {
+				natIPs[*nat.Spec.NatIP] = struct{}{}
+			}
+		}
+
+		prefixes, err := c.ListPrefixes(ctx, iface.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing prefixes of interface %s: %w", iface.ID, err)
+		}
+		snap.Prefixes = append(snap.Prefixes, prefixes.Items...)
+
+		lbPrefixes, err := c.ListLoadBalancerPrefixes(ctx, iface.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing loadbalancer prefixes of interface %s: %w", iface.ID, err)
+		}
+		for _, prefix := range lbPrefixes.Items {
+			snap.LoadBalancerPrefixes = append(snap.LoadBalancerPrefixes, api.LoadBalancerPrefix{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+				LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: iface.ID},
+				Spec: api.LoadBalancerPrefixSpec{
+					Prefix:        prefix.Spec.Prefix,
+					UnderlayRoute: prefix.Spec.UnderlayRoute,
+				},
+			})
+		}
+
+		fwRules, err := c.ListFirewallRules(ctx, iface.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing firewall rules of interface %s: %w", iface.ID, err)
+		}
+		snap.FirewallRules = append(snap.FirewallRules, fwRules.Items...)
+	}
+
+	for natIP := range natIPs {
+		natIP := natIP
+		nNats, err := c.ListNeighborNats(ctx, &natIP)
+		if err != nil {
+			return nil, fmt.Errorf("error listing neighbor nats of %s: %w", natIP, err)
+		}
+		for _, nNat := range nNats.Items {
+			snap.NeighborNats = append(snap.NeighborNats, api.NeighborNat{
+				TypeMeta:        api.TypeMeta{Kind: api.NeighborNatKind},
+				NeighborNatMeta: api.NeighborNatMeta{NatIP: &natIP},
+				Spec: api.NeighborNatSpec{
+					Vni:           nNat.Spec.Vni,
+					MinPort:       nNat.Spec.MinPort,
+					MaxPort:       nNat.Spec.MaxPort,
+					UnderlayRoute: nNat.Spec.UnderlayRoute,
+				},
+			})
+		}
+	}
+
+	for _, id := range o.loadBalancerIDs {
+		lb, err := c.GetLoadBalancer(ctx, id, notFound)
+		if err != nil {
+			return nil, fmt.Errorf("error getting loadbalancer %s: %w", id, err)
+		}
+		if lb.Status.Code != 0 {
+			continue
+		}
+		snap.LoadBalancers = append(snap.LoadBalancers, *lb)
+
+		targets, err := c.ListLoadBalancerTargets(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error listing targets of loadbalancer %s: %w", id, err)
+		}
+		snap.LoadBalancerTargets = append(snap.LoadBalancerTargets, targets.Items...)
+	}
+
+	sortedVNIs := make([]uint32, 0, len(vnis))
+	for vni := range vnis {
+		sortedVNIs = append(sortedVNIs, vni)
+	}
+	sort.Slice(sortedVNIs, func(i, j int) bool { return sortedVNIs[i] < sortedVNIs[j] })
+	for _, vni := range sortedVNIs {
+		routes, err := c.ListRoutes(ctx, vni)
+		if err != nil {
+			return nil, fmt.Errorf("error listing routes of vni %d: %w", vni, err)
+		}
+		snap.Routes = append(snap.Routes, routes.Items...)
+	}
+
+	return snap, nil
+}
+
+// Objects returns pointers to all objects of the snapshot.
+func (s *Snapshot) Objects() []api.Object {
+	var objs []api.Object
+	for i := range s.Interfaces {
+		objs = append(objs, &s.Interfaces[i])
+	}
+	for i := range s.VirtualIPs {
+		objs = append(objs, &s.VirtualIPs[i])
+	}
+	for i := range s.Nats {
+		objs = append(objs, &s.Nats[i])
+	}
+	for i := range s.NeighborNats {
+		objs = append(objs, &s.NeighborNats[i])
+	}
+	for i := range s.Prefixes {
+		objs = append(objs, &s.Prefixes[i])
+	}
+	for i := range s.LoadBalancers {
+		objs = append(objs, &s.LoadBalancers[i])
+	}
+	for i := range s.LoadBalancerTargets {
+		objs = append(objs, &s.LoadBalancerTargets[i])
+	}
+	for i := range s.LoadBalancerPrefixes {
+		objs = append(objs, &s.LoadBalancerPrefixes[i])
+	}
+	for i := range s.FirewallRules {
+		objs = append(objs, &s.FirewallRules[i])
+	}
+	for i := range s.Routes {
+		objs = append(objs, &s.Routes[i])
+	}
+	return objs
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutil starts a real dpservice for integration tests, either from its test
+// container or from a local binary, and waits until it serves gRPC.
+//
+//	dp, err := testutil.Start(ctx, testutil.Options{})
+//	if err != nil { ... }
+//	defer dp.Stop()
+//	_, err = dp.Client.CreateInterface(ctx, iface)
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+const (
+	// DefaultImage is the dpservice test container used unless Options.Binary is set.
+	DefaultImage = "ghcr.io/ironcore-dev/dpservice-tester:main"
+	// DefaultPort is the gRPC port of dpservice.
+	DefaultPort = 1337
+	// DefaultReadyTimeout bounds the wait for dpservice to serve gRPC.
+	DefaultReadyTimeout = time.Minute
+)
+
+// readyInterval is the delay between readiness checks.
+var readyInterval = 500 * time.Millisecond
+
+// Options configure how dpservice is started. Zero fields select the defaults CI uses.
+type Options struct {
+	// Image is the container image to run. Defaults to DefaultImage.
+	Image string
+	// Binary runs the given dpservice executable instead of a container.
+	Binary string
+	// Args are the flags passed to dpservice. Defaults to --no-init.
+	Args []string
+	// Port is the local port dpservice serves gRPC on. Defaults to DefaultPort.
+	Port int
+	// ReadyTimeout bounds the wait for dpservice to serve gRPC. Defaults to DefaultReadyTimeout.
+	ReadyTimeout time.Duration
+	// Docker is the docker compatible CLI used to run the container. Defaults to docker.
+	Docker string
+}
+
+func (o *Options) setDefaults() {
+	if o.Image == "" {
+		o.Image = DefaultImage
+	}
+	if o.Args == nil {
+		o.Args = []string{"--no-init"}
+	}
+	if o.Port == 0 {
+		o.Port = DefaultPort
+	}
+	if o.ReadyTimeout == 0 {
+		o.ReadyTimeout = DefaultReadyTimeout
+	}
+	if o.Docker == "" {
+		o.Docker = "docker"
+	}
+}
+
+// DPService is a running dpservice instance.
+type DPService struct {
+	// Addr is the gRPC address of dpservice.
+	Addr string
+	// Client is connected to dpservice.
+	Client *client.ConnectedClient
+
+	docker    string
+	container string
+	cmd       *exec.Cmd
+}
+
+// Start starts dpservice and waits until it answers CheckInitialized. dpservice is stopped
+// again if it does not get ready.
+func Start(ctx context.Context, opts Options) (*DPService, error) {
+	opts.setDefaults()
+	dp := &DPService{
+		Addr:   "127.0.0.1:" + strconv.Itoa(opts.Port),
+		docker: opts.Docker,
+	}
+
+	if opts.Binary != "" {
+		args := append(slices.Clone(opts.Args), "--grpc-port", strconv.Itoa(opts.Port))
+		dp.cmd = exec.Command(opts.Binary, args...)
+		if err := dp.cmd.Start(); err != nil {
+			return nil, fmt.Errorf("error starting %s: %w", opts.Binary, err)
+		}
+	} else {
+		args := []string{
+			"run", "--detach", "--rm", "--privileged",
+			"--entrypoint", "./dp_service.py",
+			"--publish", fmt.Sprintf("%d:%d", opts.Port, DefaultPort),
+			"--mount", "type=bind,source=/dev/hugepages,target=/dev/hugepages",
+			opts.Image,
+		}
+		out, err := run(ctx, opts.Docker, append(args, opts.Args...)...)
+		if err != nil {
+			return nil, fmt.Errorf("error starting container %s: %w", opts.Image, err)
+		}
+		dp.container = strings.TrimSpace(out)
+	}
+
+	if err := dp.waitReady(ctx, opts.ReadyTimeout); err != nil {
+		_ = dp.Stop()
+		return nil, err
+	}
+	return dp, nil
+}
+
+// waitReady connects to dpservice and polls CheckInitialized until it is answered. A dpservice
+// status error counts as ready since dpservice started with --no-init is uninitialized.
+func (dp *DPService) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c, err := client.Dial(ctx, dp.Addr)
+	if err != nil {
+		return fmt.Errorf("error connecting to dpservice: %w", err)
+	}
+	for {
+		initialized, err := c.CheckInitialized(ctx)
+		if err == nil || initialized.Status.Code != 0 {
+			dp.Client = c
+			return nil
+		}
+
+		timer := time.NewTimer(readyInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			_ = c.Close()
+			return fmt.Errorf("dpservice at %s did not get ready: %w", dp.Addr, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// Stop closes the client and stops dpservice.
+func (dp *DPService) Stop() error {
+	if dp.Client != nil {
+		_ = dp.Client.Close()
+	}
+	switch {
+	case dp.container != "":
+		if _, err := run(context.Background(), dp.docker, "stop", dp.container); err != nil {
+			return fmt.Errorf("error stopping container %s: %w", dp.container, err)
+		}
+	case dp.cmd != nil:
+		if err := dp.cmd.Process.Kill(); err != nil {
+			return fmt.Errorf("error stopping dpservice: %w", err)
+		}
+		_ = dp.cmd.Wait()
+	}
+	return nil
+}
+
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
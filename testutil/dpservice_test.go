@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ironcore-dev/dpservice-go/simulator"
+)
+
+var _ = Describe("DPService", func() {
+	BeforeEach(func() {
+		interval := readyInterval
+		readyInterval = 10 * time.Millisecond
+		DeferCleanup(func() { readyInterval = interval })
+	})
+
+	It("should consider an uninitialized dpservice ready", func() {
+		sim, err := simulator.Start("")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sim.Stop)
+
+		dp := &DPService{Addr: sim.Addr()}
+		Expect(dp.waitReady(context.TODO(), time.Second)).To(Succeed())
+		Expect(dp.Client).NotTo(BeNil())
+		Expect(dp.Stop()).To(Succeed())
+	})
+
+	It("should time out if dpservice does not serve", func() {
+		dp := &DPService{Addr: "127.0.0.1:1"}
+		Expect(dp.waitReady(context.TODO(), 100*time.Millisecond)).To(MatchError(ContainSubstring("did not get ready")))
+		Expect(dp.Client).To(BeNil())
+	})
+
+	It("should fail if the binary cannot be started", func() {
+		_, err := Start(context.TODO(), Options{Binary: "/nonexistent/dp_service"})
+		Expect(err).To(MatchError(ContainSubstring("error starting")))
+	})
+})
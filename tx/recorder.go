@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tx undoes the operations of a scope that failed halfway. dpservice has no
+// transactions, so a Recorder records the inverse of every successful mutation and runs them
+// in reverse order on rollback.
+//
+//	r := &tx.Recorder{}
+//	if _, err := c.CreateInterface(ctx, iface); err != nil {
+//		return err
+//	}
+//	r.Record("interface "+iface.ID, func(ctx context.Context) error {
+//		_, err := c.DeleteInterface(ctx, iface.ID)
+//		return err
+//	})
+//	if _, err := c.CreateVirtualIP(ctx, vip); err != nil {
+//		return errors.Join(err, r.Rollback(ctx))
+//	}
+//	r.Commit()
+package tx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// UndoFunc reverts a single operation.
+type UndoFunc func(ctx context.Context) error
+
+type entry struct {
+	description string
+	undo        UndoFunc
+}
+
+// Recorder records the inverse operations of a scope. The zero value is ready to use and
+// safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+// Record records undo as the inverse of an operation that succeeded. description names the
+// object of the operation in rollback errors, e.g. "Interface vm1".
+func (r *Recorder) Record(description string, undo UndoFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry{description: description, undo: undo})
+}
+
+// Len returns the number of recorded operations.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// Rollback runs the recorded inverse operations in reverse order and forgets them. All inverse
+// operations are run even if some fail, their errors are joined.
+func (r *Recorder) Rollback(ctx context.Context) error {
+	r.mu.Lock()
+	entries := r.entries
+	r.entries = nil
+	r.mu.Unlock()
+
+	var errs []error
+	for i := len(entries) - 1; i >= 0; i-- {
+		if err := entries[i].undo(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error rolling back %s: %w", entries[i].description, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Commit forgets the recorded operations, they are kept as they are.
+func (r *Recorder) Commit() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// Do runs fn with a new Recorder, rolling back the operations recorded by fn if it fails.
+// The error of fn is returned joined with the errors of the rollback.
+func Do(ctx context.Context, fn func(r *Recorder) error) error {
+	r := &Recorder{}
+	if err := fn(r); err != nil {
+		return errors.Join(err, r.Rollback(ctx))
+	}
+	r.Commit()
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tx
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	ctx := context.TODO()
+
+	var undone []string
+	undo := func(name string, err error) UndoFunc {
+		return func(context.Context) error {
+			undone = append(undone, name)
+			return err
+		}
+	}
+
+	BeforeEach(func() {
+		undone = nil
+	})
+
+	It("should roll back in reverse order and continue on errors", func() {
+		r := &Recorder{}
+		r.Record("Interface vm1", undo("vm1", nil))
+		r.Record("VirtualIP vm1", undo("vip", errors.New("boom")))
+		r.Record("Prefix vm1", undo("prefix", nil))
+		Expect(r.Len()).To(Equal(3))
+
+		Expect(r.Rollback(ctx)).To(MatchError("error rolling back VirtualIP vm1: boom"))
+		Expect(undone).To(Equal([]string{"prefix", "vip", "vm1"}))
+		Expect(r.Len()).To(BeZero())
+	})
+
+	It("should forget committed operations", func() {
+		r := &Recorder{}
+		r.Record("Interface vm1", undo("vm1", nil))
+		r.Commit()
+		Expect(r.Rollback(ctx)).To(Succeed())
+		Expect(undone).To(BeEmpty())
+	})
+
+	It("should roll back failed scopes", func() {
+		err := Do(ctx, func(r *Recorder) error {
+			r.Record("Interface vm1", undo("vm1", nil))
+			return errors.New("error creating VirtualIP vm1")
+		})
+		Expect(err).To(MatchError("error creating VirtualIP vm1"))
+		Expect(undone).To(Equal([]string{"vm1"}))
+
+		undone = nil
+		Expect(Do(ctx, func(r *Recorder) error {
+			r.Record("Interface vm1", undo("vm1", nil))
+			return nil
+		})).To(Succeed())
+		Expect(undone).To(BeEmpty())
+	})
+})